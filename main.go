@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/key7men/mag/pkg/logger"
 	"github.com/key7men/mag/server"
+	"github.com/key7men/mag/server/schema"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,10 +25,14 @@ func main() {
 	app.Usage = "RBAC scaffolding based on GIN + GORM + REDIS + CASBIN + WIRE."
 	app.Commands = []*cli.Command{
 		newWebCmd(ctx),
+		newOpenAPICmd(),
+		newGenCmd(),
+		newRBACCmd(ctx),
 	}
 	err := app.Run(os.Args)
 	if err != nil {
 		logger.Errorf(ctx, err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -67,3 +74,149 @@ func newWebCmd(ctx context.Context) *cli.Command {
 		},
 	}
 }
+
+func newOpenAPICmd() *cli.Command {
+	return &cli.Command{
+		Name:  "openapi",
+		Usage: "生成OpenAPI文档(路由缺少文档时返回错误)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "输出文件(为空时输出到标准输出)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			buf, err := server.GenerateOpenAPI(VERSION)
+			if err != nil {
+				return err
+			}
+
+			if out := c.String("out"); out != "" {
+				return ioutil.WriteFile(out, buf, 0644)
+			}
+			_, err = os.Stdout.Write(buf)
+			return err
+		},
+	}
+}
+
+func newGenCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "gen",
+		Usage: "生成代码",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "client",
+				Usage: "根据路由及接口文档生成Go及TypeScript客户端",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "go-out",
+						Value: "./pkg/client",
+						Usage: "Go客户端包的输出目录(为空时不生成)",
+					},
+					&cli.StringFlag{
+						Name:  "go-package",
+						Value: "client",
+						Usage: "Go客户端包名",
+					},
+					&cli.StringFlag{
+						Name:  "ts-out",
+						Value: "./web/src/app/core/api",
+						Usage: "TypeScript接口服务的输出目录(为空时不生成)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					return server.GenerateClient(server.ClientOptions{
+						GoOut:     c.String("go-out"),
+						GoPackage: c.String("go-package"),
+						TSOut:     c.String("ts-out"),
+					})
+				},
+			},
+		},
+	}
+}
+
+func newRBACCmd(ctx context.Context) *cli.Command {
+	confFlag := &cli.StringFlag{
+		Name:     "conf",
+		Aliases:  []string{"c"},
+		Usage:    "配置文件(.json,.yaml,.toml)",
+		Required: true,
+	}
+	formatFlag := &cli.StringFlag{
+		Name:  "format",
+		Usage: "文件格式(yaml或json，为空时按文件扩展名识别，默认为yaml)",
+	}
+
+	return &cli.Command{
+		Name:  "rbac",
+		Usage: "导出及导入菜单、动作、资源、角色及授权(用于在环境之间迁移权限配置)",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "export",
+				Usage: "导出当前的权限配置",
+				Flags: []cli.Flag{
+					confFlag,
+					formatFlag,
+					&cli.StringFlag{
+						Name:    "out",
+						Aliases: []string{"o"},
+						Usage:   "输出文件(为空时输出到标准输出)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					return server.ExportRBAC(ctx, server.RBACOptions{
+						ConfigFile: c.String("conf"),
+						File:       c.String("out"),
+						Format:     c.String("format"),
+					})
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "按菜单路径、动作编号及角色名称对比并导入权限配置(重复导入不产生变更)",
+				Flags: []cli.Flag{
+					confFlag,
+					formatFlag,
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "导入文件(为空时从标准输入读取)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "仅输出差异，不修改数据",
+					},
+					&cli.BoolFlag{
+						Name:  "prune",
+						Usage: "删除文件中不存在的菜单、动作、资源、角色及授权",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					result, err := server.ImportRBAC(ctx, server.RBACOptions{
+						ConfigFile: c.String("conf"),
+						File:       c.String("file"),
+						Format:     c.String("format"),
+						DryRun:     c.Bool("dry-run"),
+						Prune:      c.Bool("prune"),
+					})
+					if err != nil {
+						return err
+					}
+
+					for _, item := range result.Changes {
+						if item.Op == schema.RBACOpUpdate {
+							fmt.Printf("%-6s %-8s %s (%s -> %s)\n", item.Op, item.Kind, item.Key, item.From, item.To)
+							continue
+						}
+						fmt.Printf("%-6s %-8s %s\n", item.Op, item.Kind, item.Key)
+					}
+					fmt.Printf("新建：%d，更新：%d，删除：%d，已应用：%v\n", result.Created, result.Updated, result.Deleted, result.Applied)
+					return nil
+				},
+			},
+		},
+	}
+}
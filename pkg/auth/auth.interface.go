@@ -12,14 +12,14 @@ var (
 
 // Auther 认证接口
 type Auther interface {
-	// 生成令牌
-	GenerateToken(ctx context.Context, userID string) (TokenInfo, error)
+	// 生成令牌(tenantID为用户所属的租户，可为空)
+	GenerateToken(ctx context.Context, userID, tenantID string) (TokenInfo, error)
 
 	// 销毁令牌
 	DestroyToken(ctx context.Context, accessToken string) error
 
-	// 解析用户ID
-	ParseUserID(ctx context.Context, accessToken string) (string, error)
+	// 解析用户ID及租户ID
+	ParseSubject(ctx context.Context, accessToken string) (userID, tenantID string, err error)
 
 	// 释放资源
 	Release() error
@@ -82,16 +82,25 @@ type JWTAuth struct {
 	store store.Storer
 }
 
+// 令牌声明(在标准声明的基础上增加租户ID)
+type claims struct {
+	jwt.StandardClaims
+	TenantID string `json:"tid,omitempty"`
+}
+
 // GenerateToken 生成令牌
-func (a *JWTAuth) GenerateToken(ctx context.Context, userID string) (auth.TokenInfo, error) {
+func (a *JWTAuth) GenerateToken(ctx context.Context, userID, tenantID string) (auth.TokenInfo, error) {
 	now := time.Now()
 	expiresAt := now.Add(time.Duration(a.opts.expired) * time.Second).Unix()
 
-	token := jwt.NewWithClaims(a.opts.signingMethod, &jwt.StandardClaims{
-		IssuedAt:  now.Unix(),
-		ExpiresAt: expiresAt,
-		NotBefore: now.Unix(),
-		Subject:   userID,
+	token := jwt.NewWithClaims(a.opts.signingMethod, &claims{
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt,
+			NotBefore: now.Unix(),
+			Subject:   userID,
+		},
+		TenantID: tenantID,
 	})
 
 	tokenString, err := token.SignedString(a.opts.signingKey)
@@ -108,15 +117,15 @@ func (a *JWTAuth) GenerateToken(ctx context.Context, userID string) (auth.TokenI
 }
 
 // 解析令牌
-func (a *JWTAuth) parseToken(tokenString string) (*jwt.StandardClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, a.opts.keyfunc)
+func (a *JWTAuth) parseToken(tokenString string) (*claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &claims{}, a.opts.keyfunc)
 	if err != nil {
 		return nil, err
 	} else if !token.Valid {
 		return nil, auth.ErrInvalidToken
 	}
 
-	return token.Claims.(*jwt.StandardClaims), nil
+	return token.Claims.(*claims), nil
 }
 
 func (a *JWTAuth) callStore(fn func(store.Storer) error) error {
@@ -136,23 +145,23 @@ func (a *JWTAuth) DestroyToken(ctx context.Context, tokenString string) error {
 	// 如果设定了存储，则将未过期的令牌放入
 	return a.callStore(func(store store.Storer) error {
 		expired := time.Unix(claims.ExpiresAt, 0).Sub(time.Now())
-		return store.Set(tokenString, expired)
+		return store.Set(ctx, tokenString, expired)
 	})
 }
 
-// ParseUserID 解析用户ID
-func (a *JWTAuth) ParseUserID(ctx context.Context, tokenString string) (string, error) {
+// ParseSubject 解析用户ID及租户ID
+func (a *JWTAuth) ParseSubject(ctx context.Context, tokenString string) (string, string, error) {
 	if tokenString == "" {
-		return "", auth.ErrInvalidToken
+		return "", "", auth.ErrInvalidToken
 	}
 
 	claims, err := a.parseToken(tokenString)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	err = a.callStore(func(store store.Storer) error {
-		if exists, err := store.Check(tokenString); err != nil {
+		if exists, err := store.Check(ctx, tokenString); err != nil {
 			return err
 		} else if exists {
 			return auth.ErrInvalidToken
@@ -160,10 +169,10 @@ func (a *JWTAuth) ParseUserID(ctx context.Context, tokenString string) (string,
 		return nil
 	})
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	return claims.Subject, nil
+	return claims.Subject, claims.TenantID, nil
 }
 
 // Release 释放资源
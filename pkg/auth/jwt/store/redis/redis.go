@@ -1,10 +1,12 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis"
+	"github.com/key7men/mag/pkg/trace"
 )
 
 // Config redis配置参数
@@ -60,19 +62,27 @@ type Store struct {
 	prefix string
 }
 
+// 单机客户端绑定上下文，记录命令的跟踪单元
+func (s *Store) client(ctx context.Context) redisClienter {
+	if cli, ok := s.cli.(*redis.Client); ok {
+		return trace.RedisWithContext(ctx, cli)
+	}
+	return s.cli
+}
+
 func (s *Store) wrapperKey(key string) string {
 	return fmt.Sprintf("%s%s", s.prefix, key)
 }
 
 // Set ...
-func (s *Store) Set(tokenString string, expiration time.Duration) error {
-	cmd := s.cli.Set(s.wrapperKey(tokenString), "1", expiration)
+func (s *Store) Set(ctx context.Context, tokenString string, expiration time.Duration) error {
+	cmd := s.client(ctx).Set(s.wrapperKey(tokenString), "1", expiration)
 	return cmd.Err()
 }
 
 // Delete ...
-func (s *Store) Delete(tokenString string) error {
-	cmd := s.cli.Del(tokenString)
+func (s *Store) Delete(ctx context.Context, tokenString string) error {
+	cmd := s.client(ctx).Del(tokenString)
 	if err := cmd.Err(); err != nil {
 		return err
 	}
@@ -80,8 +90,8 @@ func (s *Store) Delete(tokenString string) error {
 }
 
 // Check ...
-func (s *Store) Check(tokenString string) (bool, error) {
-	cmd := s.cli.Exists(s.wrapperKey(tokenString))
+func (s *Store) Check(ctx context.Context, tokenString string) (bool, error) {
+	cmd := s.client(ctx).Exists(s.wrapperKey(tokenString))
 	if err := cmd.Err(); err != nil {
 		return false, err
 	}
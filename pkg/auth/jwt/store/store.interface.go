@@ -1,15 +1,16 @@
 package store
 
 import (
+	"context"
 	"time"
 )
 
 // Storer 令牌存储接口
 type Storer interface {
 	// 存储令牌数据，并指定到期时间
-	Set(tokenString string, expiration time.Duration) error
+	Set(ctx context.Context, tokenString string, expiration time.Duration) error
 	// 检查令牌是否存在
-	Check(tokenString string) (bool, error)
+	Check(ctx context.Context, tokenString string) (bool, error)
 	// 关闭存储
 	Close() error
 }
@@ -0,0 +1,1652 @@
+// Code generated by mag gen client. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+)
+
+// Audit 对应服务端的schema.Audit
+type Audit struct {
+	ID         string         `json:"id"`
+	EntityType string         `json:"entity_type"`
+	EntityID   string         `json:"entity_id"`
+	Action     string         `json:"action"`
+	ActorID    string         `json:"actor_id"`
+	TraceID    string         `json:"trace_id"`
+	Changes    []*AuditChange `json:"changes"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// AuditChange 对应服务端的schema.AuditChange
+type AuditChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// BulkIDParam 对应服务端的schema.BulkIDParam
+type BulkIDParam struct {
+	IDs []string `json:"ids"`
+}
+
+// BulkItemResult 对应服务端的schema.BulkItemResult
+type BulkItemResult struct {
+	Index  int        `json:"index"`
+	ID     string     `json:"id,omitempty"`
+	Status string     `json:"status"`
+	Error  *ErrorItem `json:"error,omitempty"`
+}
+
+// BulkMenuCreateParam 对应服务端的schema.BulkMenuCreateParam
+type BulkMenuCreateParam struct {
+	Items []*Menu `json:"items"`
+}
+
+// BulkResult 对应服务端的schema.BulkResult
+type BulkResult struct {
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Items     []*BulkItemResult `json:"items"`
+}
+
+// BulkRoleCreateParam 对应服务端的schema.BulkRoleCreateParam
+type BulkRoleCreateParam struct {
+	Items []*Role `json:"items"`
+}
+
+// BulkUserCreateParam 对应服务端的schema.BulkUserCreateParam
+type BulkUserCreateParam struct {
+	Items []*User `json:"items"`
+}
+
+// BulkUserRoleParam 对应服务端的schema.BulkUserRoleParam
+type BulkUserRoleParam struct {
+	IDs     []string `json:"ids"`
+	RoleIDs []string `json:"role_ids"`
+	Replace bool     `json:"replace"`
+}
+
+// Demo 对应服务端的schema.Demo
+type Demo struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Memo      string    `json:"memo"`
+	Status    int       `json:"status"`
+	Creator   string    `json:"creator"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ErrorItem 对应服务端的schema.ErrorItem
+type ErrorItem struct {
+	Code    int    `json:"code"`
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+// HealthCheck 对应服务端的schema.HealthCheck
+type HealthCheck struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration int64  `json:"duration_ms"`
+}
+
+// HealthResult 对应服务端的schema.HealthResult
+type HealthResult struct {
+	Status string         `json:"status"`
+	Checks []*HealthCheck `json:"checks,omitempty"`
+}
+
+// IDResult 对应服务端的schema.IDResult
+type IDResult struct {
+	ID string `json:"id"`
+}
+
+// ImportResult 对应服务端的schema.ImportResult
+type ImportResult struct {
+	DryRun  bool               `json:"dry_run"`
+	Applied bool               `json:"applied"`
+	Total   int                `json:"total"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Failed  int                `json:"failed"`
+	Rows    []*ImportRowResult `json:"rows"`
+}
+
+// ImportRowResult 对应服务端的schema.ImportRowResult
+type ImportRowResult struct {
+	Row    int           `json:"row"`
+	ID     string        `json:"id,omitempty"`
+	Key    string        `json:"key"`
+	Action string        `json:"action,omitempty"`
+	Status string        `json:"status"`
+	Errors []*FieldError `json:"errors,omitempty"`
+}
+
+// Log 对应服务端的schema.Log
+type Log struct {
+	ID           string    `json:"id"`
+	Level        string    `json:"level"`
+	Message      string    `json:"message"`
+	TraceID      string    `json:"trace_id"`
+	UserID       string    `json:"user_id"`
+	SpanTitle    string    `json:"span_title"`
+	SpanFunction string    `json:"span_function"`
+	Data         string    `json:"data"`
+	Version      string    `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LoginCaptcha 对应服务端的schema.LoginCaptcha
+type LoginCaptcha struct {
+	CaptchaID string `json:"captcha_id"`
+}
+
+// LoginParam 对应服务端的schema.LoginParam
+type LoginParam struct {
+	UserName    string `json:"username"`
+	Password    string `json:"password"`
+	CaptchaID   string `json:"captcha_id"`
+	CaptchaCode string `json:"captcha_code"`
+}
+
+// LoginTokenInfo 对应服务端的schema.LoginTokenInfo
+type LoginTokenInfo struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+// Menu 对应服务端的schema.Menu
+type Menu struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Sequence   int           `json:"sequence"`
+	Icon       string        `json:"icon"`
+	Router     string        `json:"router"`
+	ParentID   string        `json:"parent_id"`
+	ParentPath string        `json:"parent_path"`
+	ShowStatus int           `json:"show_status"`
+	Status     int           `json:"status"`
+	Memo       string        `json:"memo"`
+	Creator    string        `json:"creator"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	Actions    []*MenuAction `json:"actions"`
+}
+
+// MenuAction 对应服务端的schema.MenuAction
+type MenuAction struct {
+	ID        string                `json:"id"`
+	MenuID    string                `json:"menu_id"`
+	Code      string                `json:"code"`
+	Name      string                `json:"name"`
+	Resources []*MenuActionResource `json:"resources"`
+}
+
+// MenuActionResource 对应服务端的schema.MenuActionResource
+type MenuActionResource struct {
+	ID       string `json:"id"`
+	ActionID string `json:"action_id"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+}
+
+// MenuTree 对应服务端的schema.MenuTree
+type MenuTree struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Icon       string        `json:"icon"`
+	Router     string        `json:"router"`
+	ParentID   string        `json:"parent_id"`
+	ParentPath string        `json:"parent_path"`
+	Sequence   int           `json:"sequence"`
+	ShowStatus int           `json:"show_status"`
+	Status     int           `json:"status"`
+	Actions    []*MenuAction `json:"actions"`
+	Children   *[]*MenuTree  `json:"children,omitempty"`
+}
+
+// PaginationResult 对应服务端的schema.PaginationResult
+type PaginationResult struct {
+	Total      int    `json:"total"`
+	Current    uint   `json:"current"`
+	PageSize   uint   `json:"pageSize"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
+
+// RBACAction 对应服务端的schema.RBACAction
+type RBACAction struct {
+	Code      string          `json:"code"`
+	Name      string          `json:"name"`
+	Resources []*RBACResource `json:"resources,omitempty"`
+}
+
+// RBACChange 对应服务端的schema.RBACChange
+type RBACChange struct {
+	Kind string `json:"kind"`
+	Op   string `json:"op"`
+	Key  string `json:"key"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// RBACDocument 对应服务端的schema.RBACDocument
+type RBACDocument struct {
+	Menus []*RBACMenu `json:"menus"`
+	Roles []*RBACRole `json:"roles"`
+}
+
+// RBACGrant 对应服务端的schema.RBACGrant
+type RBACGrant struct {
+	Menu    string   `json:"menu"`
+	Actions []string `json:"actions"`
+}
+
+// RBACImportResult 对应服务端的schema.RBACImportResult
+type RBACImportResult struct {
+	DryRun  bool          `json:"dry_run"`
+	Applied bool          `json:"applied"`
+	Created int           `json:"created"`
+	Updated int           `json:"updated"`
+	Deleted int           `json:"deleted"`
+	Changes []*RBACChange `json:"changes"`
+}
+
+// RBACMenu 对应服务端的schema.RBACMenu
+type RBACMenu struct {
+	Name       string        `json:"name"`
+	Icon       string        `json:"icon,omitempty"`
+	Router     string        `json:"router,omitempty"`
+	Sequence   int           `json:"sequence"`
+	ShowStatus int           `json:"show_status"`
+	Status     int           `json:"status"`
+	Memo       string        `json:"memo,omitempty"`
+	Actions    []*RBACAction `json:"actions,omitempty"`
+	Children   []*RBACMenu   `json:"children,omitempty"`
+}
+
+// RBACResource 对应服务端的schema.RBACResource
+type RBACResource struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// RBACRole 对应服务端的schema.RBACRole
+type RBACRole struct {
+	Name     string       `json:"name"`
+	Sequence int          `json:"sequence"`
+	Status   int          `json:"status"`
+	Memo     string       `json:"memo,omitempty"`
+	Grants   []*RBACGrant `json:"grants,omitempty"`
+}
+
+// ReloadResult 对应服务端的config.ReloadResult
+type ReloadResult struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required"`
+}
+
+// Role 对应服务端的schema.Role
+type Role struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Sequence  int         `json:"sequence"`
+	Memo      string      `json:"memo"`
+	Status    int         `json:"status"`
+	Creator   string      `json:"creator"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	RoleMenus []*RoleMenu `json:"role_menus"`
+}
+
+// RoleMenu 对应服务端的schema.RoleMenu
+type RoleMenu struct {
+	ID       string `json:"id"`
+	RoleID   string `json:"role_id"`
+	MenuID   string `json:"menu_id"`
+	ActionID string `json:"action_id"`
+}
+
+// Tenant 对应服务端的schema.Tenant
+type Tenant struct {
+	ID        string       `json:"id"`
+	Code      string       `json:"code"`
+	Name      string       `json:"name"`
+	Domain    string       `json:"domain"`
+	Status    int          `json:"status"`
+	Memo      string       `json:"memo"`
+	AdminID   string       `json:"admin_id"`
+	Creator   string       `json:"creator"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	Admin     *TenantAdmin `json:"admin,omitempty"`
+}
+
+// TenantAdmin 对应服务端的schema.TenantAdmin
+type TenantAdmin struct {
+	UserName string `json:"user_name"`
+	RealName string `json:"real_name"`
+	Password string `json:"password"`
+	Phone    string `json:"phone"`
+	Email    string `json:"email"`
+}
+
+// UpdatePasswordParam 对应服务端的schema.UpdatePasswordParam
+type UpdatePasswordParam struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// User 对应服务端的schema.User
+type User struct {
+	ID        string      `json:"id"`
+	UserName  string      `json:"user_name"`
+	RealName  string      `json:"real_name"`
+	Password  string      `json:"password"`
+	Phone     string      `json:"phone"`
+	Email     string      `json:"email"`
+	Status    int         `json:"status"`
+	Creator   string      `json:"creator"`
+	CreatedAt time.Time   `json:"created_at"`
+	UserRoles []*UserRole `json:"user_roles"`
+}
+
+// UserLoginInfo 对应服务端的schema.UserLoginInfo
+type UserLoginInfo struct {
+	UserID   string  `json:"user_id"`
+	UserName string  `json:"username"`
+	RealName string  `json:"real_name"`
+	Roles    []*Role `json:"roles"`
+}
+
+// UserRole 对应服务端的schema.UserRole
+type UserRole struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	RoleID string `json:"role_id"`
+}
+
+// UserShow 对应服务端的schema.UserShow
+type UserShow struct {
+	ID        string    `json:"id"`
+	UserName  string    `json:"user_name"`
+	RealName  string    `json:"real_name"`
+	Phone     string    `json:"phone"`
+	Email     string    `json:"email"`
+	Status    int       `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	Roles     []*Role   `json:"roles"`
+}
+
+// Webhook 对应服务端的schema.Webhook
+type Webhook struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	Status    int       `json:"status"`
+	Memo      string    `json:"memo"`
+	Creator   string    `json:"creator"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery 对应服务端的schema.WebhookDelivery
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         int             `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	NextRetryAt    time.Time       `json:"next_retry_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookSecretResult 对应服务端的schema.WebhookSecretResult
+type WebhookSecretResult struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+// QueryFilter 通用过滤条件(filter[key][op]=value，未指定op时为eq)
+type QueryFilter struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// AuditPage Audit的分页数据
+type AuditPage struct {
+	List       []*Audit          `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// DemoPage Demo的分页数据
+type DemoPage struct {
+	List       []*Demo           `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// LogPage Log的分页数据
+type LogPage struct {
+	List       []*Log            `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// MenuPage Menu的分页数据
+type MenuPage struct {
+	List       []*Menu           `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// RolePage Role的分页数据
+type RolePage struct {
+	List       []*Role           `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// TenantPage Tenant的分页数据
+type TenantPage struct {
+	List       []*Tenant         `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// UserShowPage UserShow的分页数据
+type UserShowPage struct {
+	List       []*UserShow       `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// WebhookPage Webhook的分页数据
+type WebhookPage struct {
+	List       []*Webhook        `json:"list"`
+	Pagination *PaginationResult `json:"pagination,omitempty"`
+}
+
+// WebhookDeliveryPage WebhookDelivery的分页数据
+type WebhookDeliveryPage struct {
+	List       []*WebhookDelivery `json:"list"`
+	Pagination *PaginationResult  `json:"pagination,omitempty"`
+}
+
+// QueryAuditQuery QueryAudit的查询参数
+type QueryAuditQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	EntityType string
+	EntityID   string
+	ActorID    string
+	Action     string
+	StartTime  time.Time
+	EndTime    time.Time
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+}
+
+func (a *QueryAuditQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "entityType", a.EntityType)
+	addQuery(q, "entityID", a.EntityID)
+	addQuery(q, "actorID", a.ActorID)
+	addQuery(q, "action", a.Action)
+	addQuery(q, "startTime", a.StartTime)
+	addQuery(q, "endTime", a.EndTime)
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	return q
+}
+
+// QueryDemoQuery QueryDemo的查询参数
+type QueryDemoQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	QueryValue string
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+}
+
+func (a *QueryDemoQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "queryValue", a.QueryValue)
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	return q
+}
+
+// ExportDemoQuery ExportDemo的查询参数
+type ExportDemoQuery struct {
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+	QueryValue string
+	Status     int
+	Format     string
+}
+
+func (a *ExportDemoQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	addQuery(q, "format", a.Format)
+	return q
+}
+
+// QueryLogQuery QueryLog的查询参数
+type QueryLogQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	Levels     []string
+	TraceID    string
+	UserID     string
+	QueryValue string
+	StartTime  time.Time
+	EndTime    time.Time
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+}
+
+func (a *QueryLogQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "level", a.Levels)
+	addQuery(q, "traceID", a.TraceID)
+	addQuery(q, "userID", a.UserID)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "startTime", a.StartTime)
+	addQuery(q, "endTime", a.EndTime)
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	return q
+}
+
+// QueryMenuQuery QueryMenu的查询参数
+type QueryMenuQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	QueryValue string
+	ShowStatus int
+	Status     int
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+}
+
+func (a *QueryMenuQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "showStatus", a.ShowStatus)
+	addQuery(q, "status", a.Status)
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	return q
+}
+
+// QueryMenuTreeQuery QueryMenuTree的查询参数
+type QueryMenuTreeQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	QueryValue string
+	ShowStatus int
+	Status     int
+}
+
+func (a *QueryMenuTreeQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "showStatus", a.ShowStatus)
+	addQuery(q, "status", a.Status)
+	return q
+}
+
+// GetCaptchaPicQuery GetCaptchaPic的查询参数
+type GetCaptchaPicQuery struct {
+	ID     string
+	Reload string
+}
+
+func (a *GetCaptchaPicQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "id", a.ID)
+	addQuery(q, "reload", a.Reload)
+	return q
+}
+
+// ExportRBACQuery ExportRBAC的查询参数
+type ExportRBACQuery struct {
+	Format string
+}
+
+func (a *ExportRBACQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "format", a.Format)
+	return q
+}
+
+// ImportRBACQuery ImportRBAC的查询参数
+type ImportRBACQuery struct {
+	DryRun bool
+	Prune  bool
+}
+
+func (a *ImportRBACQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "dryRun", a.DryRun)
+	addQuery(q, "prune", a.Prune)
+	return q
+}
+
+// QueryRoleQuery QueryRole的查询参数
+type QueryRoleQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	QueryValue string
+	Status     int
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+}
+
+func (a *QueryRoleQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	return q
+}
+
+// ExportRoleQuery ExportRole的查询参数
+type ExportRoleQuery struct {
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+	QueryValue string
+	Status     int
+	Format     string
+}
+
+func (a *ExportRoleQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	addQuery(q, "format", a.Format)
+	return q
+}
+
+// QueryRoleSelectQuery QueryRoleSelect的查询参数
+type QueryRoleSelectQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	QueryValue string
+	Status     int
+}
+
+func (a *QueryRoleSelectQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	return q
+}
+
+// QueryTenantQuery QueryTenant的查询参数
+type QueryTenantQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	Code       string
+	QueryValue string
+	Status     int
+}
+
+func (a *QueryTenantQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "code", a.Code)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	return q
+}
+
+// QueryUserQuery QueryUser的查询参数
+type QueryUserQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	UserName   string
+	QueryValue string
+	Status     int
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+	RoleIDs    string
+}
+
+func (a *QueryUserQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "userName", a.UserName)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	addQuery(q, "roleIDs", a.RoleIDs)
+	return q
+}
+
+// ExportUserQuery ExportUser的查询参数
+type ExportUserQuery struct {
+	Filters    []*QueryFilter
+	Sort       string
+	Fields     string
+	UserName   string
+	QueryValue string
+	Status     int
+	RoleIDs    string
+	Format     string
+}
+
+func (a *ExportUserQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addFilters(q, a.Filters)
+	addQuery(q, "sort", a.Sort)
+	addQuery(q, "fields", a.Fields)
+	addQuery(q, "userName", a.UserName)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	addQuery(q, "roleIDs", a.RoleIDs)
+	addQuery(q, "format", a.Format)
+	return q
+}
+
+// ImportUserQuery ImportUser的查询参数
+type ImportUserQuery struct {
+	DryRun bool
+}
+
+func (a *ImportUserQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "dryRun", a.DryRun)
+	return q
+}
+
+// QueryWebhookQuery QueryWebhook的查询参数
+type QueryWebhookQuery struct {
+	Current    uint
+	PageSize   uint
+	Cursor     string
+	UseCursor  bool
+	NoCount    bool
+	QueryValue string
+	Status     int
+}
+
+func (a *QueryWebhookQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "queryValue", a.QueryValue)
+	addQuery(q, "status", a.Status)
+	return q
+}
+
+// QueryWebhookDeadLetterQuery QueryWebhookDeadLetter的查询参数
+type QueryWebhookDeadLetterQuery struct {
+	Current   uint
+	PageSize  uint
+	Cursor    string
+	UseCursor bool
+	NoCount   bool
+	EventType string
+	Status    int
+}
+
+func (a *QueryWebhookDeadLetterQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "eventType", a.EventType)
+	addQuery(q, "status", a.Status)
+	return q
+}
+
+// QueryWebhookDeliveryQuery QueryWebhookDelivery的查询参数
+type QueryWebhookDeliveryQuery struct {
+	Current   uint
+	PageSize  uint
+	Cursor    string
+	UseCursor bool
+	NoCount   bool
+	EventType string
+	Status    int
+}
+
+func (a *QueryWebhookDeliveryQuery) values() url.Values {
+	q := make(url.Values)
+	if a == nil {
+		return q
+	}
+	addQuery(q, "current", a.Current)
+	addQuery(q, "pageSize", a.PageSize)
+	addQuery(q, "cursor", a.Cursor)
+	addQuery(q, "useCursor", a.UseCursor)
+	addQuery(q, "noCount", a.NoCount)
+	addQuery(q, "eventType", a.EventType)
+	addQuery(q, "status", a.Status)
+	return q
+}
+
+// QueryAudit 查询审计记录(GET /api/v1/audits)
+func (c *Client) QueryAudit(ctx context.Context, query *QueryAuditQuery) (*AuditPage, error) {
+	result := new(AuditPage)
+	if err := c.do(ctx, "GET", "/api/v1/audits", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAudit 查询指定审计记录(GET /api/v1/audits/:id)
+func (c *Client) GetAudit(ctx context.Context, id string) (*Audit, error) {
+	result := new(Audit)
+	if err := c.do(ctx, "GET", "/api/v1/audits/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReloadConfig 重新加载配置文件(POST /api/v1/config.reload)
+func (c *Client) ReloadConfig(ctx context.Context) (*ReloadResult, error) {
+	result := new(ReloadResult)
+	if err := c.do(ctx, "POST", "/api/v1/config.reload", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryDemo 查询数据(GET /api/v1/demos)
+func (c *Client) QueryDemo(ctx context.Context, query *QueryDemoQuery) (*DemoPage, error) {
+	result := new(DemoPage)
+	if err := c.do(ctx, "GET", "/api/v1/demos", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateDemo 创建数据(POST /api/v1/demos)
+func (c *Client) CreateDemo(ctx context.Context, body *Demo) (*IDResult, error) {
+	result := new(IDResult)
+	if err := c.do(ctx, "POST", "/api/v1/demos", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportDemo 导出数据(GET /api/v1/demos.export)
+func (c *Client) ExportDemo(ctx context.Context, query *ExportDemoQuery) ([]byte, error) {
+	var result []byte
+	if err := c.do(ctx, "GET", "/api/v1/demos.export", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteDemo 删除数据(DELETE /api/v1/demos/:id)
+func (c *Client) DeleteDemo(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/demos/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetDemo 查询指定数据(GET /api/v1/demos/:id)
+func (c *Client) GetDemo(ctx context.Context, id string) (*Demo, error) {
+	result := new(Demo)
+	if err := c.do(ctx, "GET", "/api/v1/demos/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateDemo 更新数据(PUT /api/v1/demos/:id)
+func (c *Client) UpdateDemo(ctx context.Context, id string, body *Demo) error {
+	return c.do(ctx, "PUT", "/api/v1/demos/"+url.PathEscape(id), nil, body, nil)
+}
+
+// DisableDemo 禁用数据(PATCH /api/v1/demos/:id/disable)
+func (c *Client) DisableDemo(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/demos/"+url.PathEscape(id)+"/disable", nil, nil, nil)
+}
+
+// EnableDemo 启用数据(PATCH /api/v1/demos/:id/enable)
+func (c *Client) EnableDemo(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/demos/"+url.PathEscape(id)+"/enable", nil, nil, nil)
+}
+
+// QueryLog 查询日志(GET /api/v1/logs)
+func (c *Client) QueryLog(ctx context.Context, query *QueryLogQuery) (*LogPage, error) {
+	result := new(LogPage)
+	if err := c.do(ctx, "GET", "/api/v1/logs", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryLogTrace 查询跟踪ID的完整日志链路(GET /api/v1/logs/traces/:traceID)
+func (c *Client) QueryLogTrace(ctx context.Context, traceID string) ([]*Log, error) {
+	var result struct {
+		List []*Log `json:"list"`
+	}
+	if err := c.do(ctx, "GET", "/api/v1/logs/traces/"+url.PathEscape(traceID), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.List, nil
+}
+
+// QueryMenu 查询数据(GET /api/v1/menus)
+func (c *Client) QueryMenu(ctx context.Context, query *QueryMenuQuery) (*MenuPage, error) {
+	result := new(MenuPage)
+	if err := c.do(ctx, "GET", "/api/v1/menus", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateMenu 创建数据(POST /api/v1/menus)
+func (c *Client) CreateMenu(ctx context.Context, body *Menu) (*IDResult, error) {
+	result := new(IDResult)
+	if err := c.do(ctx, "POST", "/api/v1/menus", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkCreateMenu 批量创建数据(POST /api/v1/menus.bulk/create)
+func (c *Client) BulkCreateMenu(ctx context.Context, body *BulkMenuCreateParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/menus.bulk/create", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDeleteMenu 批量删除数据(POST /api/v1/menus.bulk/delete)
+func (c *Client) BulkDeleteMenu(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/menus.bulk/delete", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDisableMenu 批量禁用数据(POST /api/v1/menus.bulk/disable)
+func (c *Client) BulkDisableMenu(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/menus.bulk/disable", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkEnableMenu 批量启用数据(POST /api/v1/menus.bulk/enable)
+func (c *Client) BulkEnableMenu(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/menus.bulk/enable", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryMenuTree 查询菜单树(GET /api/v1/menus.tree)
+func (c *Client) QueryMenuTree(ctx context.Context, query *QueryMenuTreeQuery) ([]*MenuTree, error) {
+	var result struct {
+		List []*MenuTree `json:"list"`
+	}
+	if err := c.do(ctx, "GET", "/api/v1/menus.tree", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.List, nil
+}
+
+// DeleteMenu 删除数据(DELETE /api/v1/menus/:id)
+func (c *Client) DeleteMenu(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/menus/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetMenu 查询指定数据(GET /api/v1/menus/:id)
+func (c *Client) GetMenu(ctx context.Context, id string) (*Menu, error) {
+	result := new(Menu)
+	if err := c.do(ctx, "GET", "/api/v1/menus/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateMenu 更新数据(PUT /api/v1/menus/:id)
+func (c *Client) UpdateMenu(ctx context.Context, id string, body *Menu) error {
+	return c.do(ctx, "PUT", "/api/v1/menus/"+url.PathEscape(id), nil, body, nil)
+}
+
+// DisableMenu 禁用数据(PATCH /api/v1/menus/:id/disable)
+func (c *Client) DisableMenu(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/menus/"+url.PathEscape(id)+"/disable", nil, nil, nil)
+}
+
+// EnableMenu 启用数据(PATCH /api/v1/menus/:id/enable)
+func (c *Client) EnableMenu(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/menus/"+url.PathEscape(id)+"/enable", nil, nil, nil)
+}
+
+// QueryCurrentMenuTree 查询当前用户菜单树(GET /api/v1/pub/current/menutree)
+func (c *Client) QueryCurrentMenuTree(ctx context.Context) ([]*MenuTree, error) {
+	var result struct {
+		List []*MenuTree `json:"list"`
+	}
+	if err := c.do(ctx, "GET", "/api/v1/pub/current/menutree", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.List, nil
+}
+
+// UpdatePassword 更新个人密码(PUT /api/v1/pub/current/password)
+func (c *Client) UpdatePassword(ctx context.Context, body *UpdatePasswordParam) error {
+	return c.do(ctx, "PUT", "/api/v1/pub/current/password", nil, body, nil)
+}
+
+// GetCurrentUser 获取当前用户信息(GET /api/v1/pub/current/user)
+func (c *Client) GetCurrentUser(ctx context.Context) (*UserLoginInfo, error) {
+	result := new(UserLoginInfo)
+	if err := c.do(ctx, "GET", "/api/v1/pub/current/user", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Login 用户登录(POST /api/v1/pub/login)
+func (c *Client) Login(ctx context.Context, body *LoginParam) (*LoginTokenInfo, error) {
+	result := new(LoginTokenInfo)
+	if err := c.do(ctx, "POST", "/api/v1/pub/login", nil, body, result); err != nil {
+		return nil, err
+	}
+	c.SetToken(result.AccessToken)
+	return result, nil
+}
+
+// GetCaptchaPic 获取验证码图片(GET /api/v1/pub/login/captcha)
+func (c *Client) GetCaptchaPic(ctx context.Context, query *GetCaptchaPicQuery) ([]byte, error) {
+	var result []byte
+	if err := c.do(ctx, "GET", "/api/v1/pub/login/captcha", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCaptchaID 获取验证码ID(GET /api/v1/pub/login/captchaid)
+func (c *Client) GetCaptchaID(ctx context.Context) (*LoginCaptcha, error) {
+	result := new(LoginCaptcha)
+	if err := c.do(ctx, "GET", "/api/v1/pub/login/captchaid", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Logout 用户登出(POST /api/v1/pub/login/exit)
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.do(ctx, "POST", "/api/v1/pub/login/exit", nil, nil, nil); err != nil {
+		return err
+	}
+	c.SetToken("")
+	return nil
+}
+
+// RefreshToken 刷新令牌(POST /api/v1/pub/refresh-token)
+func (c *Client) RefreshToken(ctx context.Context) (*LoginTokenInfo, error) {
+	result := new(LoginTokenInfo)
+	if err := c.do(ctx, "POST", "/api/v1/pub/refresh-token", nil, nil, result); err != nil {
+		return nil, err
+	}
+	c.SetToken(result.AccessToken)
+	return result, nil
+}
+
+// ExportRBAC 导出菜单、动作、资源、角色及授权(GET /api/v1/rbac.export)
+func (c *Client) ExportRBAC(ctx context.Context, query *ExportRBACQuery) ([]byte, error) {
+	var result []byte
+	if err := c.do(ctx, "GET", "/api/v1/rbac.export", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ImportRBAC 按业务标识对比并导入菜单、动作、资源、角色及授权(POST /api/v1/rbac.import)
+func (c *Client) ImportRBAC(ctx context.Context, query *ImportRBACQuery, body *RBACDocument) (*RBACImportResult, error) {
+	result := new(RBACImportResult)
+	if err := c.do(ctx, "POST", "/api/v1/rbac.import", query.values(), body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryRole 查询数据(GET /api/v1/roles)
+func (c *Client) QueryRole(ctx context.Context, query *QueryRoleQuery) (*RolePage, error) {
+	result := new(RolePage)
+	if err := c.do(ctx, "GET", "/api/v1/roles", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateRole 创建数据(POST /api/v1/roles)
+func (c *Client) CreateRole(ctx context.Context, body *Role) (*IDResult, error) {
+	result := new(IDResult)
+	if err := c.do(ctx, "POST", "/api/v1/roles", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkCreateRole 批量创建数据(POST /api/v1/roles.bulk/create)
+func (c *Client) BulkCreateRole(ctx context.Context, body *BulkRoleCreateParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/roles.bulk/create", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDeleteRole 批量删除数据(POST /api/v1/roles.bulk/delete)
+func (c *Client) BulkDeleteRole(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/roles.bulk/delete", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDisableRole 批量禁用数据(POST /api/v1/roles.bulk/disable)
+func (c *Client) BulkDisableRole(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/roles.bulk/disable", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkEnableRole 批量启用数据(POST /api/v1/roles.bulk/enable)
+func (c *Client) BulkEnableRole(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/roles.bulk/enable", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportRole 导出数据及菜单授权(GET /api/v1/roles.export)
+func (c *Client) ExportRole(ctx context.Context, query *ExportRoleQuery) ([]byte, error) {
+	var result []byte
+	if err := c.do(ctx, "GET", "/api/v1/roles.export", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryRoleSelect 查询选择数据(GET /api/v1/roles.select)
+func (c *Client) QueryRoleSelect(ctx context.Context, query *QueryRoleSelectQuery) ([]*Role, error) {
+	var result struct {
+		List []*Role `json:"list"`
+	}
+	if err := c.do(ctx, "GET", "/api/v1/roles.select", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.List, nil
+}
+
+// DeleteRole 删除数据(DELETE /api/v1/roles/:id)
+func (c *Client) DeleteRole(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/roles/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetRole 查询指定数据(GET /api/v1/roles/:id)
+func (c *Client) GetRole(ctx context.Context, id string) (*Role, error) {
+	result := new(Role)
+	if err := c.do(ctx, "GET", "/api/v1/roles/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateRole 更新数据(PUT /api/v1/roles/:id)
+func (c *Client) UpdateRole(ctx context.Context, id string, body *Role) error {
+	return c.do(ctx, "PUT", "/api/v1/roles/"+url.PathEscape(id), nil, body, nil)
+}
+
+// DisableRole 禁用数据(PATCH /api/v1/roles/:id/disable)
+func (c *Client) DisableRole(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/roles/"+url.PathEscape(id)+"/disable", nil, nil, nil)
+}
+
+// EnableRole 启用数据(PATCH /api/v1/roles/:id/enable)
+func (c *Client) EnableRole(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/roles/"+url.PathEscape(id)+"/enable", nil, nil, nil)
+}
+
+// QueryTenant 查询数据(GET /api/v1/tenants)
+func (c *Client) QueryTenant(ctx context.Context, query *QueryTenantQuery) (*TenantPage, error) {
+	result := new(TenantPage)
+	if err := c.do(ctx, "GET", "/api/v1/tenants", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateTenant 创建数据(同时开通租户管理员)(POST /api/v1/tenants)
+func (c *Client) CreateTenant(ctx context.Context, body *Tenant) (*IDResult, error) {
+	result := new(IDResult)
+	if err := c.do(ctx, "POST", "/api/v1/tenants", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteTenant 删除数据(DELETE /api/v1/tenants/:id)
+func (c *Client) DeleteTenant(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/tenants/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetTenant 查询指定数据(GET /api/v1/tenants/:id)
+func (c *Client) GetTenant(ctx context.Context, id string) (*Tenant, error) {
+	result := new(Tenant)
+	if err := c.do(ctx, "GET", "/api/v1/tenants/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateTenant 更新数据(PUT /api/v1/tenants/:id)
+func (c *Client) UpdateTenant(ctx context.Context, id string, body *Tenant) error {
+	return c.do(ctx, "PUT", "/api/v1/tenants/"+url.PathEscape(id), nil, body, nil)
+}
+
+// DisableTenant 禁用数据(PATCH /api/v1/tenants/:id/disable)
+func (c *Client) DisableTenant(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/tenants/"+url.PathEscape(id)+"/disable", nil, nil, nil)
+}
+
+// EnableTenant 启用数据(PATCH /api/v1/tenants/:id/enable)
+func (c *Client) EnableTenant(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/tenants/"+url.PathEscape(id)+"/enable", nil, nil, nil)
+}
+
+// QueryUser 查询数据(GET /api/v1/users)
+func (c *Client) QueryUser(ctx context.Context, query *QueryUserQuery) (*UserShowPage, error) {
+	result := new(UserShowPage)
+	if err := c.do(ctx, "GET", "/api/v1/users", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateUser 创建数据(POST /api/v1/users)
+func (c *Client) CreateUser(ctx context.Context, body *User) (*IDResult, error) {
+	result := new(IDResult)
+	if err := c.do(ctx, "POST", "/api/v1/users", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkCreateUser 批量创建数据(POST /api/v1/users.bulk/create)
+func (c *Client) BulkCreateUser(ctx context.Context, body *BulkUserCreateParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/users.bulk/create", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDeleteUser 批量删除数据(POST /api/v1/users.bulk/delete)
+func (c *Client) BulkDeleteUser(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/users.bulk/delete", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDisableUser 批量禁用数据(POST /api/v1/users.bulk/disable)
+func (c *Client) BulkDisableUser(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/users.bulk/disable", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkEnableUser 批量启用数据(POST /api/v1/users.bulk/enable)
+func (c *Client) BulkEnableUser(ctx context.Context, body *BulkIDParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/users.bulk/enable", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkAssignUserRoles 批量分配角色(POST /api/v1/users.bulk/roles)
+func (c *Client) BulkAssignUserRoles(ctx context.Context, body *BulkUserRoleParam) (*BulkResult, error) {
+	result := new(BulkResult)
+	if err := c.do(ctx, "POST", "/api/v1/users.bulk/roles", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportUser 导出数据(GET /api/v1/users.export)
+func (c *Client) ExportUser(ctx context.Context, query *ExportUserQuery) ([]byte, error) {
+	var result []byte
+	if err := c.do(ctx, "GET", "/api/v1/users.export", query.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ImportUser 导入数据(POST /api/v1/users.import)
+func (c *Client) ImportUser(ctx context.Context, query *ImportUserQuery, filename string, file io.Reader) (*ImportResult, error) {
+	result := new(ImportResult)
+	if err := c.do(ctx, "POST", "/api/v1/users.import", query.values(), &fileUpload{field: "file", filename: filename, reader: file}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteUser 删除数据(DELETE /api/v1/users/:id)
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/users/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetUser 查询指定数据(GET /api/v1/users/:id)
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	result := new(User)
+	if err := c.do(ctx, "GET", "/api/v1/users/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateUser 更新数据(PUT /api/v1/users/:id)
+func (c *Client) UpdateUser(ctx context.Context, id string, body *User) error {
+	return c.do(ctx, "PUT", "/api/v1/users/"+url.PathEscape(id), nil, body, nil)
+}
+
+// DisableUser 禁用数据(PATCH /api/v1/users/:id/disable)
+func (c *Client) DisableUser(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(id)+"/disable", nil, nil, nil)
+}
+
+// EnableUser 启用数据(PATCH /api/v1/users/:id/enable)
+func (c *Client) EnableUser(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/users/"+url.PathEscape(id)+"/enable", nil, nil, nil)
+}
+
+// QueryWebhook 查询数据(GET /api/v1/webhooks)
+func (c *Client) QueryWebhook(ctx context.Context, query *QueryWebhookQuery) (*WebhookPage, error) {
+	result := new(WebhookPage)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWebhook 创建数据(POST /api/v1/webhooks)
+func (c *Client) CreateWebhook(ctx context.Context, body *Webhook) (*WebhookSecretResult, error) {
+	result := new(WebhookSecretResult)
+	if err := c.do(ctx, "POST", "/api/v1/webhooks", nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryWebhookDeadLetter 查询死信投递记录(GET /api/v1/webhooks.deadletters)
+func (c *Client) QueryWebhookDeadLetter(ctx context.Context, query *QueryWebhookDeadLetterQuery) (*WebhookDeliveryPage, error) {
+	result := new(WebhookDeliveryPage)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks.deadletters", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteWebhook 删除数据(DELETE /api/v1/webhooks/:id)
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/api/v1/webhooks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// GetWebhook 查询指定数据(GET /api/v1/webhooks/:id)
+func (c *Client) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	result := new(Webhook)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks/"+url.PathEscape(id), nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateWebhook 更新数据(PUT /api/v1/webhooks/:id)
+func (c *Client) UpdateWebhook(ctx context.Context, id string, body *Webhook) error {
+	return c.do(ctx, "PUT", "/api/v1/webhooks/"+url.PathEscape(id), nil, body, nil)
+}
+
+// QueryWebhookDelivery 查询投递记录(GET /api/v1/webhooks/:id/deliveries)
+func (c *Client) QueryWebhookDelivery(ctx context.Context, id string, query *QueryWebhookDeliveryQuery) (*WebhookDeliveryPage, error) {
+	result := new(WebhookDeliveryPage)
+	if err := c.do(ctx, "GET", "/api/v1/webhooks/"+url.PathEscape(id)+"/deliveries", query.values(), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RedeliverWebhookDelivery 重新投递(POST /api/v1/webhooks/:id/deliveries/:deliveryID/redeliver)
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, id string, deliveryID string) (*WebhookDelivery, error) {
+	result := new(WebhookDelivery)
+	if err := c.do(ctx, "POST", "/api/v1/webhooks/"+url.PathEscape(id)+"/deliveries/"+url.PathEscape(deliveryID)+"/redeliver", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DisableWebhook 禁用数据(PATCH /api/v1/webhooks/:id/disable)
+func (c *Client) DisableWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/webhooks/"+url.PathEscape(id)+"/disable", nil, nil, nil)
+}
+
+// EnableWebhook 启用数据(PATCH /api/v1/webhooks/:id/enable)
+func (c *Client) EnableWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, "PATCH", "/api/v1/webhooks/"+url.PathEscape(id)+"/enable", nil, nil, nil)
+}
+
+// PingWebhook 发送测试事件(POST /api/v1/webhooks/:id/ping)
+func (c *Client) PingWebhook(ctx context.Context, id string) (*WebhookDelivery, error) {
+	result := new(WebhookDelivery)
+	if err := c.do(ctx, "POST", "/api/v1/webhooks/"+url.PathEscape(id)+"/ping", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RotateWebhookSecret 重新生成签名密钥(POST /api/v1/webhooks/:id/secret)
+func (c *Client) RotateWebhookSecret(ctx context.Context, id string) (*WebhookSecretResult, error) {
+	result := new(WebhookSecretResult)
+	if err := c.do(ctx, "POST", "/api/v1/webhooks/"+url.PathEscape(id)+"/secret", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Live 存活检查(GET /healthz)
+func (c *Client) Live(ctx context.Context) (*HealthResult, error) {
+	result := new(HealthResult)
+	if err := c.do(ctx, "GET", "/healthz", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Ready 就绪检查(依赖不可用时返回503)(GET /readyz)
+func (c *Client) Ready(ctx context.Context) (*HealthResult, error) {
+	result := new(HealthResult)
+	if err := c.do(ctx, "GET", "/readyz", nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -1,12 +1,14 @@
 package errs
 
 import (
-"github.com/pkg/errors"
+	"github.com/pkg/errors"
 )
 
 // 定义别名
 var (
 	New          = errors.New
+	Errorf       = errors.Errorf
+	Cause        = errors.Cause
 	Wrap         = errors.Wrap
 	Wrapf        = errors.Wrapf
 	WithStack    = errors.WithStack
@@ -14,21 +16,23 @@ var (
 	WithMessagef = errors.WithMessagef
 )
 
-// 定义错误
+// 定义错误(消息标识对应多语言消息目录中的键)
 var (
-	ErrBadRequest              = New400Response("请求发生错误")
-	ErrInvalidParent           = New400Response("无效的父级节点")
-	ErrNotAllowDeleteWithChild = New400Response("含有子级，不能删除")
-	ErrNotAllowDelete          = New400Response("资源不允许删除")
-	ErrInvalidUserName         = New400Response("无效的用户名")
-	ErrInvalidPassword         = New400Response("无效的密码")
-	ErrInvalidUser             = New400Response("无效的用户")
-	ErrUserDisable             = New400Response("用户被禁用，请联系管理员")
+	ErrBadRequest              = New400I18nResponse("error.bad_request")
+	ErrInvalidParent           = New400I18nResponse("error.invalid_parent")
+	ErrNotAllowDeleteWithChild = New400I18nResponse("error.not_allow_delete_with_child")
+	ErrNotAllowDelete          = New400I18nResponse("error.not_allow_delete")
+	ErrInvalidUserName         = New400I18nResponse("error.invalid_user_name")
+	ErrInvalidPassword         = New400I18nResponse("error.invalid_password")
+	ErrInvalidUser             = New400I18nResponse("error.invalid_user")
+	ErrUserDisable             = New400I18nResponse("error.user_disable")
+	ErrInvalidCursor           = New400I18nResponse("error.invalid_cursor")
 
-	ErrNoPerm          = NewResponse(401, 401, "无访问权限")
-	ErrInvalidToken    = NewResponse(9999, 401, "令牌失效")
-	ErrNotFound        = NewResponse(404, 404, "资源不存在")
-	ErrMethodNotAllow  = NewResponse(405, 405, "方法不被允许")
-	ErrTooManyRequests = NewResponse(429, 429, "请求过于频繁")
-	ErrInternalServer  = NewResponse(500, 500, "服务器发生错误")
+	ErrNoPerm          = NewI18nResponse("error.no_perm", 401, 401)
+	ErrInvalidToken    = NewI18nResponse("error.invalid_token", 9999, 401)
+	ErrTenantDisable   = NewI18nResponse("error.tenant_disable", 403, 403)
+	ErrNotFound        = NewI18nResponse("error.not_found", 404, 404)
+	ErrMethodNotAllow  = NewI18nResponse("error.method_not_allow", 405, 405)
+	ErrTooManyRequests = NewI18nResponse("error.too_many_requests", 429, 429)
+	ErrInternalServer  = NewI18nResponse("error.internal_server", 500, 500)
 )
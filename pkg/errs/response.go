@@ -1,13 +1,19 @@
 package errs
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/key7men/mag/pkg/i18n"
+)
 
 // ResponseError 定义响应错误
 type ResponseError struct {
-	Code       int    // 错误码
-	Message    string // 错误消息
-	StatusCode int    // 响应状态码
-	ERR        error  // 响应错误
+	Code       int           // 错误码
+	Message    string        // 错误消息
+	StatusCode int           // 响应状态码
+	ERR        error         // 响应错误
+	ID         string        // 消息标识(多语言消息目录中的键，为空时不翻译)
+	Args       []interface{} // 消息参数
 }
 
 func (r *ResponseError) Error() string {
@@ -17,6 +23,14 @@ func (r *ResponseError) Error() string {
 	return r.Message
 }
 
+// Localize 获取指定语言的错误消息
+func (r *ResponseError) Localize(locale string) string {
+	if r.ID == "" {
+		return r.Message
+	}
+	return i18n.T(locale, r.ID, r.Args...)
+}
+
 // UnWrapResponse 解包响应错误
 func UnWrapResponse(err error) *ResponseError {
 	if v, ok := err.(*ResponseError); ok {
@@ -65,3 +79,36 @@ func New400Response(msg string, args ...interface{}) error {
 func New500Response(msg string, args ...interface{}) error {
 	return NewResponse(500, 500, msg, args...)
 }
+
+// WrapI18nResponse 包装多语言响应错误(id为消息目录中的消息标识)
+func WrapI18nResponse(err error, id string, code, statusCode int, args ...interface{}) error {
+	res := &ResponseError{
+		Code:       code,
+		Message:    i18n.T(i18n.GetDefaultLocale(), id, args...),
+		StatusCode: statusCode,
+		ERR:        err,
+		ID:         id,
+		Args:       args,
+	}
+	return res
+}
+
+// Wrap400I18nResponse 包装错误码为400的多语言响应错误
+func Wrap400I18nResponse(err error, id string, args ...interface{}) error {
+	return WrapI18nResponse(err, id, 400, 400, args...)
+}
+
+// Wrap500I18nResponse 包装错误码为500的多语言响应错误
+func Wrap500I18nResponse(err error, id string, args ...interface{}) error {
+	return WrapI18nResponse(err, id, 500, 500, args...)
+}
+
+// NewI18nResponse 创建多语言响应错误(id为消息目录中的消息标识)
+func NewI18nResponse(id string, code, statusCode int, args ...interface{}) error {
+	return WrapI18nResponse(nil, id, code, statusCode, args...)
+}
+
+// New400I18nResponse 创建错误码为400的多语言响应错误
+func New400I18nResponse(id string, args ...interface{}) error {
+	return NewI18nResponse(id, 400, 400, args...)
+}
@@ -0,0 +1,295 @@
+package i18n
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/key7men/mag/pkg/util"
+)
+
+// DefaultLocale 默认语言
+const DefaultLocale = "zh-CN"
+
+// Localizer 可按语言翻译的消息参数(如校验错误)，格式化消息时按请求的语言展开
+type Localizer interface {
+	Localize(locale string) string
+}
+
+// NewCatalog 创建消息目录(包含内置的语言)
+func NewCatalog() *Catalog {
+	c := &Catalog{defaultLocale: DefaultLocale}
+	c.reset()
+	return c
+}
+
+// Catalog 多语言消息目录(语言 -> 消息标识 -> 消息模板)
+type Catalog struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	names         map[string]string // 小写语言标识 -> 语言标识
+	messages      map[string]map[string]string
+}
+
+func (a *Catalog) reset() {
+	a.names = make(map[string]string)
+	a.messages = make(map[string]map[string]string)
+	for locale, msgs := range builtinMessages {
+		a.add(locale, msgs)
+	}
+}
+
+func (a *Catalog) add(locale string, msgs map[string]string) {
+	key := normalize(locale)
+	if _, ok := a.names[key]; !ok {
+		a.names[key] = locale
+		a.messages[key] = make(map[string]string, len(msgs))
+	}
+	for id, msg := range msgs {
+		a.messages[key][id] = msg
+	}
+}
+
+// Add 添加(覆盖)指定语言的消息
+func (a *Catalog) Add(locale string, msgs map[string]string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.add(locale, msgs)
+}
+
+// LoadDir 从目录加载语言文件(文件名为语言标识，如en.yaml、zh-TW.yaml)，重新加载时先恢复为内置的消息
+func (a *Catalog) LoadDir(dir string) error {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		buf, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var msgs map[string]string
+		if err := util.YAMLUnmarshal(buf, &msgs); err != nil {
+			return fmt.Errorf("parse locale file %s: %s", file, err.Error())
+		}
+		loaded[strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))] = msgs
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reset()
+	for locale, msgs := range loaded {
+		a.add(locale, msgs)
+	}
+	return nil
+}
+
+// SetDefaultLocale 设定默认语言(请求的语言不支持或缺少消息时使用)
+func (a *Catalog) SetDefaultLocale(locale string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if name, ok := a.names[normalize(locale)]; ok {
+		locale = name
+	}
+	a.defaultLocale = locale
+}
+
+// DefaultLocale 获取默认语言
+func (a *Catalog) DefaultLocale() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.defaultLocale
+}
+
+// Locales 获取支持的语言列表
+func (a *Catalog) Locales() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	locales := make([]string, 0, len(a.names))
+	for _, name := range a.names {
+		locales = append(locales, name)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match 根据Accept-Language请求头协商语言(按权重依次匹配完整标识及主语言，均不支持时使用默认语言)
+func (a *Catalog) Match(acceptLanguage string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			break
+		}
+		if name, ok := a.names[tag]; ok {
+			return name
+		}
+
+		base := baseLanguage(tag)
+		if name, ok := a.names[base]; ok {
+			return name
+		}
+
+		var candidates []string
+		for key, name := range a.names {
+			if baseLanguage(key) == base {
+				candidates = append(candidates, name)
+			}
+		}
+		if len(candidates) > 0 {
+			sort.Strings(candidates)
+			return candidates[0]
+		}
+	}
+	return a.defaultLocale
+}
+
+// T 翻译消息(依次查找指定语言、主语言及默认语言，均不存在时返回消息标识)
+func (a *Catalog) T(locale, id string, args ...interface{}) string {
+	msg, ok := a.lookup(locale, id)
+	if !ok {
+		return id
+	}
+	if len(args) == 0 {
+		return msg
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		if l, ok := arg.(Localizer); ok {
+			values[i] = l.Localize(locale)
+			continue
+		}
+		values[i] = arg
+	}
+	return fmt.Sprintf(msg, values...)
+}
+
+// Has 指定语言(含回退语言)中是否存在消息
+func (a *Catalog) Has(locale, id string) bool {
+	_, ok := a.lookup(locale, id)
+	return ok
+}
+
+func (a *Catalog) lookup(locale, id string) (string, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	key := normalize(locale)
+	for _, l := range []string{key, baseLanguage(key), normalize(a.defaultLocale), normalize(DefaultLocale)} {
+		if msg, ok := a.messages[l][id]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// 解析Accept-Language请求头，按权重从高到低返回语言标识(小写)
+func parseAcceptLanguage(s string) []string {
+	type item struct {
+		tag string
+		q   float64
+	}
+
+	var items []item
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		it := item{tag: part, q: 1}
+		if i := strings.Index(part, ";"); i >= 0 {
+			it.tag = strings.TrimSpace(part[:i])
+			for _, param := range strings.Split(part[i+1:], ";") {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						it.q = q
+					}
+				}
+			}
+		}
+		if it.q > 0 {
+			it.tag = normalize(it.tag)
+			items = append(items, it)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+
+	tags := make([]string, len(items))
+	for i, it := range items {
+		tags[i] = it.tag
+	}
+	return tags
+}
+
+func normalize(locale string) string {
+	return strings.ToLower(strings.Replace(locale, "_", "-", -1))
+}
+
+func baseLanguage(locale string) string {
+	if i := strings.Index(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// 默认消息目录
+var std = NewCatalog()
+
+// Add 添加(覆盖)指定语言的消息
+func Add(locale string, msgs map[string]string) {
+	std.Add(locale, msgs)
+}
+
+// LoadDir 从目录加载语言文件
+func LoadDir(dir string) error {
+	return std.LoadDir(dir)
+}
+
+// SetDefaultLocale 设定默认语言
+func SetDefaultLocale(locale string) {
+	std.SetDefaultLocale(locale)
+}
+
+// GetDefaultLocale 获取默认语言
+func GetDefaultLocale() string {
+	return std.DefaultLocale()
+}
+
+// Locales 获取支持的语言列表
+func Locales() []string {
+	return std.Locales()
+}
+
+// Match 根据Accept-Language请求头协商语言
+func Match(acceptLanguage string) string {
+	return std.Match(acceptLanguage)
+}
+
+// T 翻译消息
+func T(locale, id string, args ...interface{}) string {
+	return std.T(locale, id, args...)
+}
+
+// Has 是否存在消息
+func Has(locale, id string) bool {
+	return std.Has(locale, id)
+}
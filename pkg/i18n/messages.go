@@ -0,0 +1,164 @@
+package i18n
+
+// 内置的消息(可通过语言文件覆盖或新增语言)
+// 校验消息的参数依次为：字段名称、校验参数、校验标签
+var builtinMessages = map[string]map[string]string{
+	"zh-CN": {
+		"error.bad_request":                 "请求发生错误",
+		"error.invalid_parent":              "无效的父级节点",
+		"error.not_allow_delete_with_child": "含有子级，不能删除",
+		"error.not_allow_delete":            "资源不允许删除",
+		"error.invalid_user_name":           "无效的用户名",
+		"error.invalid_password":            "无效的密码",
+		"error.invalid_user":                "无效的用户",
+		"error.user_disable":                "用户被禁用，请联系管理员",
+		"error.invalid_cursor":              "无效的分页游标",
+		"error.no_perm":                     "无访问权限",
+		"error.invalid_token":               "令牌失效",
+		"error.not_found":                   "资源不存在",
+		"error.method_not_allow":            "方法不被允许",
+		"error.too_many_requests":           "请求过于频繁",
+		"error.internal_server":             "服务器发生错误",
+		"error.invalid_request":             "解析请求参数发生错误 - %s",
+		"error.unsupported_filter_field":    "不支持的过滤字段 - %s",
+		"error.unsupported_filter_operator": "不支持的过滤操作符 - %s",
+		"error.unsupported_sort_field":      "不支持的排序字段 - %s",
+		"error.unsupported_select_field":    "不支持的选择字段 - %s",
+		"error.invalid_time_filter":         "无效的时间过滤值：%s",
+		"error.invalid_number_filter":       "无效的数值过滤值：%s",
+		"error.invalid_bool_filter":         "无效的布尔过滤值：%s",
+		"error.captcha_id_required":         "请提供验证码ID",
+		"error.captcha_id_not_found":        "未找到验证码ID",
+		"error.password_required":           "密码不能为空",
+		"error.config_reload_failed":        "重新加载配置失败：%s",
+		"error.root_password_not_allowed":   "root用户不允许更新密码",
+		"error.old_password_incorrect":      "旧密码不正确",
+		"error.menu_name_exists":            "菜单名称已经存在",
+		"error.user_name_not_allowed":       "用户名不合法",
+		"error.user_name_exists":            "用户名已经存在",
+		"error.delivery_succeeded":          "投递已成功，无需重新投递",
+		"error.role_name_exists":            "角色名称已经存在",
+		"error.role_in_use":                 "该角色已被赋予用户，不允许删除",
+		"error.code_exists":                 "编号已经存在",
+		"error.bulk_too_many":               "批量操作最多允许%d项",
+		"error.invalid_role":                "无效的角色",
+		"error.unsupported_format":          "仅支持CSV及XLSX格式",
+		"error.import_invalid_file":         "无法解析导入文件",
+		"error.import_file_too_large":       "导入文件不能超过%dMB",
+		"error.import_too_many_rows":        "单次最多导入%d行数据",
+		"error.import_missing_column":       "缺少必需的列：%s",
+		"error.import_required":             "不能为空",
+		"error.import_invalid_status":       "状态只能为1(启用)或2(停用)",
+		"error.import_unknown_role":         "角色不存在：%s",
+		"error.import_duplicate_row":        "与第%d行重复",
+		"error.rbac_unsupported_format":     "仅支持YAML及JSON格式",
+		"error.rbac_invalid_document":       "无法解析权限配置：%s",
+		"error.rbac_invalid_item":           "配置项不完整或无效：%s",
+		"error.rbac_duplicate_menu":         "菜单重复：%s",
+		"error.rbac_duplicate_action":       "动作编号重复：%s",
+		"error.rbac_duplicate_role":         "角色重复：%s",
+		"error.rbac_unknown_grant":          "角色%s的授权不存在：%s",
+		"error.invalid_tenant_code":         "租户编号只能包含小写字母、数字及中划线",
+		"error.tenant_code_exists":          "租户编号已经存在",
+		"error.tenant_domain_exists":        "租户域名已经存在",
+		"error.tenant_admin_required":       "请填写租户管理员的用户名、姓名及密码",
+		"error.tenant_admin_not_allowed":    "租户管理员不允许删除或禁用",
+		"error.tenant_disable":              "租户已被禁用，请联系平台管理员",
+
+		"validation.default":  "%[1]s校验失败(%[3]s)",
+		"validation.required": "%[1]s不能为空",
+		"validation.min":      "%[1]s最小为%[2]s",
+		"validation.max":      "%[1]s最大为%[2]s",
+		"validation.len":      "%[1]s长度必须为%[2]s",
+		"validation.eq":       "%[1]s必须等于%[2]s",
+		"validation.ne":       "%[1]s不能等于%[2]s",
+		"validation.gt":       "%[1]s必须大于%[2]s",
+		"validation.gte":      "%[1]s必须大于或等于%[2]s",
+		"validation.lt":       "%[1]s必须小于%[2]s",
+		"validation.lte":      "%[1]s必须小于或等于%[2]s",
+		"validation.oneof":    "%[1]s必须是[%[2]s]中的一个",
+		"validation.email":    "%[1]s必须是有效的邮箱地址",
+		"validation.url":      "%[1]s必须是有效的URL",
+		"validation.numeric":  "%[1]s必须是数字",
+		"validation.alphanum": "%[1]s只能包含字母和数字",
+	},
+	"en": {
+		"error.bad_request":                 "Bad request",
+		"error.invalid_parent":              "Invalid parent node",
+		"error.not_allow_delete_with_child": "Cannot delete a node that has children",
+		"error.not_allow_delete":            "The resource cannot be deleted",
+		"error.invalid_user_name":           "Invalid user name",
+		"error.invalid_password":            "Invalid password",
+		"error.invalid_user":                "Invalid user",
+		"error.user_disable":                "The user is disabled, please contact the administrator",
+		"error.invalid_cursor":              "Invalid pagination cursor",
+		"error.no_perm":                     "Access denied",
+		"error.invalid_token":               "Invalid or expired token",
+		"error.not_found":                   "Resource not found",
+		"error.method_not_allow":            "Method not allowed",
+		"error.too_many_requests":           "Too many requests",
+		"error.internal_server":             "Internal server error",
+		"error.invalid_request":             "Failed to parse request parameters - %s",
+		"error.unsupported_filter_field":    "Unsupported filter field - %s",
+		"error.unsupported_filter_operator": "Unsupported filter operator - %s",
+		"error.unsupported_sort_field":      "Unsupported sort field - %s",
+		"error.unsupported_select_field":    "Unsupported field selection - %s",
+		"error.invalid_time_filter":         "Invalid time filter value: %s",
+		"error.invalid_number_filter":       "Invalid numeric filter value: %s",
+		"error.invalid_bool_filter":         "Invalid boolean filter value: %s",
+		"error.captcha_id_required":         "Captcha ID is required",
+		"error.captcha_id_not_found":        "Captcha ID not found",
+		"error.password_required":           "Password is required",
+		"error.config_reload_failed":        "Failed to reload config: %s",
+		"error.root_password_not_allowed":   "The root user's password cannot be changed",
+		"error.old_password_incorrect":      "The old password is incorrect",
+		"error.menu_name_exists":            "The menu name already exists",
+		"error.user_name_not_allowed":       "The user name is not allowed",
+		"error.user_name_exists":            "The user name already exists",
+		"error.delivery_succeeded":          "The delivery already succeeded and cannot be redelivered",
+		"error.role_name_exists":            "The role name already exists",
+		"error.role_in_use":                 "The role is assigned to users and cannot be deleted",
+		"error.code_exists":                 "The code already exists",
+		"error.bulk_too_many":               "A bulk operation allows at most %d items",
+		"error.invalid_role":                "Invalid role",
+		"error.unsupported_format":          "Only CSV and XLSX formats are supported",
+		"error.import_invalid_file":         "The import file cannot be parsed",
+		"error.import_file_too_large":       "The import file must not exceed %dMB",
+		"error.import_too_many_rows":        "An import allows at most %d rows",
+		"error.import_missing_column":       "Missing required column: %s",
+		"error.import_required":             "Is required",
+		"error.import_invalid_status":       "Status must be 1 (enabled) or 2 (disabled)",
+		"error.import_unknown_role":         "Role does not exist: %s",
+		"error.import_duplicate_row":        "Duplicates row %d",
+		"error.rbac_unsupported_format":     "Only YAML and JSON formats are supported",
+		"error.rbac_invalid_document":       "The RBAC document cannot be parsed: %s",
+		"error.rbac_invalid_item":           "Incomplete or invalid item: %s",
+		"error.rbac_duplicate_menu":         "Duplicate menu: %s",
+		"error.rbac_duplicate_action":       "Duplicate action code: %s",
+		"error.rbac_duplicate_role":         "Duplicate role: %s",
+		"error.rbac_unknown_grant":          "The grant of role %s does not exist: %s",
+		"error.invalid_tenant_code":         "The tenant code may only contain lowercase letters, digits and hyphens",
+		"error.tenant_code_exists":          "The tenant code already exists",
+		"error.tenant_domain_exists":        "The tenant domain already exists",
+		"error.tenant_admin_required":       "The tenant administrator's user name, real name and password are required",
+		"error.tenant_admin_not_allowed":    "The tenant administrator cannot be deleted or disabled",
+		"error.tenant_disable":              "The tenant is disabled, please contact the platform administrator",
+
+		"validation.default":  "%[1]s failed on the '%[3]s' validation",
+		"validation.required": "%[1]s is required",
+		"validation.min":      "%[1]s must be at least %[2]s",
+		"validation.max":      "%[1]s must be at most %[2]s",
+		"validation.len":      "%[1]s must have a length of %[2]s",
+		"validation.eq":       "%[1]s must be equal to %[2]s",
+		"validation.ne":       "%[1]s must not be equal to %[2]s",
+		"validation.gt":       "%[1]s must be greater than %[2]s",
+		"validation.gte":      "%[1]s must be greater than or equal to %[2]s",
+		"validation.lt":       "%[1]s must be less than %[2]s",
+		"validation.lte":      "%[1]s must be less than or equal to %[2]s",
+		"validation.oneof":    "%[1]s must be one of [%[2]s]",
+		"validation.email":    "%[1]s must be a valid email address",
+		"validation.url":      "%[1]s must be a valid URL",
+		"validation.numeric":  "%[1]s must be numeric",
+		"validation.alphanum": "%[1]s can only contain letters and digits",
+	},
+}
@@ -1,6 +1,8 @@
 package gorm
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -47,13 +49,62 @@ type Hook struct {
 
 // Exec 执行日志写入
 func (h *Hook) Exec(entry *logrus.Entry) error {
+	result := h.db.Create(newLogItem(entry))
+	if err := result.Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// 单条插入语句的最大行数(避免超出数据库的参数数量限制)
+const maxBatchRows = 100
+
+// ExecBatch 批量执行日志写入
+func (h *Hook) ExecBatch(entries []*logrus.Entry) error {
+	for len(entries) > 0 {
+		n := len(entries)
+		if n > maxBatchRows {
+			n = maxBatchRows
+		}
+
+		err := h.insert(entries[:n])
+		if err != nil {
+			return err
+		}
+		entries = entries[n:]
+	}
+	return nil
+}
+
+func (h *Hook) insert(entries []*logrus.Entry) error {
+	const columns = "level,message,trace_id,user_id,span_title,span_function,data,version,created_at"
+
+	rows := make([]string, len(entries))
+	values := make([]interface{}, 0, len(entries)*9)
+	for i, entry := range entries {
+		item := newLogItem(entry)
+		rows[i] = "(?,?,?,?,?,?,?,?,?)"
+		values = append(values, item.Level, item.Message, item.TraceID, item.UserID,
+			item.SpanTitle, item.SpanFunction, item.Data, item.Version, item.CreatedAt)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		h.db.Dialect().Quote(tableName), columns, strings.Join(rows, ","))
+	return h.db.Exec(query, values...).Error
+}
+
+func newLogItem(entry *logrus.Entry) *LogItem {
 	item := &LogItem{
 		Level:     entry.Level.String(),
 		Message:   entry.Message,
 		CreatedAt: entry.Time,
 	}
 
-	data := entry.Data
+	// 复制数据，避免写入失败重试时丢失已提取的字段
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
 	if v, ok := data[logger.TraceIDKey]; ok {
 		item.TraceID, _ = v.(string)
 		delete(data, logger.TraceIDKey)
@@ -78,12 +129,7 @@ func (h *Hook) Exec(entry *logrus.Entry) error {
 	if len(data) > 0 {
 		item.Data = util.JSONMarshalToString(data)
 	}
-
-	result := h.db.Create(item)
-	if err := result.Error; err != nil {
-		return err
-	}
-	return nil
+	return item
 }
 
 // Close 关闭钩子
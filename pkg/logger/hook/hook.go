@@ -3,14 +3,30 @@ package hook
 import (
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/LyricTian/queue"
 	"github.com/sirupsen/logrus"
 )
 
+// OverflowPolicy the policy applied when the buffer is full
+type OverflowPolicy string
+
+// Supported overflow policies
+const (
+	OverflowBlock      OverflowPolicy = "block"       // block the caller until there is room
+	OverflowDropOldest OverflowPolicy = "drop_oldest" // discard the oldest buffered entry
+	OverflowDropNewest OverflowPolicy = "drop_newest" // discard the entry being written
+)
+
 var defaultOptions = options{
-	maxQueues:  512,
-	maxWorkers: 1,
+	maxQueues:     512,
+	maxWorkers:    1,
+	batchSize:     100,
+	flushInterval: time.Second,
+	overflow:      OverflowBlock,
+	retryInterval: time.Second,
 	levels: []logrus.Level{
 		logrus.PanicLevel,
 		logrus.FatalLevel,
@@ -28,28 +44,90 @@ type ExecCloser interface {
 	Close() error
 }
 
+// BatchExecer write multiple logrus entries to the store at once,
+// an ExecCloser implementing it receives whole batches instead of single entries
+type BatchExecer interface {
+	ExecBatch(entries []*logrus.Entry) error
+}
+
 // FilterHandle a filter handler
 type FilterHandle func(*logrus.Entry) *logrus.Entry
 
 type options struct {
-	maxQueues  int
-	maxWorkers int
-	extra      map[string]interface{}
-	filter     FilterHandle
-	levels     []logrus.Level
+	maxQueues     int
+	maxWorkers    int
+	batchSize     int
+	flushInterval time.Duration
+	overflow      OverflowPolicy
+	maxRetries    int
+	retryInterval time.Duration
+	spoolDir      string
+	extra         map[string]interface{}
+	filter        FilterHandle
+	levels        []logrus.Level
 }
 
 // SetMaxQueues set the number of buffers
 func SetMaxQueues(maxQueues int) Option {
 	return func(o *options) {
-		o.maxQueues = maxQueues
+		if maxQueues > 0 {
+			o.maxQueues = maxQueues
+		}
 	}
 }
 
 // SetMaxWorkers set the number of worker threads
 func SetMaxWorkers(maxWorkers int) Option {
 	return func(o *options) {
-		o.maxWorkers = maxWorkers
+		if maxWorkers > 0 {
+			o.maxWorkers = maxWorkers
+		}
+	}
+}
+
+// SetBatchSize set the maximum number of entries written at once
+func SetBatchSize(batchSize int) Option {
+	return func(o *options) {
+		if batchSize > 0 {
+			o.batchSize = batchSize
+		}
+	}
+}
+
+// SetFlushInterval set the maximum time an entry waits in a partial batch
+func SetFlushInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval > 0 {
+			o.flushInterval = interval
+		}
+	}
+}
+
+// SetOverflowPolicy set the policy applied when the buffer is full
+func SetOverflowPolicy(policy OverflowPolicy) Option {
+	return func(o *options) {
+		switch policy {
+		case OverflowBlock, OverflowDropOldest, OverflowDropNewest:
+			o.overflow = policy
+		}
+	}
+}
+
+// SetRetry set the number of retries for a failed batch and the interval between them
+func SetRetry(maxRetries int, interval time.Duration) Option {
+	return func(o *options) {
+		o.maxRetries = maxRetries
+		if interval > 0 {
+			o.retryInterval = interval
+		}
+	}
+}
+
+// SetSpoolDir set the directory where batches that still fail after retries are spooled,
+// spooled entries are replayed once the store accepts writes again
+func SetSpoolDir(dir string) Option {
+	return func(o *options) {
+		o.spoolDir = dir
 	}
 }
 
@@ -80,6 +158,15 @@ func SetLevels(levels ...logrus.Level) Option {
 // Option a hook parameter options
 type Option func(*options)
 
+// Stats the counters of a hook
+type Stats struct {
+	Queued  int    // entries waiting in the buffer
+	Written uint64 // entries written to the store
+	Dropped uint64 // entries discarded because the buffer was full or the hook was flushed
+	Failed  uint64 // entries lost because the store rejected them
+	Spooled uint64 // entries spooled to disk and waiting for replay
+}
+
 // New creates a hook to be added to an instance of logger
 func New(exec ExecCloser, opt ...Option) *Hook {
 	opts := defaultOptions
@@ -87,21 +174,37 @@ func New(exec ExecCloser, opt ...Option) *Hook {
 		o(&opts)
 	}
 
-	q := queue.NewQueue(opts.maxQueues, opts.maxWorkers)
-	q.Run()
-
-	return &Hook{
+	h := &Hook{
 		opts: opts,
-		q:    q,
 		e:    exec,
+		buf:  make(chan *logrus.Entry, opts.maxQueues),
+		done: make(chan struct{}),
+	}
+	if opts.spoolDir != "" {
+		h.spool = newSpool(opts.spoolDir)
+		atomic.StoreUint64(&h.spooled, uint64(h.spool.count()))
+	}
+
+	for i := 0; i < opts.maxWorkers; i++ {
+		h.wg.Add(1)
+		go h.work()
 	}
+	return h
 }
 
-// Hook to send logs to a mongo database
+// Hook to send logs to a store asynchronously in batches
 type Hook struct {
-	opts options
-	q    *queue.Queue
-	e    ExecCloser
+	opts      options
+	e         ExecCloser
+	buf       chan *logrus.Entry
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+	spool     *spool
+	written   uint64
+	dropped   uint64
+	failed    uint64
+	spooled   uint64
 }
 
 // Levels returns the available logging levels
@@ -112,12 +215,56 @@ func (h *Hook) Levels() []logrus.Level {
 // Fire is called when a log event is fired
 func (h *Hook) Fire(entry *logrus.Entry) error {
 	entry = h.copyEntry(entry)
-	h.q.Push(queue.NewJob(entry, func(v interface{}) {
-		h.exec(v.(*logrus.Entry))
-	}))
+
+	select {
+	case <-h.done:
+		atomic.AddUint64(&h.dropped, 1)
+		return nil
+	default:
+	}
+
+	switch h.opts.overflow {
+	case OverflowDropNewest:
+		select {
+		case h.buf <- entry:
+		default:
+			atomic.AddUint64(&h.dropped, 1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case h.buf <- entry:
+				return nil
+			default:
+			}
+
+			select {
+			case <-h.buf:
+				atomic.AddUint64(&h.dropped, 1)
+			default:
+			}
+		}
+	default:
+		select {
+		case h.buf <- entry:
+		case <-h.done:
+			atomic.AddUint64(&h.dropped, 1)
+		}
+	}
 	return nil
 }
 
+// Stats returns a snapshot of the hook counters
+func (h *Hook) Stats() Stats {
+	return Stats{
+		Queued:  len(h.buf),
+		Written: atomic.LoadUint64(&h.written),
+		Dropped: atomic.LoadUint64(&h.dropped),
+		Failed:  atomic.LoadUint64(&h.failed),
+		Spooled: atomic.LoadUint64(&h.spooled),
+	}
+}
+
 func (h *Hook) copyEntry(e *logrus.Entry) *logrus.Entry {
 	entry := logrus.NewEntry(e.Logger)
 	entry.Data = make(logrus.Fields)
@@ -130,25 +277,147 @@ func (h *Hook) copyEntry(e *logrus.Entry) *logrus.Entry {
 	return entry
 }
 
-func (h *Hook) exec(entry *logrus.Entry) {
-	for k, v := range h.opts.extra {
-		if _, ok := entry.Data[k]; !ok {
-			entry.Data[k] = v
+func (h *Hook) work() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.opts.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*logrus.Entry, 0, h.opts.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			h.flush(batch)
+			batch = make([]*logrus.Entry, 0, h.opts.batchSize)
+		}
+	}
+
+	for {
+		select {
+		case entry := <-h.buf:
+			batch = append(batch, entry)
+			if len(batch) >= h.opts.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+			h.replay()
+		case <-h.done:
+			for {
+				select {
+				case entry := <-h.buf:
+					batch = append(batch, entry)
+					if len(batch) >= h.opts.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (h *Hook) flush(batch []*logrus.Entry) {
+	entries := make([]*logrus.Entry, 0, len(batch))
+	for _, entry := range batch {
+		for k, v := range h.opts.extra {
+			if _, ok := entry.Data[k]; !ok {
+				entry.Data[k] = v
+			}
+		}
+
+		if filter := h.opts.filter; filter != nil {
+			entry = filter(entry)
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	for i := 0; ; i++ {
+		var err error
+		entries, err = h.exec(entries)
+		if err == nil {
+			return
+		} else if i >= h.opts.maxRetries {
+			h.fail(entries, err)
+			return
+		}
+
+		select {
+		case <-time.After(h.opts.retryInterval):
+		case <-h.done:
+			// the hook is being flushed, fall back to the spool immediately
+			h.fail(entries, err)
+			return
+		}
+	}
+}
+
+// exec writes the entries and returns those that could not be written
+func (h *Hook) exec(entries []*logrus.Entry) ([]*logrus.Entry, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	if be, ok := h.e.(BatchExecer); ok {
+		if err := be.ExecBatch(entries); err != nil {
+			return entries, err
 		}
+		atomic.AddUint64(&h.written, uint64(len(entries)))
+		return nil, nil
 	}
 
-	if filter := h.opts.filter; filter != nil {
-		entry = filter(entry)
+	var (
+		failed  []*logrus.Entry
+		lastErr error
+	)
+	for _, entry := range entries {
+		if err := h.e.Exec(entry); err != nil {
+			failed = append(failed, entry)
+			lastErr = err
+			continue
+		}
+		atomic.AddUint64(&h.written, 1)
+	}
+	return failed, lastErr
+}
+
+func (h *Hook) fail(entries []*logrus.Entry, err error) {
+	if h.spool != nil {
+		serr := h.spool.write(entries)
+		if serr == nil {
+			atomic.AddUint64(&h.spooled, uint64(len(entries)))
+			return
+		}
+		fmt.Fprintf(os.Stderr, "[logrus-hook] spool error: %s\n", serr.Error())
 	}
 
-	err := h.e.Exec(entry)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "[logrus-hook] execution error: %s", err.Error())
+	atomic.AddUint64(&h.failed, uint64(len(entries)))
+	fmt.Fprintf(os.Stderr, "[logrus-hook] execution error: %s\n", err.Error())
+}
+
+// replay writes the spooled entries back to the store, stopping at the first failure
+func (h *Hook) replay() {
+	if h.spool == nil {
+		return
 	}
+
+	h.spool.replay(func(entries []*logrus.Entry) ([]*logrus.Entry, error) {
+		failed, err := h.exec(entries)
+		if n := len(entries) - len(failed); n > 0 {
+			atomic.AddUint64(&h.spooled, ^uint64(n-1))
+		}
+		return failed, err
+	})
 }
 
 // Flush waits for the log queue to be empty
 func (h *Hook) Flush() {
-	h.q.Terminate()
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+	h.wg.Wait()
 	h.e.Close()
 }
@@ -0,0 +1,158 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Config 配置参数
+type Config struct {
+	URI        string
+	Database   string
+	Collection string
+	Timeout    int // 连接及写入超时时间(单位秒)
+	TTL        int // 日志过期时间(单位秒，0表示不过期)
+}
+
+// New 创建基于mongo的钩子实例(需要指定集合名)
+func New(c *Config) *Hook {
+	timeout := time.Duration(c.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cli, err := mongo.Connect(ctx, options.Client().ApplyURI(c.URI))
+	if err != nil {
+		panic(err)
+	}
+
+	coll := cli.Database(c.Database).Collection(c.Collection)
+	err = createIndexes(ctx, coll, c.TTL)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Hook{
+		cli:     cli,
+		coll:    coll,
+		timeout: timeout,
+	}
+}
+
+// 创建过期(TTL)、跟踪ID及常用查询字段索引
+func createIndexes(ctx context.Context, coll *mongo.Collection, ttl int) error {
+	createdAt := options.Index()
+	if ttl > 0 {
+		createdAt.SetExpireAfterSeconds(int32(ttl))
+	}
+
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: createdAt},
+		{Keys: bson.D{{Key: "trace_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "level", Value: 1}}},
+		{Keys: bson.D{{Key: "version", Value: 1}}},
+	})
+	return err
+}
+
+// Hook mongo日志钩子
+type Hook struct {
+	cli     *mongo.Client
+	coll    *mongo.Collection
+	timeout time.Duration
+}
+
+// Exec 执行日志写入
+func (h *Hook) Exec(entry *logrus.Entry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	_, err := h.coll.InsertOne(ctx, newLogItem(entry))
+	return err
+}
+
+// ExecBatch 批量执行日志写入
+func (h *Hook) ExecBatch(entries []*logrus.Entry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	items := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		items[i] = newLogItem(entry)
+	}
+
+	_, err := h.coll.InsertMany(ctx, items, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func newLogItem(entry *logrus.Entry) *LogItem {
+	item := &LogItem{
+		Level:     entry.Level.String(),
+		Message:   entry.Message,
+		CreatedAt: entry.Time,
+	}
+
+	// 复制数据，避免写入失败重试时丢失已提取的字段
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	if v, ok := data[logger.TraceIDKey]; ok {
+		item.TraceID, _ = v.(string)
+		delete(data, logger.TraceIDKey)
+	}
+	if v, ok := data[logger.UserIDKey]; ok {
+		item.UserID, _ = v.(string)
+		delete(data, logger.UserIDKey)
+	}
+	if v, ok := data[logger.SpanTitleKey]; ok {
+		item.SpanTitle, _ = v.(string)
+		delete(data, logger.SpanTitleKey)
+	}
+	if v, ok := data[logger.SpanFunctionKey]; ok {
+		item.SpanFunction, _ = v.(string)
+		delete(data, logger.SpanFunctionKey)
+	}
+	if v, ok := data[logger.VersionKey]; ok {
+		item.Version, _ = v.(string)
+		delete(data, logger.VersionKey)
+	}
+
+	if len(data) > 0 {
+		item.Data = util.JSONMarshalToString(data)
+	}
+	return item
+}
+
+// Close 关闭钩子
+func (h *Hook) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	return h.cli.Disconnect(ctx)
+}
+
+// LogItem 存储日志项
+type LogItem struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"` // id
+	Level        string             `bson:"level"`         // 日志级别
+	Message      string             `bson:"message"`       // 消息
+	TraceID      string             `bson:"trace_id"`      // 跟踪ID
+	UserID       string             `bson:"user_id"`       // 用户ID
+	SpanTitle    string             `bson:"span_title"`    // 跟踪单元标题
+	SpanFunction string             `bson:"span_function"` // 跟踪单元函数名
+	Data         string             `bson:"data"`          // 日志数据(json)
+	Version      string             `bson:"version"`       // 服务版本号
+	CreatedAt    time.Time          `bson:"created_at"`    // 创建时间
+}
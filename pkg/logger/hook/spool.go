@@ -0,0 +1,196 @@
+package hook
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const spoolExt = ".spool"
+
+// spoolRecord the on-disk representation of a spooled entry (one json document per line)
+type spoolRecord struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// spool keeps the batches that could not be written in files under dir
+type spool struct {
+	dir       string
+	seq       uint64
+	replaying int32
+}
+
+func newSpool(dir string) *spool {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "[logrus-hook] spool error: %s\n", err.Error())
+	}
+	return &spool{dir: dir}
+}
+
+// files returns the spool files, oldest first
+func (s *spool) files() []string {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), spoolExt) {
+			names = append(names, filepath.Join(s.dir, info.Name()))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// count returns the number of spooled entries
+func (s *spool) count() int {
+	var n int
+	for _, name := range s.files() {
+		buf, err := ioutil.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		n += bytes.Count(buf, []byte("\n"))
+	}
+	return n
+}
+
+// write stores the entries in a new spool file
+func (s *spool) write(entries []*logrus.Entry) error {
+	data, err := encodeEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(s.dir, fmt.Sprintf("%d-%06d%s", time.Now().UnixNano(), atomic.AddUint64(&s.seq, 1), spoolExt))
+	return writeFile(name, data)
+}
+
+// rewrite replaces the content of a spool file with the given entries, keeping its position in the replay order
+func (s *spool) rewrite(name string, entries []*logrus.Entry) error {
+	data, err := encodeEntries(entries)
+	if err != nil {
+		return err
+	}
+	return writeFile(name, data)
+}
+
+func encodeEntries(entries []*logrus.Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		record := spoolRecord{
+			Time:    entry.Time,
+			Level:   entry.Level.String(),
+			Message: entry.Message,
+		}
+		if len(entry.Data) > 0 {
+			record.Data = make(map[string]interface{}, len(entry.Data))
+			for k, v := range entry.Data {
+				if err, ok := v.(error); ok {
+					v = err.Error()
+				}
+				if _, err := json.Marshal(v); err != nil {
+					v = fmt.Sprint(v)
+				}
+				record.Data[k] = v
+			}
+		}
+
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile writes through a temporary file so that replay never reads a partial file
+func writeFile(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func (s *spool) read(name string) ([]*logrus.Entry, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*logrus.Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record spoolRecord
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.UseNumber()
+		if err := dec.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		level, err := logrus.ParseLevel(record.Level)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := logrus.NewEntry(logrus.StandardLogger())
+		entry.Time = record.Time
+		entry.Level = level
+		entry.Message = record.Message
+		entry.Data = make(logrus.Fields, len(record.Data))
+		for k, v := range record.Data {
+			entry.Data[k] = v
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// replay hands the spooled batches to fn oldest first; fn returns the entries it could not write,
+// these are kept in the spool and replay stops until the next call
+func (s *spool) replay(fn func([]*logrus.Entry) ([]*logrus.Entry, error)) {
+	if !atomic.CompareAndSwapInt32(&s.replaying, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&s.replaying, 0)
+
+	for _, name := range s.files() {
+		entries, err := s.read(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[logrus-hook] spool file %s is corrupted: %s\n", name, err.Error())
+			_ = os.Rename(name, name+".bad")
+			continue
+		}
+
+		failed, err := fn(entries)
+		if err != nil {
+			if len(failed) < len(entries) {
+				if werr := s.rewrite(name, failed); werr != nil {
+					fmt.Fprintf(os.Stderr, "[logrus-hook] spool error: %s\n", werr.Error())
+				}
+			}
+			return
+		}
+		_ = os.Remove(name)
+	}
+}
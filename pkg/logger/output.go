@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Output 日志输出(各输出可独立设定级别及格式)
+type Output struct {
+	Writer io.Writer
+	Level  int    // 日志级别(1:fatal 2:error,3:warn,4:info,5:debug)
+	Format string // 日志格式(text/json)
+}
+
+// Reopener 支持重新打开的日志输出(如滚动日志文件)
+type Reopener interface {
+	Reopen() error
+}
+
+type output struct {
+	w         io.Writer
+	level     logrus.Level
+	formatter logrus.Formatter
+}
+
+// fanout 将日志按各输出的级别及格式分别写入
+type fanout struct {
+	mu      sync.RWMutex
+	outputs []*output
+}
+
+func (f *fanout) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (f *fanout) Fire(entry *logrus.Entry) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, o := range f.outputs {
+		if entry.Level > o.level {
+			continue
+		}
+
+		buf, err := o.formatter.Format(entry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format log entry: %s\n", err.Error())
+			continue
+		}
+		if _, err := o.w.Write(buf); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write log entry: %s\n", err.Error())
+		}
+	}
+	return nil
+}
+
+var (
+	fanoutOnce sync.Once
+	fanoutHook = new(fanout)
+)
+
+// SetOutputs 设定多个日志输出(替代SetOutput)，日志级别自动调整为各输出中最详细的级别
+func SetOutputs(outputs ...Output) {
+	fanoutOnce.Do(func() {
+		logrus.AddHook(fanoutHook)
+	})
+
+	items := make([]*output, len(outputs))
+	for i, o := range outputs {
+		item := &output{
+			w:     o.Writer,
+			level: logrus.Level(o.Level),
+		}
+		switch o.Format {
+		case "json":
+			item.formatter = new(logrus.JSONFormatter)
+		default:
+			item.formatter = new(logrus.TextFormatter)
+		}
+
+		if item.level > logrus.GetLevel() {
+			logrus.SetLevel(item.level)
+		}
+		items[i] = item
+	}
+
+	fanoutHook.mu.Lock()
+	fanoutHook.outputs = items
+	fanoutHook.mu.Unlock()
+
+	logrus.SetOutput(ioutil.Discard)
+}
+
+// Reopen 重新打开所有支持重新打开的日志输出(用于响应SIGHUP)
+func Reopen() error {
+	fanoutHook.mu.RLock()
+	defer fanoutHook.mu.RUnlock()
+
+	var firstErr error
+	for _, o := range fanoutHook.outputs {
+		if r, ok := o.w.(Reopener); ok {
+			if err := r.Reopen(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RotateConfig 日志文件滚动配置
+type RotateConfig struct {
+	Filename   string        // 日志文件路径
+	MaxSize    int           // 单个文件的最大大小(单位MB，超过后滚动)
+	MaxBackups int           // 保留的历史文件数量(0表示不限制)
+	MaxAge     int           // 历史文件的保留天数(0表示不限制)
+	Compress   bool          // 是否gzip压缩历史文件
+	LocalTime  bool          // 历史文件名是否使用本地时间
+	Interval   time.Duration // 按时间滚动的间隔(0表示仅按大小滚动)
+}
+
+// NewRotateWriter 创建支持按大小及时间滚动的日志文件
+func NewRotateWriter(c RotateConfig) *RotateWriter {
+	_ = os.MkdirAll(filepath.Dir(c.Filename), 0777)
+
+	w := &RotateWriter{
+		l: &lumberjack.Logger{
+			Filename:   c.Filename,
+			MaxSize:    c.MaxSize,
+			MaxBackups: c.MaxBackups,
+			MaxAge:     c.MaxAge,
+			Compress:   c.Compress,
+			LocalTime:  c.LocalTime,
+		},
+	}
+
+	if c.Interval > 0 {
+		w.stop = make(chan struct{})
+		w.wg.Add(1)
+		go w.rotateEvery(c.Interval)
+	}
+	return w
+}
+
+// RotateWriter 滚动日志文件
+type RotateWriter struct {
+	l    *lumberjack.Logger
+	stop chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// Write 写入日志
+func (w *RotateWriter) Write(p []byte) (int, error) {
+	return w.l.Write(p)
+}
+
+// Rotate 立即滚动日志文件
+func (w *RotateWriter) Rotate() error {
+	return w.l.Rotate()
+}
+
+// Reopen 重新打开日志文件(文件被外部工具(如logrotate)移走后，下次写入时重新创建)
+func (w *RotateWriter) Reopen() error {
+	return w.l.Close()
+}
+
+// Close 停止按时间滚动并关闭日志文件
+func (w *RotateWriter) Close() error {
+	w.once.Do(func() {
+		if w.stop != nil {
+			close(w.stop)
+		}
+	})
+	w.wg.Wait()
+	return w.l.Close()
+}
+
+// 按间隔的整数倍对齐滚动(如间隔为24小时则在每天零点(UTC)滚动)
+func (w *RotateWriter) rotateEvery(interval time.Duration) {
+	defer w.wg.Done()
+
+	for {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(interval).Add(interval).Sub(now))
+		select {
+		case <-w.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			_ = w.l.Rotate()
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// 跟踪器名称
+const tracerName = "github.com/key7men/mag"
+
+// Config OpenTelemetry配置参数
+type Config struct {
+	ServiceName    string
+	ServiceVersion string
+	Exporter       string            // 导出方式(otlp/stdout/file)
+	Endpoint       string            // otlp接收地址(host:port)
+	URLPath        string            // otlp接收路径(默认/v1/traces)
+	Insecure       bool              // otlp是否使用http
+	Headers        map[string]string // otlp请求头
+	File           string            // 导出文件路径(导出方式为file时有效)
+	SampleRatio    float64           // 采样率(0~1，父级已采样时跟随父级)
+}
+
+// InitProvider 初始化全局的TracerProvider及W3C传播器，返回刷新并关闭导出的函数
+func InitProvider(ctx context.Context, c *Config) (func(), error) {
+	var (
+		exporter sdktrace.SpanExporter
+		file     *os.File
+		err      error
+	)
+
+	switch c.Exporter {
+	case "otlp":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(c.Endpoint)}
+		if c.URLPath != "" {
+			opts = append(opts, otlptracehttp.WithURLPath(c.URLPath))
+		}
+		if c.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(c.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(c.Headers))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "file":
+		if c.File == "" {
+			return nil, errors.New("trace export file is not specified")
+		}
+		_ = os.MkdirAll(filepath.Dir(c.File), 0777)
+
+		file, err = os.OpenFile(c.File, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0666)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, errors.New("unknown trace exporter")
+	}
+	if err != nil {
+		if file != nil {
+			file.Close()
+		}
+		return nil, err
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(c.ServiceName),
+		semconv.ServiceVersionKey.String(c.ServiceVersion),
+	)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = tp.Shutdown(ctx)
+		if file != nil {
+			file.Close()
+		}
+	}, nil
+}
+
+// StartSpan 创建跟踪单元(未初始化TracerProvider时不记录)
+func StartSpan(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// SpanFromContext 从上下文中获取当前跟踪单元
+func SpanFromContext(ctx context.Context) oteltrace.Span {
+	return oteltrace.SpanFromContext(ctx)
+}
+
+// IDFromContext 从上下文中获取OpenTelemetry跟踪ID(不存在时返回空)
+func IDFromContext(ctx context.Context) string {
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
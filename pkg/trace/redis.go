@@ -0,0 +1,63 @@
+package trace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// RedisWithContext 返回绑定上下文的redis客户端，每条命令(或管道)创建一个跟踪单元
+func RedisWithContext(ctx context.Context, cli *redis.Client) *redis.Client {
+	if !SpanFromContext(ctx).IsRecording() {
+		return cli
+	}
+
+	c := cli.WithContext(ctx)
+	c.WrapProcess(func(old func(redis.Cmder) error) func(redis.Cmder) error {
+		return func(cmd redis.Cmder) error {
+			_, span := startRedisSpan(ctx, cmd.Name(), cmd)
+			defer span.End()
+
+			err := old(cmd)
+			endRedisSpan(span, err)
+			return err
+		}
+	})
+	c.WrapProcessPipeline(func(old func([]redis.Cmder) error) func([]redis.Cmder) error {
+		return func(cmds []redis.Cmder) error {
+			_, span := startRedisSpan(ctx, "pipeline", cmds...)
+			defer span.End()
+
+			err := old(cmds)
+			endRedisSpan(span, err)
+			return err
+		}
+	})
+	return c
+}
+
+func startRedisSpan(ctx context.Context, name string, cmds ...redis.Cmder) (context.Context, oteltrace.Span) {
+	names := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		names[i] = cmd.Name()
+	}
+
+	return StartSpan(ctx, "redis."+name,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			semconv.DBSystemRedis,
+			attribute.String("db.operation", strings.Join(names, " ")),
+		))
+}
+
+func endRedisSpan(span oteltrace.Span, err error) {
+	if err != nil && err != redis.Nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
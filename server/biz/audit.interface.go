@@ -0,0 +1,15 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IAudit 审计日志业务逻辑接口
+type IAudit interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.AuditQueryParam, opts ...schema.AuditQueryOptions) (*schema.AuditQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, id string, opts ...schema.AuditQueryOptions) (*schema.Audit, error)
+}
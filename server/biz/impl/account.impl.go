@@ -11,6 +11,7 @@ import (
 	"github.com/key7men/mag/pkg/errs"
 	"github.com/key7men/mag/pkg/util"
 	"github.com/key7men/mag/server/biz"
+	icontext "github.com/key7men/mag/server/enhance/context"
 	"github.com/key7men/mag/server/model"
 	"github.com/key7men/mag/server/schema"
 )
@@ -29,6 +30,7 @@ type Login struct {
 	RoleMenuModel   model.IRoleMenu
 	MenuModel       model.IMenu
 	MenuActionModel model.IMenuAction
+	TenantModel     model.ITenant
 }
 
 // GetCaptchaId 获取图形验证码ID
@@ -59,9 +61,10 @@ func (l *Login) GetCaptchaPic(ctx context.Context, w http.ResponseWriter, captch
 
 // Verify 登录验证
 func (l *Login) Verify(ctx context.Context, username, password string) (*schema.User, error) {
-	// 检查是否是超级用户
+	// 检查是否是超级用户(超级用户仅可在平台登录，租户内使用租户管理员)
+	tenantID, _ := icontext.FromTenantID(ctx)
 	root := schema.GetRootUser()
-	if username == root.UserName && root.Password == password {
+	if tenantID == "" && username == root.UserName && root.Password == password {
 		return root, nil
 	}
 
@@ -84,9 +87,10 @@ func (l *Login) Verify(ctx context.Context, username, password string) (*schema.
 	return item, nil
 }
 
-// GenerateToken 生成令牌
+// GenerateToken 生成令牌(令牌中包含当前上下文的租户ID)
 func (l *Login) GenerateToken(ctx context.Context, userID string) (*schema.LoginTokenInfo, error) {
-	tokenInfo, err := l.Auth.GenerateToken(ctx, userID)
+	tenantID, _ := icontext.FromTenantID(ctx)
+	tokenInfo, err := l.Auth.GenerateToken(ctx, userID, tenantID)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -166,7 +170,15 @@ func (l *Login) GetLoginInfo(ctx context.Context, userID string) (*schema.UserLo
 // QueryUserMenuTree 查询当前用户的权限菜单树
 func (l *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.MenuTrees, error) {
 	isRoot := schema.CheckIsRootUser(ctx, userID)
-	// 如果是root用户，则查询所有显示的菜单树
+	if !isRoot {
+		isAdmin, err := isTenantAdmin(ctx, l.TenantModel, userID)
+		if err != nil {
+			return nil, err
+		}
+		isRoot = isAdmin
+	}
+
+	// 如果是root用户或租户管理员，则查询所有显示的菜单树
 	if isRoot {
 		result, err := l.MenuModel.Query(ctx, schema.MenuQueryParam{
 			Status: 1,
@@ -243,14 +255,14 @@ func (l *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.Me
 // UpdatePassword 更新当前用户登录密码
 func (l *Login) UpdatePassword(ctx context.Context, userID string, params schema.UpdatePasswordParam) error {
 	if schema.CheckIsRootUser(ctx, userID) {
-		return errs.New400Response("root用户不允许更新密码")
+		return errs.New400I18nResponse("error.root_password_not_allowed")
 	}
 
 	user, err := l.checkAndGetUser(ctx, userID)
 	if err != nil {
 		return err
 	} else if util.SHA1HashString(params.OldPassword) != user.Password {
-		return errs.New400Response("旧密码不正确")
+		return errs.New400I18nResponse("error.old_password_incorrect")
 	}
 
 	params.NewPassword = util.SHA1HashString(params.NewPassword)
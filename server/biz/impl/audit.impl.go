@@ -0,0 +1,64 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/biz"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ biz.IAudit = (*Audit)(nil)
+
+// AuditSet 注入Audit
+var AuditSet = wire.NewSet(wire.Struct(new(Audit), "*"), wire.Bind(new(biz.IAudit), new(*Audit)))
+
+// Audit 审计日志
+type Audit struct {
+	AuditModel model.IAudit
+}
+
+// Query 查询数据
+func (a *Audit) Query(ctx context.Context, params schema.AuditQueryParam, opts ...schema.AuditQueryOptions) (*schema.AuditQueryResult, error) {
+	return a.AuditModel.Query(ctx, params, opts...)
+}
+
+// Get 查询指定数据
+func (a *Audit) Get(ctx context.Context, id string, opts ...schema.AuditQueryOptions) (*schema.Audit, error) {
+	item, err := a.AuditModel.Get(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errs.ErrNotFound
+	}
+	return item, nil
+}
+
+// RecordAudit 记录审计日志(应在数据变更的事务中调用，保证与变更一同提交或回滚)
+func RecordAudit(ctx context.Context, auditModel model.IAudit, entityType, entityID string, action schema.AuditAction, before, after interface{}) error {
+	changes, err := schema.NewAuditChanges(before, after)
+	if err != nil {
+		return errs.WithStack(err)
+	} else if action == schema.AuditUpdate && len(changes) == 0 {
+		return nil
+	}
+
+	item := schema.Audit{
+		ID:         uuid.NewID(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    changes,
+	}
+	if v, ok := icontext.FromUserID(ctx); ok {
+		item.ActorID = v
+	}
+	if v, ok := icontext.FromTraceID(ctx); ok {
+		item.TraceID = v
+	}
+	return auditModel.Create(ctx, item)
+}
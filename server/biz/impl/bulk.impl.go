@@ -0,0 +1,90 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 未配置时单次批量操作的最大数量
+const defaultBulkMaxItems = 100
+
+// BulkItemFunc 批量操作中单项的处理函数，返回该项的唯一标识及领域事件中该项的数据(为nil时不发布)
+type BulkItemFunc func(ctx context.Context, i int) (string, interface{}, error)
+
+// BulkAfterFunc 批量操作全部处理完成后的处理函数，data为成功项的数据(按请求中的顺序)
+type BulkAfterFunc func(ctx context.Context, data []interface{}) error
+
+// CheckBulkSize 检查批量操作的数量是否超过限制
+func CheckBulkSize(n int) error {
+	max := config.Current().Bulk.MaxItems
+	if max <= 0 {
+		max = defaultBulkMaxItems
+	}
+
+	if n > max {
+		return errs.New400I18nResponse("error.bulk_too_many", max)
+	}
+	return nil
+}
+
+// ExecBulk 在同一事务中逐项执行批量操作，全部处理完成且有成功项时执行after(用于发布一次领域事件)
+// 每项在单独的保存点中执行，单项返回业务错误(4xx)时回滚该项、记录为失败并继续处理其他项，其他错误时回滚全部
+func ExecBulk(ctx context.Context, transModel model.ITrans, n int, fn BulkItemFunc, after BulkAfterFunc) (*schema.BulkResult, error) {
+	if err := CheckBulkSize(n); err != nil {
+		return nil, err
+	}
+
+	var result *schema.BulkResult
+	err := ExecTrans(ctx, transModel, func(ctx context.Context) error {
+		// 事务重试时(如mongo的WithTransaction)会重新执行，需重置结果及事件数据
+		result = &schema.BulkResult{Total: n, Items: make([]*schema.BulkItemResult, n)}
+		var data []interface{}
+		for i := 0; i < n; i++ {
+			var (
+				id       string
+				itemData interface{}
+			)
+			err := transModel.Savepoint(ctx, func(ctx context.Context) error {
+				var err error
+				id, itemData, err = fn(ctx, i)
+				return err
+			})
+			item := &schema.BulkItemResult{Index: i, ID: id, Status: schema.OKStatus}
+			if err != nil {
+				if !isBizError(err) {
+					return err
+				}
+				item.Status = schema.FailStatus
+				item.Err = err
+				result.Failed++
+			} else {
+				result.Succeeded++
+				if itemData != nil {
+					data = append(data, itemData)
+				}
+			}
+			result.Items[i] = item
+		}
+
+		if result.Succeeded == 0 || after == nil {
+			return nil
+		}
+		return after(ctx, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 是否为业务错误(请求数据不满足业务规则，不影响事务中的其他操作)
+func isBizError(err error) bool {
+	if e, ok := errs.Cause(err).(*errs.ResponseError); ok {
+		return e.StatusCode >= 400 && e.StatusCode < 500
+	}
+	return false
+}
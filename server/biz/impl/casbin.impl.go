@@ -6,6 +6,8 @@ import (
 	"github.com/casbin/casbin/v2"
 	"github.com/key7men/mag/pkg/logger"
 	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/schema"
 )
 
 var chCasbinPolicy chan *chCasbinPolicyItem
@@ -43,3 +45,43 @@ func LoadCasbinPolicy(ctx context.Context, e *casbin.SyncedEnforcer) {
 		e:   e,
 	}
 }
+
+// CasbinPolicyEventTypes 影响casbin权限策略的领域事件
+var CasbinPolicyEventTypes = []string{
+	schema.EventUserCreated,
+	schema.EventUserUpdated,
+	schema.EventUserRolesChanged,
+	schema.EventUserDeleted,
+	schema.EventUserStatusChanged,
+	schema.EventRoleCreated,
+	schema.EventRoleUpdated,
+	schema.EventRoleMenusChanged,
+	schema.EventRoleDeleted,
+	schema.EventRoleStatusChanged,
+	schema.EventMenuCreated,
+	schema.EventMenuUpdated,
+	schema.EventMenuDeleted,
+	schema.EventMenuStatusChanged,
+	schema.EventTenantCreated,
+	schema.EventTenantDeleted,
+	schema.EventTenantStatusChanged,
+	schema.EventUserBulkCreated,
+	schema.EventUserBulkDeleted,
+	schema.EventUserBulkStatusChanged,
+	schema.EventUserBulkRolesChanged,
+	schema.EventRoleBulkCreated,
+	schema.EventRoleBulkDeleted,
+	schema.EventRoleBulkStatusChanged,
+	schema.EventMenuBulkCreated,
+	schema.EventMenuBulkDeleted,
+	schema.EventMenuBulkStatusChanged,
+	schema.EventRBACImported,
+}
+
+// SubscribeCasbinPolicy 订阅领域事件以重新加载casbin权限策略
+func SubscribeCasbinPolicy(bus *event.Bus, e *casbin.SyncedEnforcer) {
+	bus.Subscribe(func(ctx context.Context, _ *schema.Event) error {
+		LoadCasbinPolicy(ctx, e)
+		return nil
+	}, CasbinPolicyEventTypes...)
+}
@@ -2,17 +2,36 @@ package impl
 
 import (
 	"context"
+	"time"
 
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/trace"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/assist/uuid"
 	icontext "github.com/key7men/mag/server/enhance/context"
 	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+	"go.opentelemetry.io/otel/codes"
 )
 
 // TransFunc 定义事务执行函数
 type TransFunc func(context.Context) error
 
-// ExecTrans 执行事务
+// ExecTrans 执行事务(嵌套事务复用外层事务，仅最外层创建跟踪单元)
 func ExecTrans(ctx context.Context, transModel model.ITrans, fn TransFunc) error {
-	return transModel.Exec(ctx, fn)
+	if _, ok := icontext.FromTrans(ctx); ok {
+		return transModel.Exec(ctx, fn)
+	}
+
+	ctx, span := trace.StartSpan(ctx, "biz.transaction")
+	defer span.End()
+
+	err := transModel.Exec(ctx, fn)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
 
 // ExecTransWithLock 执行事务（加锁）
@@ -30,3 +49,32 @@ func NewNoTrans(ctx context.Context) context.Context {
 	}
 	return ctx
 }
+
+// PublishEvent 将领域事件写入发件箱(应在数据变更的事务中调用，提交后由分发器投递)
+func PublishEvent(ctx context.Context, outboxModel model.IOutbox, eventType, aggregateID string, payload interface{}) error {
+	buf, err := util.JSONMarshal(payload)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+
+	now := time.Now()
+	item := schema.Event{
+		ID:          uuid.NewID(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     buf,
+		Status:      schema.EventPending,
+		NextRetryAt: now,
+		CreatedAt:   now,
+	}
+	if v, ok := icontext.FromUserID(ctx); ok {
+		item.ActorID = v
+	}
+	if v, ok := icontext.FromTraceID(ctx); ok {
+		item.TraceID = v
+	}
+	if v, ok := icontext.FromTenantID(ctx); ok {
+		item.TenantID = v
+	}
+	return outboxModel.Create(ctx, item)
+}
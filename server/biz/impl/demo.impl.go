@@ -8,6 +8,7 @@ import (
 	"github.com/key7men/mag/server/assist/uuid"
 	"github.com/key7men/mag/server/biz"
 	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -18,7 +19,11 @@ var DemoSet = wire.NewSet(wire.Struct(new(Demo), "*"), wire.Bind(new(biz.IDemo),
 
 // Demo 示例程序
 type Demo struct {
-	DemoModel model.IDemo
+	TransModel      model.ITrans
+	DemoModel       model.IDemo
+	AuditModel      model.IAudit
+	OutboxModel     model.IOutbox
+	EventDispatcher *event.Dispatcher
 }
 
 // Query 查询数据
@@ -48,7 +53,7 @@ func (a *Demo) checkCode(ctx context.Context, code string) error {
 	if err != nil {
 		return err
 	} else if result.PageResult.Total > 0 {
-		return errs.New400Response("编号已经存在")
+		return errs.New400I18nResponse("error.code_exists")
 	}
 
 	return nil
@@ -62,11 +67,23 @@ func (a *Demo) Create(ctx context.Context, item schema.Demo) (*schema.IDResult,
 	}
 
 	item.ID = uuid.NewID()
-	err = a.DemoModel.Create(ctx, item)
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.DemoModel.Create(ctx, item)
+		if err != nil {
+			return err
+		}
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityDemo, item.ID, schema.AuditCreate, nil, item)
+		if err != nil {
+			return err
+		}
+		return PublishEvent(ctx, a.OutboxModel, schema.EventDemoCreated, item.ID, item)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	a.EventDispatcher.Notify()
+
 	return schema.NewIDResult(item.ID), nil
 }
 
@@ -86,7 +103,24 @@ func (a *Demo) Update(ctx context.Context, id string, item schema.Demo) error {
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
 
-	return a.DemoModel.Update(ctx, id, item)
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.DemoModel.Update(ctx, id, item)
+		if err != nil {
+			return err
+		}
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityDemo, id, schema.AuditUpdate, oldItem, item)
+		if err != nil {
+			return err
+		}
+		return PublishEvent(ctx, a.OutboxModel, schema.EventDemoUpdated, id, item)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.EventDispatcher.Notify()
+
+	return nil
 }
 
 // Delete 删除数据
@@ -98,7 +132,24 @@ func (a *Demo) Delete(ctx context.Context, id string) error {
 		return errs.ErrNotFound
 	}
 
-	return a.DemoModel.Delete(ctx, id)
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.DemoModel.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityDemo, id, schema.AuditDelete, oldItem, nil)
+		if err != nil {
+			return err
+		}
+		return PublishEvent(ctx, a.OutboxModel, schema.EventDemoDeleted, id, oldItem)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.EventDispatcher.Notify()
+
+	return nil
 }
 
 // UpdateStatus 更新状态
@@ -110,5 +161,25 @@ func (a *Demo) UpdateStatus(ctx context.Context, id string, status int) error {
 		return errs.ErrNotFound
 	}
 
-	return a.DemoModel.UpdateStatus(ctx, id, status)
+	newItem := *oldItem
+	newItem.Status = status
+
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.DemoModel.UpdateStatus(ctx, id, status)
+		if err != nil {
+			return err
+		}
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityDemo, id, schema.AuditUpdateStatus, oldItem, newItem)
+		if err != nil {
+			return err
+		}
+		return PublishEvent(ctx, a.OutboxModel, schema.EventDemoStatusChanged, id, newItem)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.EventDispatcher.Notify()
+
+	return nil
 }
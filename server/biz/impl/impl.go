@@ -4,9 +4,14 @@ import "github.com/google/wire"
 
 // BizImplSet 注入
 var BizImplSet = wire.NewSet(
+	AuditSet,
 	DemoSet,
+	LogSet,
 	LoginSet,
 	MenuSet,
+	RBACSet,
 	RoleSet,
+	TenantSet,
 	UserSet,
+	WebhookSet,
 )
\ No newline at end of file
@@ -0,0 +1,41 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/server/biz"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ biz.ILog = (*Log)(nil)
+
+// LogSet 注入Log
+var LogSet = wire.NewSet(wire.Struct(new(Log), "*"), wire.Bind(new(biz.ILog), new(*Log)))
+
+// Log 系统日志
+type Log struct {
+	LogModel model.ILog
+}
+
+// Query 查询数据
+func (a *Log) Query(ctx context.Context, params schema.LogQueryParam, opts ...schema.LogQueryOptions) (*schema.LogQueryResult, error) {
+	return a.LogModel.Query(ctx, params, opts...)
+}
+
+// QueryTrace 查询指定跟踪ID的全部日志(按时间先后排序)
+func (a *Log) QueryTrace(ctx context.Context, traceID string) (schema.Logs, error) {
+	result, err := a.LogModel.Query(ctx, schema.LogQueryParam{
+		TraceID: traceID,
+	}, schema.LogQueryOptions{
+		OrderFields: schema.NewOrderFields(
+			schema.NewOrderField("created_at", schema.OrderByASC),
+			schema.NewOrderField("id", schema.OrderByASC),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/key7men/mag/server/biz"
 	"github.com/key7men/mag/server/assist/uuid"
+	icontext "github.com/key7men/mag/server/enhance/context"
 	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
 	"github.com/key7men/mag/server/schema"
 	"github.com/key7men/mag/pkg/errs"
 	"github.com/key7men/mag/pkg/util"
@@ -24,6 +26,10 @@ type Menu struct {
 	MenuModel               model.IMenu
 	MenuActionModel         model.IMenuAction
 	MenuActionResourceModel model.IMenuActionResource
+	RoleMenuModel           model.IRoleMenu
+	AuditModel              model.IAudit
+	OutboxModel             model.IOutbox
+	EventDispatcher         *event.Dispatcher
 }
 
 // InitData 初始化菜单数据
@@ -161,37 +167,54 @@ func (a *Menu) checkName(ctx context.Context, item schema.Menu) error {
 	if err != nil {
 		return err
 	} else if result.PageResult.Total > 0 {
-		return errs.New400Response("菜单名称已经存在")
+		return errs.New400I18nResponse("error.menu_name_exists")
 	}
 	return nil
 }
 
 // Create 创建数据
 func (a *Menu) Create(ctx context.Context, item schema.Menu) (*schema.IDResult, error) {
-	if err := a.checkName(ctx, item); err != nil {
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.create(ctx, &item)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuCreated, item.ID, item)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	a.EventDispatcher.Notify()
+
+	return schema.NewIDResult(item.ID), nil
+}
+
+// 创建数据并记录审计日志(需在事务中调用)
+func (a *Menu) create(ctx context.Context, item *schema.Menu) error {
+	if err := a.checkName(ctx, *item); err != nil {
+		return err
+	}
+
 	parentPath, err := a.getParentPath(ctx, item.ParentID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	item.ParentPath = parentPath
 	item.ID = uuid.NewID()
 
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		err := a.createActions(ctx, item.ID, item.Actions)
-		if err != nil {
-			return err
-		}
+	err = a.createActions(ctx, item.ID, item.Actions)
+	if err != nil {
+		return err
+	}
 
-		return a.MenuModel.Create(ctx, item)
-	})
+	err = a.MenuModel.Create(ctx, *item)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return schema.NewIDResult(item.ID), nil
+	return RecordAudit(ctx, a.AuditModel, schema.AuditEntityMenu, item.ID, schema.AuditCreate, nil, item)
 }
 
 // 创建动作数据
@@ -271,7 +294,7 @@ func (a *Menu) Update(ctx context.Context, id string, item schema.Menu) error {
 		item.ParentPath = oldItem.ParentPath
 	}
 
-	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
 		err := a.updateActions(ctx, id, oldItem.Actions, item.Actions)
 		if err != nil {
 			return err
@@ -282,8 +305,24 @@ func (a *Menu) Update(ctx context.Context, id string, item schema.Menu) error {
 			return err
 		}
 
-		return a.MenuModel.Update(ctx, id, item)
+		err = a.MenuModel.Update(ctx, id, item)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityMenu, id, schema.AuditUpdate, oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuUpdated, id, item)
 	})
+	if err != nil {
+		return err
+	}
+
+	a.EventDispatcher.Notify()
+	return nil
 }
 
 // 更新动作数据
@@ -305,6 +344,11 @@ func (a *Menu) updateActions(ctx context.Context, menuID string, oldItems, newIt
 		if err != nil {
 			return err
 		}
+
+		err = deleteActionGrants(ctx, a.RoleMenuModel, item.ID)
+		if err != nil {
+			return err
+		}
 	}
 
 	mOldItems := oldItems.ToMap()
@@ -406,11 +450,29 @@ func (a *Menu) updateChildParentPath(ctx context.Context, oldItem, newItem schem
 
 // Delete 删除数据
 func (a *Menu) Delete(ctx context.Context, id string) error {
-	oldItem, err := a.MenuModel.Get(ctx, id)
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		oldItem, err := a.delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuDeleted, id, oldItem)
+	})
 	if err != nil {
 		return err
+	}
+
+	a.EventDispatcher.Notify()
+	return nil
+}
+
+// 删除数据并记录审计日志，返回删除前的数据(需在事务中调用)
+func (a *Menu) delete(ctx context.Context, id string) (*schema.Menu, error) {
+	oldItem, err := a.MenuModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	} else if oldItem == nil {
-		return errs.ErrNotFound
+		return nil, errs.ErrNotFound
 	}
 
 	result, err := a.MenuModel.Query(ctx, schema.MenuQueryParam{
@@ -418,34 +480,140 @@ func (a *Menu) Delete(ctx context.Context, id string) error {
 		ParentID:        &id,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	} else if result.PageResult.Total > 0 {
-		return errs.ErrNotAllowDeleteWithChild
+		return nil, errs.ErrNotAllowDeleteWithChild
 	}
 
-	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		err = a.MenuActionResourceModel.DeleteByMenuID(ctx, id)
-		if err != nil {
-			return err
-		}
+	err = a.MenuActionResourceModel.DeleteByMenuID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-		err := a.MenuActionModel.DeleteByMenuID(ctx, id)
+	err = a.MenuActionModel.DeleteByMenuID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 菜单为全局数据，同时删除所有租户的角色对其的授权
+	err = a.RoleMenuModel.DeleteByMenuID(icontext.NewAllTenants(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.MenuModel.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityMenu, id, schema.AuditDelete, oldItem, nil)
+	if err != nil {
+		return nil, err
+	}
+	return oldItem, nil
+}
+
+// UpdateStatus 更新状态
+func (a *Menu) UpdateStatus(ctx context.Context, id string, status int) error {
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		newItem, err := a.updateStatus(ctx, id, status)
 		if err != nil {
 			return err
 		}
 
-		return a.MenuModel.Delete(ctx, id)
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuStatusChanged, id, newItem)
 	})
+	if err != nil {
+		return err
+	}
+
+	a.EventDispatcher.Notify()
+	return nil
 }
 
-// UpdateStatus 更新状态
-func (a *Menu) UpdateStatus(ctx context.Context, id string, status int) error {
+// 更新状态并记录审计日志，返回更新后的数据(需在事务中调用)
+func (a *Menu) updateStatus(ctx context.Context, id string, status int) (*schema.Menu, error) {
 	oldItem, err := a.MenuModel.Get(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	} else if oldItem == nil {
-		return errs.ErrNotFound
+		return nil, errs.ErrNotFound
+	}
+
+	newItem := *oldItem
+	newItem.Status = status
+
+	err = a.MenuModel.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return nil, err
 	}
 
-	return a.MenuModel.UpdateStatus(ctx, id, status)
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityMenu, id, schema.AuditUpdateStatus, oldItem, newItem)
+	if err != nil {
+		return nil, err
+	}
+	return &newItem, nil
+}
+
+// BulkCreate 批量创建数据(按顺序创建)
+func (a *Menu) BulkCreate(ctx context.Context, items []*schema.Menu) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(items), func(ctx context.Context, i int) (string, interface{}, error) {
+		item := *items[i]
+		err := a.create(ctx, &item)
+		if err != nil {
+			return "", nil, err
+		}
+		return item.ID, &item, nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuBulkCreated, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkDelete 批量删除数据(含有子级的菜单需在子级之后删除)
+func (a *Menu) BulkDelete(ctx context.Context, ids []string) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		oldItem, err := a.delete(ctx, ids[i])
+		if err != nil {
+			return ids[i], nil, err
+		}
+		return ids[i], oldItem, nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuBulkDeleted, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkUpdateStatus 批量更新状态
+func (a *Menu) BulkUpdateStatus(ctx context.Context, ids []string, status int) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		newItem, err := a.updateStatus(ctx, ids[i], status)
+		if err != nil {
+			return ids[i], nil, err
+		}
+		return ids[i], newItem, nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventMenuBulkStatusChanged, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// 删除所有租户的角色对动作的授权(动作为全局数据)
+func deleteActionGrants(ctx context.Context, roleMenuModel model.IRoleMenu, actionID string) error {
+	return roleMenuModel.DeleteByActionID(icontext.NewAllTenants(ctx), actionID)
 }
@@ -0,0 +1,899 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/biz"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ biz.IRBAC = (*RBAC)(nil)
+
+// RBACSet 注入RBAC
+var RBACSet = wire.NewSet(wire.Struct(new(RBAC), "*"), wire.Bind(new(biz.IRBAC), new(*RBAC)))
+
+// RBAC RBAC模型配置管理(菜单按名称路径、动作按编号、资源按请求方式及路径、角色按名称对比，不依赖生成的ID)
+type RBAC struct {
+	TransModel              model.ITrans
+	MenuModel               model.IMenu
+	MenuActionModel         model.IMenuAction
+	MenuActionResourceModel model.IMenuActionResource
+	RoleModel               model.IRole
+	RoleMenuModel           model.IRoleMenu
+	AuditModel              model.IAudit
+	OutboxModel             model.IOutbox
+	EventDispatcher         *event.Dispatcher
+	MenuBiz                 *Menu
+	RoleBiz                 *Role
+}
+
+// 当前的菜单及角色数据(按业务标识索引)
+type rbacState struct {
+	menus   schema.Menus                  // 菜单列表
+	paths   map[string]string             // 菜单ID对应的菜单路径
+	mMenus  map[string]*schema.Menu       // 菜单路径对应的菜单
+	actions map[string]schema.MenuActions // 菜单ID对应的动作列表(含资源)
+	roles   schema.Roles                  // 角色列表(含角色菜单)
+	mRoles  map[string]*schema.Role       // 角色名称对应的角色
+	removed map[string]bool               // 导入时删除的菜单及动作ID
+}
+
+func (a *RBAC) loadState(ctx context.Context) (*rbacState, error) {
+	menuResult, err := a.MenuModel.Query(ctx, schema.MenuQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	actionResult, err := a.MenuActionModel.Query(ctx, schema.MenuActionQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	resourceResult, err := a.MenuActionResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+	actionResult.Data.FillResources(resourceResult.Data.ToActionIDMap())
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+	mRoleMenus := roleMenuResult.Data.ToRoleIDMap()
+
+	st := &rbacState{
+		menus:   menuResult.Data,
+		paths:   make(map[string]string),
+		mMenus:  make(map[string]*schema.Menu),
+		actions: actionResult.Data.ToMenuIDMap(),
+		roles:   roleResult.Data,
+		mRoles:  make(map[string]*schema.Role),
+		removed: make(map[string]bool),
+	}
+
+	mIDs := menuResult.Data.ToMap()
+	for _, item := range st.menus {
+		st.pathOf(item, mIDs)
+	}
+	for _, item := range st.roles {
+		item.RoleMenus = mRoleMenus[item.ID]
+		st.mRoles[item.Name] = item
+	}
+	return st, nil
+}
+
+// 菜单路径(父级菜单不存在时从存在的上级开始)
+func (a *rbacState) pathOf(item *schema.Menu, mIDs map[string]*schema.Menu) string {
+	if v, ok := a.paths[item.ID]; ok {
+		return v
+	}
+
+	var parent string
+	if pitem, ok := mIDs[item.ParentID]; ok {
+		parent = a.pathOf(pitem, mIDs)
+	}
+	path := schema.JoinRBACMenuPath(parent, item.Name)
+	a.paths[item.ID] = path
+	if _, ok := a.mMenus[path]; !ok {
+		a.mMenus[path] = item
+	}
+	return path
+}
+
+// 添加新建的菜单
+func (a *rbacState) addMenu(path string, item *schema.Menu) {
+	a.paths[item.ID] = path
+	a.mMenus[path] = item
+	a.actions[item.ID] = item.Actions
+}
+
+// 按路由查找同级且未匹配的菜单
+func (a *rbacState) findByRouter(parentID, router string, seen map[string]bool) (*schema.Menu, bool) {
+	for _, item := range a.menus {
+		if item.ParentID == parentID && item.Router == router && !seen[item.ID] {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// 菜单重命名后更新菜单及其下级菜单的路径
+func (a *rbacState) renameMenu(oldPath, newPath string) {
+	for _, item := range a.menus {
+		path := a.paths[item.ID]
+		if path != oldPath && !strings.HasPrefix(path, oldPath+schema.RBACMenuPathSep) {
+			continue
+		}
+
+		if a.mMenus[path] == item {
+			delete(a.mMenus, path)
+		}
+		path = newPath + path[len(oldPath):]
+		a.paths[item.ID] = path
+		a.mMenus[path] = item
+	}
+}
+
+// 角色菜单对应的菜单路径及动作编号
+func (a *rbacState) grantOf(rm *schema.RoleMenu) (path, code string, ok bool) {
+	path, ok = a.paths[rm.MenuID]
+	if !ok {
+		return "", "", false
+	}
+	for _, action := range a.actions[rm.MenuID] {
+		if action.ID == rm.ActionID {
+			return path, action.Code, true
+		}
+	}
+	return "", "", false
+}
+
+// Export 导出菜单、动作、资源、角色及授权(同级菜单及角色按排序值降序)
+func (a *RBAC) Export(ctx context.Context) (*schema.RBACDocument, error) {
+	st, err := a.loadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string]schema.Menus)
+	for _, item := range st.menus {
+		parentID := item.ParentID
+		if _, ok := st.paths[parentID]; !ok {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], item)
+	}
+
+	doc := &schema.RBACDocument{
+		Menus: schema.RBACMenus{},
+		Roles: schema.RBACRoles{},
+	}
+	order := make(map[string]int)
+	doc.Menus = a.exportMenus(st, children, "", order)
+
+	roles := append(schema.Roles{}, st.roles...)
+	sort.SliceStable(roles, func(i, j int) bool {
+		if roles[i].Sequence != roles[j].Sequence {
+			return roles[i].Sequence > roles[j].Sequence
+		}
+		return roles[i].Name < roles[j].Name
+	})
+	for _, item := range roles {
+		doc.Roles = append(doc.Roles, &schema.RBACRole{
+			Name:     item.Name,
+			Sequence: item.Sequence,
+			Status:   item.Status,
+			Memo:     item.Memo,
+			Grants:   a.exportGrants(st, item.RoleMenus, order),
+		})
+	}
+	return doc, nil
+}
+
+func (a *RBAC) exportMenus(st *rbacState, children map[string]schema.Menus, parentID string, order map[string]int) schema.RBACMenus {
+	list := children[parentID]
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Sequence != list[j].Sequence {
+			return list[i].Sequence > list[j].Sequence
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	var result schema.RBACMenus
+	for _, item := range list {
+		path := st.paths[item.ID]
+		mitem := &schema.RBACMenu{
+			Name:       item.Name,
+			Icon:       item.Icon,
+			Router:     item.Router,
+			Sequence:   item.Sequence,
+			ShowStatus: item.ShowStatus,
+			Status:     item.Status,
+			Memo:       item.Memo,
+		}
+		for _, action := range st.actions[item.ID] {
+			order[path+"#"+action.Code] = len(order)
+			aitem := &schema.RBACAction{Code: action.Code, Name: action.Name}
+			for _, ritem := range action.Resources {
+				aitem.Resources = append(aitem.Resources, &schema.RBACResource{Method: ritem.Method, Path: ritem.Path})
+			}
+			mitem.Actions = append(mitem.Actions, aitem)
+		}
+		mitem.Children = a.exportMenus(st, children, item.ID, order)
+		result = append(result, mitem)
+	}
+	return result
+}
+
+// 角色的菜单授权(按菜单在文档中的顺序分组，忽略已删除的菜单及动作)
+func (a *RBAC) exportGrants(st *rbacState, roleMenus schema.RoleMenus, order map[string]int) schema.RBACGrants {
+	type grant struct {
+		path, code string
+	}
+	var list []grant
+	for _, rm := range roleMenus {
+		if path, code, ok := st.grantOf(rm); ok {
+			list = append(list, grant{path: path, code: code})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return order[list[i].path+"#"+list[i].code] < order[list[j].path+"#"+list[j].code]
+	})
+
+	var result schema.RBACGrants
+	mGrants := make(map[string]*schema.RBACGrant)
+	for _, item := range list {
+		g, ok := mGrants[item.path]
+		if !ok {
+			g = &schema.RBACGrant{Menu: item.path}
+			mGrants[item.path] = g
+			result = append(result, g)
+		}
+		g.Actions = append(g.Actions, item.code)
+	}
+	return result
+}
+
+// Import 对比当前数据并按业务标识导入(重复导入同一文档不产生变更)
+// 文档中的可选字段为空时保留现有值；prune为true时删除文档中不存在的菜单、动作、资源、角色及授权
+func (a *RBAC) Import(ctx context.Context, doc *schema.RBACDocument, params schema.RBACImportParam) (*schema.RBACImportResult, error) {
+	return a.importDocument(ctx, doc, &rbacImporter{params: params})
+}
+
+// MergeMenus 合并菜单数据文件(按菜单路径或同级的路由匹配菜单、按编号匹配动作，新增缺少的数据并同步动作的资源，不修改角色)
+// 已存在的菜单及动作保留现有的属性(如管理员修改的名称、图标及排序)；prune为true时删除文件中不存在的菜单及动作(同时删除角色对其的授权)
+func (a *RBAC) MergeMenus(ctx context.Context, dataFile string, prune bool) (*schema.RBACImportResult, error) {
+	data, err := a.MenuBiz.readData(dataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &schema.RBACDocument{Menus: toRBACMenus(data)}
+	return a.importDocument(ctx, doc, &rbacImporter{
+		params:        schema.RBACImportParam{Prune: prune},
+		menusOnly:     true,
+		syncResources: true,
+		keepExisting:  true,
+	})
+}
+
+func toRBACMenus(list schema.MenuTrees) schema.RBACMenus {
+	menus := make(schema.RBACMenus, len(list))
+	for i, item := range list {
+		menus[i] = &schema.RBACMenu{
+			Name:       item.Name,
+			Icon:       item.Icon,
+			Router:     item.Router,
+			Sequence:   item.Sequence,
+			ShowStatus: item.ShowStatus,
+			Status:     item.Status,
+		}
+		for _, action := range item.Actions {
+			aitem := &schema.RBACAction{Code: action.Code, Name: action.Name}
+			for _, ritem := range action.Resources {
+				aitem.Resources = append(aitem.Resources, &schema.RBACResource{Method: ritem.Method, Path: ritem.Path})
+			}
+			menus[i].Actions = append(menus[i].Actions, aitem)
+		}
+		if item.Children != nil {
+			menus[i].Children = toRBACMenus(*item.Children)
+		}
+	}
+	return menus
+}
+
+// 在事务中对比并应用文档(仅对比差异时不修改数据)
+func (a *RBAC) importDocument(ctx context.Context, doc *schema.RBACDocument, im *rbacImporter) (*schema.RBACImportResult, error) {
+	if err := checkRBACDocument(doc); err != nil {
+		return nil, err
+	}
+
+	params := im.params
+	var result *schema.RBACImportResult
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		st, err := a.loadState(ctx)
+		if err != nil {
+			return err
+		}
+
+		// 事务重试时(如mongo的WithTransaction)会重新执行，需重置结果
+		result = &schema.RBACImportResult{DryRun: params.DryRun, Changes: []*schema.RBACChange{}}
+		im.RBAC, im.st, im.result, im.seen = a, st, result, make(map[string]bool)
+		if v, ok := icontext.FromUserID(ctx); ok {
+			im.creator = v
+		}
+
+		err = im.importMenus(ctx, "", "", doc.Menus)
+		if err != nil {
+			return err
+		}
+
+		if params.Prune {
+			err = im.pruneMenus(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !im.menusOnly {
+			err = im.importRoles(ctx, doc.Roles)
+			if err != nil {
+				return err
+			}
+		}
+
+		if params.DryRun || len(result.Changes) == 0 {
+			return nil
+		}
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRBACImported, uuid.NewID(), result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !params.DryRun && len(result.Changes) > 0 {
+		result.Applied = true
+		a.EventDispatcher.Notify()
+	}
+	return result, nil
+}
+
+// 检查文档的完整性及业务标识的唯一性
+func checkRBACDocument(doc *schema.RBACDocument) error {
+	paths := make(map[string]struct{})
+	var checkMenus func(parent string, list schema.RBACMenus) error
+	checkMenus = func(parent string, list schema.RBACMenus) error {
+		for _, item := range list {
+			if item == nil || item.Name == "" {
+				return errs.New400I18nResponse("error.rbac_invalid_item", schema.JoinRBACMenuPath(parent, "?"))
+			}
+
+			path := schema.JoinRBACMenuPath(parent, item.Name)
+			if _, ok := paths[path]; ok {
+				return errs.New400I18nResponse("error.rbac_duplicate_menu", path)
+			} else if !isRBACStatus(item.ShowStatus) || !isRBACStatus(item.Status) {
+				return errs.New400I18nResponse("error.rbac_invalid_item", path)
+			}
+			paths[path] = struct{}{}
+
+			codes := make(map[string]struct{})
+			for _, action := range item.Actions {
+				if action == nil || action.Code == "" || action.Name == "" {
+					return errs.New400I18nResponse("error.rbac_invalid_item", path+"#?")
+				}
+
+				key := path + "#" + action.Code
+				if _, ok := codes[action.Code]; ok {
+					return errs.New400I18nResponse("error.rbac_duplicate_action", key)
+				}
+				codes[action.Code] = struct{}{}
+
+				for _, ritem := range action.Resources {
+					if ritem == nil || ritem.Method == "" || ritem.Path == "" {
+						return errs.New400I18nResponse("error.rbac_invalid_item", key)
+					}
+				}
+			}
+
+			if err := checkMenus(path, item.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := checkMenus("", doc.Menus); err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{})
+	for _, item := range doc.Roles {
+		if item == nil || item.Name == "" {
+			return errs.New400I18nResponse("error.rbac_invalid_item", "?")
+		} else if _, ok := names[item.Name]; ok {
+			return errs.New400I18nResponse("error.rbac_duplicate_role", item.Name)
+		} else if !isRBACStatus(item.Status) {
+			return errs.New400I18nResponse("error.rbac_invalid_item", item.Name)
+		}
+		names[item.Name] = struct{}{}
+
+		for _, g := range item.Grants {
+			if g == nil || g.Menu == "" {
+				return errs.New400I18nResponse("error.rbac_invalid_item", item.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// 状态为空(保留现有值或使用默认值)或1、2
+func isRBACStatus(v int) bool {
+	return v >= 0 && v <= 2
+}
+
+// 导入过程(仅对比差异时不修改数据，新建的对象使用临时ID以便关联)
+type rbacImporter struct {
+	*RBAC
+	st            *rbacState
+	params        schema.RBACImportParam
+	result        *schema.RBACImportResult
+	seen          map[string]bool // 文档中存在的菜单及角色ID
+	creator       string
+	menusOnly     bool // 仅处理菜单(不导入及删除角色)
+	syncResources bool // 动作的资源与文档保持一致(不论是否删除文档中不存在的数据)
+	keepExisting  bool // 不修改已存在的菜单及动作的属性(仅新增缺少的数据)
+}
+
+// 字段差异(新值为空时保留原值)
+type rbacFieldDiff struct {
+	from, to []string
+}
+
+func (d *rbacFieldDiff) str(name string, old *string, v string) {
+	if v != "" && v != *old {
+		d.from = append(d.from, fmt.Sprintf("%s=%s", name, *old))
+		d.to = append(d.to, fmt.Sprintf("%s=%s", name, v))
+		*old = v
+	}
+}
+
+func (d *rbacFieldDiff) int(name string, old *int, v int) {
+	if v != 0 && v != *old {
+		d.from = append(d.from, fmt.Sprintf("%s=%d", name, *old))
+		d.to = append(d.to, fmt.Sprintf("%s=%d", name, v))
+		*old = v
+	}
+}
+
+func (d *rbacFieldDiff) changed() bool {
+	return len(d.to) > 0
+}
+
+func (d *rbacFieldDiff) values() (string, string) {
+	return strings.Join(d.from, ", "), strings.Join(d.to, ", ")
+}
+
+func (a *rbacImporter) importMenus(ctx context.Context, parentPath, parentID string, list schema.RBACMenus) error {
+	for _, item := range list {
+		path := schema.JoinRBACMenuPath(parentPath, item.Name)
+		menu, ok := a.st.mMenus[path]
+		if !ok && item.Router != "" {
+			// 菜单名称变更时按同级的路由匹配
+			menu, ok = a.st.findByRouter(parentID, item.Router, a.seen)
+		}
+		if ok {
+			a.seen[menu.ID] = true
+			if err := a.updateMenu(ctx, path, menu, item); err != nil {
+				return err
+			}
+		} else {
+			menu = &schema.Menu{
+				Name:       item.Name,
+				Sequence:   item.Sequence,
+				Icon:       item.Icon,
+				Router:     item.Router,
+				ParentID:   parentID,
+				ShowStatus: 1,
+				Status:     1,
+				Memo:       item.Memo,
+				Creator:    a.creator,
+				Actions:    toMenuActions(item.Actions),
+			}
+			if v := item.ShowStatus; v > 0 {
+				menu.ShowStatus = v
+			}
+			if v := item.Status; v > 0 {
+				menu.Status = v
+			}
+
+			a.result.AddChange(schema.RBACKindMenu, schema.RBACOpCreate, path)
+			if err := a.createMenu(ctx, menu); err != nil {
+				return err
+			}
+			a.st.addMenu(path, menu)
+		}
+
+		if err := a.importMenus(ctx, path, menu.ID, item.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toMenuActions(list schema.RBACActions) schema.MenuActions {
+	actions := make(schema.MenuActions, len(list))
+	for i, item := range list {
+		actions[i] = &schema.MenuAction{Code: item.Code, Name: item.Name, Resources: toMenuActionResources(item.Resources)}
+	}
+	return actions
+}
+
+func toMenuActionResources(list schema.RBACResources) schema.MenuActionResources {
+	resources := make(schema.MenuActionResources, 0, len(list))
+	m := make(map[string]struct{})
+	for _, item := range list {
+		if _, ok := m[item.Method+item.Path]; ok {
+			continue
+		}
+		m[item.Method+item.Path] = struct{}{}
+		resources = append(resources, &schema.MenuActionResource{Method: item.Method, Path: item.Path})
+	}
+	return resources
+}
+
+// 新建菜单(含动作及资源)
+func (a *rbacImporter) createMenu(ctx context.Context, menu *schema.Menu) error {
+	if !a.params.DryRun {
+		return a.MenuBiz.create(ctx, menu)
+	}
+
+	menu.ID = uuid.NewID()
+	for _, action := range menu.Actions {
+		action.ID = uuid.NewID()
+		action.MenuID = menu.ID
+	}
+	return nil
+}
+
+// 更新菜单的属性、动作及资源
+func (a *rbacImporter) updateMenu(ctx context.Context, path string, menu *schema.Menu, item *schema.RBACMenu) error {
+	newItem := *menu
+	var d rbacFieldDiff
+	if !a.keepExisting {
+		d.str("name", &newItem.Name, item.Name)
+		d.str("icon", &newItem.Icon, item.Icon)
+		d.str("router", &newItem.Router, item.Router)
+		d.int("sequence", &newItem.Sequence, item.Sequence)
+		d.int("show_status", &newItem.ShowStatus, item.ShowStatus)
+		d.int("status", &newItem.Status, item.Status)
+		d.str("memo", &newItem.Memo, item.Memo)
+	}
+
+	if d.changed() {
+		from, to := d.values()
+		a.result.AddChange(schema.RBACKindMenu, schema.RBACOpUpdate, path, from, to)
+		if !a.params.DryRun {
+			err := a.MenuModel.Update(ctx, menu.ID, newItem)
+			if err != nil {
+				return err
+			}
+
+			err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityMenu, menu.ID, schema.AuditUpdate, menu, newItem)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if oldPath := a.st.paths[menu.ID]; oldPath != path {
+		a.st.renameMenu(oldPath, path)
+	}
+	return a.importActions(ctx, path, menu.ID, item.Actions)
+}
+
+func (a *rbacImporter) importActions(ctx context.Context, path, menuID string, list schema.RBACActions) error {
+	oldActions := a.st.actions[menuID]
+	mOldActions := oldActions.ToMap()
+	codes := make(map[string]struct{})
+
+	for _, item := range list {
+		codes[item.Code] = struct{}{}
+		key := path + "#" + item.Code
+
+		oitem, ok := mOldActions[item.Code]
+		if !ok {
+			action := &schema.MenuAction{MenuID: menuID, Code: item.Code, Name: item.Name, Resources: toMenuActionResources(item.Resources)}
+			a.result.AddChange(schema.RBACKindAction, schema.RBACOpCreate, key)
+			if a.params.DryRun {
+				action.ID = uuid.NewID()
+			} else if err := a.MenuBiz.createActions(ctx, menuID, schema.MenuActions{action}); err != nil {
+				return err
+			}
+			a.st.actions[menuID] = append(a.st.actions[menuID], action)
+			continue
+		}
+
+		if item.Name != oitem.Name && !a.keepExisting {
+			a.result.AddChange(schema.RBACKindAction, schema.RBACOpUpdate, key, oitem.Name, item.Name)
+			if !a.params.DryRun {
+				nitem := *oitem
+				nitem.Name = item.Name
+				err := a.MenuActionModel.Update(ctx, oitem.ID, nitem)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := a.importResources(ctx, key, oitem, item.Resources); err != nil {
+			return err
+		}
+	}
+
+	if !a.params.Prune {
+		return nil
+	}
+	for _, oitem := range oldActions {
+		if _, ok := codes[oitem.Code]; ok {
+			continue
+		}
+
+		a.result.AddChange(schema.RBACKindAction, schema.RBACOpDelete, path+"#"+oitem.Code)
+		a.st.removed[oitem.ID] = true
+		if a.params.DryRun {
+			continue
+		}
+
+		err := a.MenuActionResourceModel.DeleteByActionID(ctx, oitem.ID)
+		if err != nil {
+			return err
+		}
+
+		err = a.MenuActionModel.Delete(ctx, oitem.ID)
+		if err != nil {
+			return err
+		}
+
+		err = deleteActionGrants(ctx, a.RoleMenuModel, oitem.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *rbacImporter) importResources(ctx context.Context, key string, action *schema.MenuAction, list schema.RBACResources) error {
+	mOldResources := action.Resources.ToMap()
+	mNewResources := make(map[string]struct{})
+
+	for _, item := range toMenuActionResources(list) {
+		mNewResources[item.Method+item.Path] = struct{}{}
+		if _, ok := mOldResources[item.Method+item.Path]; ok {
+			continue
+		}
+
+		a.result.AddChange(schema.RBACKindResource, schema.RBACOpCreate, key+" "+item.Method+" "+item.Path)
+		if !a.params.DryRun {
+			item.ID = uuid.NewID()
+			item.ActionID = action.ID
+			err := a.MenuActionResourceModel.Create(ctx, *item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !a.params.Prune && !a.syncResources {
+		return nil
+	}
+	for _, item := range action.Resources {
+		if _, ok := mNewResources[item.Method+item.Path]; ok {
+			continue
+		}
+
+		a.result.AddChange(schema.RBACKindResource, schema.RBACOpDelete, key+" "+item.Method+" "+item.Path)
+		if !a.params.DryRun {
+			err := a.MenuActionResourceModel.Delete(ctx, item.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 删除文档中不存在的菜单(下级菜单优先删除)
+func (a *rbacImporter) pruneMenus(ctx context.Context) error {
+	var list schema.Menus
+	for _, item := range a.st.menus {
+		if !a.seen[item.ID] {
+			list = append(list, item)
+		}
+	}
+
+	depth := func(item *schema.Menu) int {
+		if item.ParentPath == "" {
+			return 0
+		}
+		return strings.Count(item.ParentPath, "/") + 1
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return depth(list[i]) > depth(list[j])
+	})
+
+	for _, item := range list {
+		a.result.AddChange(schema.RBACKindMenu, schema.RBACOpDelete, a.st.paths[item.ID])
+		a.st.removed[item.ID] = true
+		for _, action := range a.st.actions[item.ID] {
+			a.st.removed[action.ID] = true
+		}
+
+		if !a.params.DryRun {
+			if _, err := a.MenuBiz.delete(ctx, item.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 按菜单路径及动作编号解析角色的授权
+func (a *rbacImporter) resolveGrants(item *schema.RBACRole) (schema.RoleMenus, error) {
+	var list schema.RoleMenus
+	m := make(map[string]struct{})
+	for _, g := range item.Grants {
+		menu, ok := a.st.mMenus[g.Menu]
+		if !ok || a.st.removed[menu.ID] {
+			return nil, errs.New400I18nResponse("error.rbac_unknown_grant", item.Name, g.Menu)
+		}
+
+		mActions := a.st.actions[menu.ID].ToMap()
+		for _, code := range g.Actions {
+			action, ok := mActions[code]
+			if !ok || a.st.removed[action.ID] {
+				return nil, errs.New400I18nResponse("error.rbac_unknown_grant", item.Name, g.Menu+"#"+code)
+			} else if _, ok := m[action.ID]; ok {
+				continue
+			}
+			m[action.ID] = struct{}{}
+			list = append(list, &schema.RoleMenu{MenuID: menu.ID, ActionID: action.ID})
+		}
+	}
+	return list, nil
+}
+
+// 授权的变更标识(角色名称 => 菜单路径#动作编号)
+func (a *rbacImporter) grantKey(roleName string, rm *schema.RoleMenu) string {
+	if path, code, ok := a.st.grantOf(rm); ok {
+		return roleName + " => " + path + "#" + code
+	}
+	return roleName + " => " + rm.MenuID + "#" + rm.ActionID
+}
+
+func (a *rbacImporter) importRoles(ctx context.Context, list schema.RBACRoles) error {
+	for _, item := range list {
+		roleMenus, err := a.resolveGrants(item)
+		if err != nil {
+			return err
+		}
+
+		role, ok := a.st.mRoles[item.Name]
+		if !ok {
+			role = &schema.Role{
+				Name:      item.Name,
+				Sequence:  item.Sequence,
+				Memo:      item.Memo,
+				Status:    1,
+				Creator:   a.creator,
+				RoleMenus: roleMenus,
+			}
+			if v := item.Status; v > 0 {
+				role.Status = v
+			}
+
+			a.result.AddChange(schema.RBACKindRole, schema.RBACOpCreate, item.Name)
+			if !a.params.DryRun {
+				if err := a.RoleBiz.create(ctx, role); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		a.seen[role.ID] = true
+		if err := a.updateRole(ctx, role, item, roleMenus); err != nil {
+			return err
+		}
+	}
+
+	if !a.params.Prune {
+		return nil
+	}
+	for _, item := range a.st.roles {
+		if a.seen[item.ID] {
+			continue
+		}
+
+		a.result.AddChange(schema.RBACKindRole, schema.RBACOpDelete, item.Name)
+		if !a.params.DryRun {
+			if _, err := a.RoleBiz.delete(ctx, item.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 更新角色的属性及授权
+func (a *rbacImporter) updateRole(ctx context.Context, role *schema.Role, item *schema.RBACRole, roleMenus schema.RoleMenus) error {
+	newItem := *role
+	var d rbacFieldDiff
+	d.int("sequence", &newItem.Sequence, item.Sequence)
+	d.int("status", &newItem.Status, item.Status)
+	d.str("memo", &newItem.Memo, item.Memo)
+
+	if d.changed() {
+		from, to := d.values()
+		a.result.AddChange(schema.RBACKindRole, schema.RBACOpUpdate, role.Name, from, to)
+		if !a.params.DryRun {
+			err := a.RoleModel.Update(ctx, role.ID, newItem)
+			if err != nil {
+				return err
+			}
+
+			err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityRole, role.ID, schema.AuditUpdate, role, newItem)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	mOldRoleMenus := role.RoleMenus.ToMap()
+	mNewRoleMenus := roleMenus.ToMap()
+	for _, rm := range roleMenus {
+		if _, ok := mOldRoleMenus[rm.MenuID+"-"+rm.ActionID]; ok {
+			continue
+		}
+
+		a.result.AddChange(schema.RBACKindGrant, schema.RBACOpCreate, a.grantKey(role.Name, rm))
+		if !a.params.DryRun {
+			rm.ID = uuid.NewID()
+			rm.RoleID = role.ID
+			err := a.RoleMenuModel.Create(ctx, *rm)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !a.params.Prune {
+		return nil
+	}
+	for _, rm := range role.RoleMenus {
+		if _, ok := mNewRoleMenus[rm.MenuID+"-"+rm.ActionID]; ok {
+			continue
+		}
+
+		a.result.AddChange(schema.RBACKindGrant, schema.RBACOpDelete, a.grantKey(role.Name, rm))
+		if !a.params.DryRun {
+			err := a.RoleMenuModel.Delete(ctx, rm.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
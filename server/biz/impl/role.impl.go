@@ -3,12 +3,12 @@ package impl
 import (
 	"context"
 
-	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
 	"github.com/key7men/mag/pkg/errs"
 	"github.com/key7men/mag/server/assist/uuid"
 	"github.com/key7men/mag/server/biz"
 	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -19,11 +19,15 @@ var RoleSet = wire.NewSet(wire.Struct(new(Role), "*"), wire.Bind(new(biz.IRole),
 
 // Role 角色管理
 type Role struct {
-	Enforcer      *casbin.SyncedEnforcer
-	TransModel    model.ITrans
-	RoleModel     model.IRole
-	RoleMenuModel model.IRoleMenu
-	UserModel     model.IUser
+	TransModel      model.ITrans
+	RoleModel       model.IRole
+	RoleMenuModel   model.IRoleMenu
+	MenuModel       model.IMenu
+	MenuActionModel model.IMenuAction
+	UserModel       model.IUser
+	AuditModel      model.IAudit
+	OutboxModel     model.IOutbox
+	EventDispatcher *event.Dispatcher
 }
 
 // Query 查询数据
@@ -60,32 +64,105 @@ func (a *Role) QueryRoleMenus(ctx context.Context, roleID string) (schema.RoleMe
 	return result.Data, nil
 }
 
-// Create 创建数据
-func (a *Role) Create(ctx context.Context, item schema.Role) (*schema.IDResult, error) {
-	err := a.checkName(ctx, item)
+// QueryGrants 查询角色的菜单授权(按角色ID分组，忽略已删除的菜单及动作)
+func (a *Role) QueryGrants(ctx context.Context, roleIDs []string) (map[string]schema.RoleGrants, error) {
+	m := make(map[string]schema.RoleGrants)
+	if len(roleIDs) == 0 {
+		return m, nil
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
+		RoleIDs: roleIDs,
+	})
 	if err != nil {
 		return nil, err
+	} else if len(roleMenuResult.Data) == 0 {
+		return m, nil
 	}
 
-	item.ID = uuid.NewID()
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		for _, rmItem := range item.RoleMenus {
-			rmItem.ID = uuid.NewID()
-			rmItem.RoleID = item.ID
-			err := a.RoleMenuModel.Create(ctx, *rmItem)
-			if err != nil {
-				return err
-			}
+	menuResult, err := a.MenuModel.Query(ctx, schema.MenuQueryParam{
+		IDs: roleMenuResult.Data.ToMenuIDs(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	mMenus := menuResult.Data.ToMap()
+
+	var actionIDs []string
+	for _, item := range roleMenuResult.Data {
+		actionIDs = append(actionIDs, item.ActionID)
+	}
+	actionResult, err := a.MenuActionModel.Query(ctx, schema.MenuActionQueryParam{
+		IDs: actionIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	mActions := make(map[string]*schema.MenuAction)
+	for _, item := range actionResult.Data {
+		mActions[item.ID] = item
+	}
+
+	for _, item := range roleMenuResult.Data {
+		menu, ok := mMenus[item.MenuID]
+		if !ok {
+			continue
 		}
-		return a.RoleModel.Create(ctx, item)
+		action, ok := mActions[item.ActionID]
+		if !ok {
+			continue
+		}
+		m[item.RoleID] = append(m[item.RoleID], &schema.RoleGrant{
+			MenuID:     menu.ID,
+			MenuName:   menu.Name,
+			ActionCode: action.Code,
+			ActionName: action.Name,
+		})
+	}
+	return m, nil
+}
+
+// Create 创建数据
+func (a *Role) Create(ctx context.Context, item schema.Role) (*schema.IDResult, error) {
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.create(ctx, &item)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleCreated, item.ID, item)
 	})
 	if err != nil {
 		return nil, err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.EventDispatcher.Notify()
 	return schema.NewIDResult(item.ID), nil
 }
 
+// 创建数据并记录审计日志(需在事务中调用)
+func (a *Role) create(ctx context.Context, item *schema.Role) error {
+	err := a.checkName(ctx, *item)
+	if err != nil {
+		return err
+	}
+
+	item.ID = uuid.NewID()
+	for _, rmItem := range item.RoleMenus {
+		rmItem.ID = uuid.NewID()
+		rmItem.RoleID = item.ID
+		err := a.RoleMenuModel.Create(ctx, *rmItem)
+		if err != nil {
+			return err
+		}
+	}
+	err = a.RoleModel.Create(ctx, *item)
+	if err != nil {
+		return err
+	}
+
+	return RecordAudit(ctx, a.AuditModel, schema.AuditEntityRole, item.ID, schema.AuditCreate, nil, item)
+}
+
 func (a *Role) checkName(ctx context.Context, item schema.Role) error {
 	result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
 		PaginationParam: schema.PaginationParam{OnlyCount: true},
@@ -94,7 +171,7 @@ func (a *Role) checkName(ctx context.Context, item schema.Role) error {
 	if err != nil {
 		return err
 	} else if result.PageResult.Total > 0 {
-		return errs.New400Response("角色名称已经存在")
+		return errs.New400I18nResponse("error.role_name_exists")
 	}
 	return nil
 }
@@ -134,12 +211,29 @@ func (a *Role) Update(ctx context.Context, id string, item schema.Role) error {
 			}
 		}
 
-		return a.RoleModel.Update(ctx, id, item)
+		err := a.RoleModel.Update(ctx, id, item)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityRole, id, schema.AuditUpdate, oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		if len(addRoleMenus) > 0 || len(delRoleMenus) > 0 {
+			err = PublishEvent(ctx, a.OutboxModel, schema.EventRoleMenusChanged, id, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleUpdated, id, item)
 	})
 	if err != nil {
 		return err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.EventDispatcher.Notify()
 	return nil
 }
 
@@ -163,11 +257,29 @@ func (a *Role) compareRoleMenus(ctx context.Context, oldRoleMenus, newRoleMenus
 
 // Delete 删除数据
 func (a *Role) Delete(ctx context.Context, id string) error {
-	oldItem, err := a.RoleModel.Get(ctx, id)
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		oldItem, err := a.delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleDeleted, id, oldItem)
+	})
 	if err != nil {
 		return err
+	}
+
+	a.EventDispatcher.Notify()
+	return nil
+}
+
+// 删除数据并记录审计日志，返回删除前的数据(需在事务中调用)
+func (a *Role) delete(ctx context.Context, id string) (*schema.Role, error) {
+	oldItem, err := a.RoleModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	} else if oldItem == nil {
-		return errs.ErrNotFound
+		return nil, errs.ErrNotFound
 	}
 
 	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
@@ -175,40 +287,123 @@ func (a *Role) Delete(ctx context.Context, id string) error {
 		RoleIDs:         []string{id},
 	})
 	if err != nil {
-		return err
+		return nil, err
 	} else if userResult.PageResult.Total > 0 {
-		return errs.New400Response("该角色已被赋予用户，不允许删除")
+		return nil, errs.New400I18nResponse("error.role_in_use")
 	}
 
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		err := a.RoleMenuModel.DeleteByRoleID(ctx, id)
+	err = a.RoleMenuModel.DeleteByRoleID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.RoleModel.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityRole, id, schema.AuditDelete, oldItem, nil)
+	if err != nil {
+		return nil, err
+	}
+	return oldItem, nil
+}
+
+// UpdateStatus 更新状态
+func (a *Role) UpdateStatus(ctx context.Context, id string, status int) error {
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		newItem, err := a.updateStatus(ctx, id, status)
 		if err != nil {
 			return err
 		}
 
-		return a.RoleModel.Delete(ctx, id)
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleStatusChanged, id, newItem)
 	})
 	if err != nil {
 		return err
 	}
-
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.EventDispatcher.Notify()
 	return nil
 }
 
-// UpdateStatus 更新状态
-func (a *Role) UpdateStatus(ctx context.Context, id string, status int) error {
+// 更新状态并记录审计日志，返回更新后的数据(需在事务中调用)
+func (a *Role) updateStatus(ctx context.Context, id string, status int) (*schema.Role, error) {
 	oldItem, err := a.RoleModel.Get(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	} else if oldItem == nil {
-		return errs.ErrNotFound
+		return nil, errs.ErrNotFound
 	}
 
+	newItem := *oldItem
+	newItem.Status = status
+
 	err = a.RoleModel.UpdateStatus(ctx, id, status)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
+
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityRole, id, schema.AuditUpdateStatus, oldItem, newItem)
+	if err != nil {
+		return nil, err
+	}
+	return &newItem, nil
+}
+
+// BulkCreate 批量创建数据
+func (a *Role) BulkCreate(ctx context.Context, items []*schema.Role) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(items), func(ctx context.Context, i int) (string, interface{}, error) {
+		item := *items[i]
+		err := a.create(ctx, &item)
+		if err != nil {
+			return "", nil, err
+		}
+		return item.ID, &item, nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleBulkCreated, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkDelete 批量删除数据
+func (a *Role) BulkDelete(ctx context.Context, ids []string) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		oldItem, err := a.delete(ctx, ids[i])
+		if err != nil {
+			return ids[i], nil, err
+		}
+		return ids[i], oldItem, nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleBulkDeleted, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkUpdateStatus 批量更新状态
+func (a *Role) BulkUpdateStatus(ctx context.Context, ids []string, status int) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		newItem, err := a.updateStatus(ctx, ids[i], status)
+		if err != nil {
+			return ids[i], nil, err
+		}
+		return ids[i], newItem, nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventRoleBulkStatusChanged, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
 }
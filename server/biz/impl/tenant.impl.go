@@ -0,0 +1,388 @@
+package impl
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/biz"
+	"github.com/key7men/mag/server/config"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ biz.ITenant = (*Tenant)(nil)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"), wire.Bind(new(biz.ITenant), new(*Tenant)))
+
+// 租户编号(小写字母、数字及中划线，可作为子域名)
+var tenantCodeRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+const (
+	// 识别租户结果的缓存时长(多实例部署时，其他实例的租户变更在该时长后生效)
+	tenantCacheTTL = 30 * time.Second
+	// 识别租户结果的最大缓存数量
+	tenantCacheSize = 1024
+)
+
+// Tenant 租户管理
+type Tenant struct {
+	TransModel      model.ITrans
+	TenantModel     model.ITenant
+	AuditModel      model.IAudit
+	OutboxModel     model.IOutbox
+	EventDispatcher *event.Dispatcher
+	UserBiz         *User
+	cache           tenantCache `wire:"-"`
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	return a.TenantModel.Query(ctx, params, opts...)
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, id string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	item, err := a.TenantModel.Get(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return item, nil
+}
+
+// 规范化并检查租户编号及绑定的域名(编号及域名唯一)
+func (a *Tenant) checkTenant(ctx context.Context, item *schema.Tenant, oldItem *schema.Tenant) error {
+	item.Code = strings.ToLower(strings.TrimSpace(item.Code))
+	item.Domain = schema.TenantHost(strings.TrimSpace(item.Domain))
+	if !tenantCodeRegexp.MatchString(item.Code) {
+		return errs.New400I18nResponse("error.invalid_tenant_code")
+	}
+
+	if oldItem == nil || oldItem.Code != item.Code {
+		result, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{
+			PaginationParam: schema.PaginationParam{OnlyCount: true},
+			Code:            item.Code,
+		})
+		if err != nil {
+			return err
+		} else if result.PageResult.Total > 0 {
+			return errs.New400I18nResponse("error.tenant_code_exists")
+		}
+	}
+
+	if item.Domain != "" && (oldItem == nil || oldItem.Domain != item.Domain) {
+		result, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{
+			PaginationParam: schema.PaginationParam{OnlyCount: true},
+			Domain:          item.Domain,
+		})
+		if err != nil {
+			return err
+		} else if result.PageResult.Total > 0 {
+			return errs.New400I18nResponse("error.tenant_domain_exists")
+		}
+	}
+	return nil
+}
+
+// Create 创建租户并在租户内开通管理员账号(租户管理员拥有该租户的全部权限)
+func (a *Tenant) Create(ctx context.Context, item schema.Tenant) (*schema.IDResult, error) {
+	admin := item.Admin
+	if admin == nil || admin.UserName == "" || admin.RealName == "" || admin.Password == "" {
+		return nil, errs.New400I18nResponse("error.tenant_admin_required")
+	}
+	item.Admin = nil
+
+	err := a.checkTenant(ctx, &item, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item.ID = uuid.NewID()
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		user := &schema.User{
+			UserName: admin.UserName,
+			RealName: admin.RealName,
+			Password: admin.Password,
+			Phone:    admin.Phone,
+			Email:    admin.Email,
+			Status:   1,
+			Creator:  item.Creator,
+		}
+		err := a.UserBiz.create(icontext.NewTenantID(ctx, item.ID), user)
+		if err != nil {
+			return err
+		}
+		item.AdminID = user.ID
+
+		err = a.TenantModel.Create(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityTenant, item.ID, schema.AuditCreate, nil, item)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventTenantCreated, item.ID, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.cache.reset()
+	a.EventDispatcher.Notify()
+	return schema.NewIDResult(item.ID), nil
+}
+
+// Update 更新数据(租户管理员不允许修改)
+func (a *Tenant) Update(ctx context.Context, id string, item schema.Tenant) error {
+	oldItem, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errs.ErrNotFound
+	}
+
+	err = a.checkTenant(ctx, &item, oldItem)
+	if err != nil {
+		return err
+	}
+
+	item.ID = oldItem.ID
+	item.AdminID = oldItem.AdminID
+	item.Creator = oldItem.Creator
+	item.CreatedAt = oldItem.CreatedAt
+	item.Admin = nil
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.TenantModel.Update(ctx, id, item)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityTenant, id, schema.AuditUpdate, oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventTenantUpdated, id, item)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.cache.reset()
+	a.EventDispatcher.Notify()
+	return nil
+}
+
+// Delete 删除数据(租户内的数据保留，租户的用户无法再访问)
+func (a *Tenant) Delete(ctx context.Context, id string) error {
+	oldItem, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errs.ErrNotFound
+	}
+
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.TenantModel.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityTenant, id, schema.AuditDelete, oldItem, nil)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventTenantDeleted, id, oldItem)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.cache.reset()
+	a.EventDispatcher.Notify()
+	return nil
+}
+
+// UpdateStatus 更新状态(禁用后租户的用户无法访问)
+func (a *Tenant) UpdateStatus(ctx context.Context, id string, status int) error {
+	oldItem, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errs.ErrNotFound
+	}
+	newItem := *oldItem
+	newItem.Status = status
+
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.TenantModel.UpdateStatus(ctx, id, status)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityTenant, id, schema.AuditUpdateStatus, oldItem, newItem)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventTenantStatusChanged, id, newItem)
+	})
+	if err != nil {
+		return err
+	}
+
+	a.cache.reset()
+	a.EventDispatcher.Notify()
+	return nil
+}
+
+// Resolve 按请求的Host识别租户(先匹配绑定的域名，再按Tenant.Domain配置的上级域名匹配租户编号)
+func (a *Tenant) Resolve(ctx context.Context, host string) (*schema.Tenant, error) {
+	host = schema.TenantHost(host)
+	if host == "" {
+		return nil, nil
+	}
+
+	// Host由客户端指定，只查询绑定的域名及上级域名下的租户编号
+	domains, err := a.cache.loadDomains(func() (map[string]*schema.Tenant, error) {
+		result, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{})
+		if err != nil {
+			return nil, err
+		}
+
+		domains := make(map[string]*schema.Tenant)
+		for _, item := range result.Data {
+			if item.Domain != "" {
+				domains[item.Domain] = item
+			}
+		}
+		return domains, nil
+	})
+	if err != nil {
+		return nil, err
+	} else if item, ok := domains[host]; ok {
+		return item, nil
+	}
+
+	code, ok := schema.TenantCodeOfHost(host, config.C.Tenant.Domain)
+	if !ok {
+		return nil, nil
+	}
+
+	return a.cache.load("code:"+code, func() (*schema.Tenant, error) {
+		result, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{Code: code})
+		if err != nil {
+			return nil, err
+		} else if len(result.Data) > 0 {
+			return result.Data[0], nil
+		}
+		return nil, nil
+	})
+}
+
+// Lookup 查询指定租户
+func (a *Tenant) Lookup(ctx context.Context, id string) (*schema.Tenant, error) {
+	return a.cache.load("id:"+id, func() (*schema.Tenant, error) {
+		return a.TenantModel.Get(ctx, id)
+	})
+}
+
+// 识别租户结果的缓存(不缓存未识别的结果，缓存的数量超过tenantCacheSize时清除过期的结果)
+type tenantCache struct {
+	mu               sync.RWMutex
+	items            map[string]*tenantCacheItem
+	domains          map[string]*schema.Tenant
+	domainsExpiresAt time.Time
+}
+
+type tenantCacheItem struct {
+	tenant    *schema.Tenant
+	expiresAt time.Time
+}
+
+func (a *tenantCache) load(key string, fn func() (*schema.Tenant, error)) (*schema.Tenant, error) {
+	a.mu.RLock()
+	item, ok := a.items[key]
+	a.mu.RUnlock()
+	if ok && time.Now().Before(item.expiresAt) {
+		return item.tenant, nil
+	}
+
+	tenant, err := fn()
+	if err != nil || tenant == nil {
+		return tenant, err
+	}
+
+	now := time.Now()
+	a.mu.Lock()
+	if len(a.items) >= tenantCacheSize {
+		for k, v := range a.items {
+			if !now.Before(v.expiresAt) {
+				delete(a.items, k)
+			}
+		}
+	}
+	if a.items == nil || len(a.items) >= tenantCacheSize {
+		a.items = make(map[string]*tenantCacheItem)
+	}
+	a.items[key] = &tenantCacheItem{tenant: tenant, expiresAt: now.Add(tenantCacheTTL)}
+	a.mu.Unlock()
+	return tenant, nil
+}
+
+// 加载绑定的域名(域名 -> 租户)
+func (a *tenantCache) loadDomains(fn func() (map[string]*schema.Tenant, error)) (map[string]*schema.Tenant, error) {
+	a.mu.RLock()
+	domains, expiresAt := a.domains, a.domainsExpiresAt
+	a.mu.RUnlock()
+	if domains != nil && time.Now().Before(expiresAt) {
+		return domains, nil
+	}
+
+	domains, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	a.domains = domains
+	a.domainsExpiresAt = time.Now().Add(tenantCacheTTL)
+	a.mu.Unlock()
+	return domains, nil
+}
+
+func (a *tenantCache) reset() {
+	a.mu.Lock()
+	a.items = nil
+	a.domains = nil
+	a.mu.Unlock()
+}
+
+// 检查用户是否为当前上下文中租户的管理员
+func isTenantAdmin(ctx context.Context, tenantModel model.ITenant, userID string) (bool, error) {
+	tenantID, ok := icontext.FromTenantID(ctx)
+	if !ok {
+		return false, nil
+	}
+
+	item, err := tenantModel.Get(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	return item != nil && item.AdminID == userID, nil
+}
@@ -2,14 +2,16 @@ package impl
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
-	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
 	"github.com/key7men/mag/pkg/errs"
 	"github.com/key7men/mag/pkg/util"
 	"github.com/key7men/mag/server/assist/uuid"
 	"github.com/key7men/mag/server/biz"
 	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -20,11 +22,14 @@ var UserSet = wire.NewSet(wire.Struct(new(User), "*"), wire.Bind(new(biz.IUser),
 
 // User 用户管理
 type User struct {
-	Enforcer      *casbin.SyncedEnforcer
-	TransModel    model.ITrans
-	UserModel     model.IUser
-	UserRoleModel model.IUserRole
-	RoleModel     model.IRole
+	TransModel      model.ITrans
+	UserModel       model.IUser
+	UserRoleModel   model.IUserRole
+	RoleModel       model.IRole
+	AuditModel      model.IAudit
+	OutboxModel     model.IOutbox
+	TenantModel     model.ITenant
+	EventDispatcher *event.Dispatcher
 }
 
 // Query 查询数据
@@ -80,36 +85,56 @@ func (a *User) Get(ctx context.Context, id string, opts ...schema.UserQueryOptio
 
 // Create 创建数据
 func (a *User) Create(ctx context.Context, item schema.User) (*schema.IDResult, error) {
-	err := a.checkUserName(ctx, item)
+	var id string
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		// 事务重试时会重新执行，须基于原始数据创建(create会修改密码等字段)
+		u := item
+		err := a.create(ctx, &u)
+		if err != nil {
+			return err
+		}
+		id = u.ID
+
+		c := u
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserCreated, u.ID, c.CleanSecure())
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	a.EventDispatcher.Notify()
+	return schema.NewIDResult(id), nil
+}
+
+// 创建数据并记录审计日志(需在事务中调用)
+func (a *User) create(ctx context.Context, item *schema.User) error {
+	err := a.checkUserName(ctx, *item)
+	if err != nil {
+		return err
+	}
+
 	item.Password = util.SHA1HashString(item.Password)
 	item.ID = uuid.NewID()
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		for _, urItem := range item.UserRoles {
-			urItem.ID = uuid.NewID()
-			urItem.UserID = item.ID
-			err := a.UserRoleModel.Create(ctx, *urItem)
-			if err != nil {
-				return err
-			}
+	for _, urItem := range item.UserRoles {
+		urItem.ID = uuid.NewID()
+		urItem.UserID = item.ID
+		err := a.UserRoleModel.Create(ctx, *urItem)
+		if err != nil {
+			return err
 		}
+	}
 
-		return a.UserModel.Create(ctx, item)
-	})
+	err = a.UserModel.Create(ctx, *item)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return schema.NewIDResult(item.ID), nil
+	return RecordAudit(ctx, a.AuditModel, schema.AuditEntityUser, item.ID, schema.AuditCreate, nil, item)
 }
 
 func (a *User) checkUserName(ctx context.Context, item schema.User) error {
 	if item.UserName == schema.GetRootUser().UserName {
-		return errs.New400Response("用户名不合法")
+		return errs.New400I18nResponse("error.user_name_not_allowed")
 	}
 
 	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
@@ -119,7 +144,22 @@ func (a *User) checkUserName(ctx context.Context, item schema.User) error {
 	if err != nil {
 		return err
 	} else if result.PageResult.Total > 0 {
-		return errs.New400Response("用户名已经存在")
+		return errs.New400I18nResponse("error.user_name_exists")
+	}
+	return nil
+}
+
+// 检查用户是否为所属租户的管理员(租户管理员不允许删除或禁用)
+func (a *User) checkTenantAdmin(ctx context.Context, item *schema.User) error {
+	if item.TenantID == "" {
+		return nil
+	}
+
+	tenant, err := a.TenantModel.Get(ctx, item.TenantID)
+	if err != nil {
+		return err
+	} else if tenant != nil && tenant.AdminID == item.ID {
+		return errs.New400I18nResponse("error.tenant_admin_not_allowed")
 	}
 	return nil
 }
@@ -138,6 +178,12 @@ func (a *User) Update(ctx context.Context, id string, item schema.User) error {
 		}
 	}
 
+	if item.Status != 1 {
+		if err := a.checkTenantAdmin(ctx, oldItem); err != nil {
+			return err
+		}
+	}
+
 	if item.Password != "" {
 		item.Password = util.SHA1HashString(item.Password)
 	} else {
@@ -165,13 +211,32 @@ func (a *User) Update(ctx context.Context, id string, item schema.User) error {
 			}
 		}
 
-		return a.UserModel.Update(ctx, id, item)
+		err := a.UserModel.Update(ctx, id, item)
+		if err != nil {
+			return err
+		}
+
+		err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityUser, id, schema.AuditUpdate, oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		// 事务重试时会重新执行，不能清除待保存数据的密码
+		c := item
+		if len(addUserRoles) > 0 || len(delUserRoles) > 0 {
+			err = PublishEvent(ctx, a.OutboxModel, schema.EventUserRolesChanged, id, c.CleanSecure())
+			if err != nil {
+				return err
+			}
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserUpdated, id, c.CleanSecure())
 	})
 	if err != nil {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.EventDispatcher.Notify()
 	return nil
 }
 
@@ -195,44 +260,415 @@ func (a *User) compareUserRoles(ctx context.Context, oldUserRoles, newUserRoles
 
 // Delete 删除数据
 func (a *User) Delete(ctx context.Context, id string) error {
-	oldItem, err := a.UserModel.Get(ctx, id)
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		oldItem, err := a.delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserDeleted, id, oldItem.CleanSecure())
+	})
 	if err != nil {
 		return err
+	}
+
+	a.EventDispatcher.Notify()
+	return nil
+}
+
+// 删除数据并记录审计日志，返回删除前的数据(需在事务中调用)
+func (a *User) delete(ctx context.Context, id string) (*schema.User, error) {
+	oldItem, err := a.UserModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	} else if oldItem == nil {
-		return errs.ErrNotFound
+		return nil, errs.ErrNotFound
+	} else if err := a.checkTenantAdmin(ctx, oldItem); err != nil {
+		return nil, err
 	}
 
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		err := a.UserRoleModel.DeleteByUserID(ctx, id)
+	err = a.UserRoleModel.DeleteByUserID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.UserModel.Delete(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityUser, id, schema.AuditDelete, oldItem, nil)
+	if err != nil {
+		return nil, err
+	}
+	return oldItem, nil
+}
+
+// UpdateStatus 更新状态
+func (a *User) UpdateStatus(ctx context.Context, id string, status int) error {
+	err := ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		newItem, err := a.updateStatus(ctx, id, status)
 		if err != nil {
 			return err
 		}
 
-		return a.UserModel.Delete(ctx, id)
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserStatusChanged, id, newItem.CleanSecure())
 	})
 	if err != nil {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.EventDispatcher.Notify()
 	return nil
 }
 
-// UpdateStatus 更新状态
-func (a *User) UpdateStatus(ctx context.Context, id string, status int) error {
+// 更新状态并记录审计日志，返回更新后的数据(需在事务中调用)
+func (a *User) updateStatus(ctx context.Context, id string, status int) (*schema.User, error) {
 	oldItem, err := a.UserModel.Get(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	} else if oldItem == nil {
-		return errs.ErrNotFound
+		return nil, errs.ErrNotFound
+	} else if status != 1 {
+		if err := a.checkTenantAdmin(ctx, oldItem); err != nil {
+			return nil, err
+		}
 	}
-	oldItem.Status = status
+	newItem := *oldItem
+	newItem.Status = status
 
 	err = a.UserModel.UpdateStatus(ctx, id, status)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityUser, id, schema.AuditUpdateStatus, oldItem, newItem)
+	if err != nil {
+		return nil, err
+	}
+	return &newItem, nil
+}
+
+// BulkCreate 批量创建数据
+func (a *User) BulkCreate(ctx context.Context, items []*schema.User) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(items), func(ctx context.Context, i int) (string, interface{}, error) {
+		item := *items[i]
+		if item.Password == "" {
+			return "", nil, errs.New400I18nResponse("error.password_required")
+		}
+
+		err := a.create(ctx, &item)
+		if err != nil {
+			return "", nil, err
+		}
+		return item.ID, item.CleanSecure(), nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserBulkCreated, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkDelete 批量删除数据
+func (a *User) BulkDelete(ctx context.Context, ids []string) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		oldItem, err := a.delete(ctx, ids[i])
+		if err != nil {
+			return ids[i], nil, err
+		}
+		return ids[i], oldItem.CleanSecure(), nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserBulkDeleted, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkUpdateStatus 批量更新状态
+func (a *User) BulkUpdateStatus(ctx context.Context, ids []string, status int) (*schema.BulkResult, error) {
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		newItem, err := a.updateStatus(ctx, ids[i], status)
+		if err != nil {
+			return ids[i], nil, err
+		}
+		return ids[i], newItem.CleanSecure(), nil
+	}, func(ctx context.Context, data []interface{}) error {
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserBulkStatusChanged, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// BulkAssignRoles 批量分配角色(replace为true时替换用户已有的角色，否则追加)
+func (a *User) BulkAssignRoles(ctx context.Context, ids []string, roleIDs []string, replace bool) (*schema.BulkResult, error) {
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		IDs: roleIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	mRoles := roleResult.Data.ToMap()
+	for _, roleID := range roleIDs {
+		if _, ok := mRoles[roleID]; !ok {
+			return nil, errs.New400I18nResponse("error.invalid_role")
+		}
+	}
+
+	result, err := ExecBulk(ctx, a.TransModel, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		newItem, ok, err := a.assignRoles(ctx, ids[i], roleIDs, replace)
+		if err != nil {
+			return ids[i], nil, err
+		} else if !ok {
+			return ids[i], nil, nil
+		}
+		return ids[i], newItem.CleanSecure(), nil
+	}, func(ctx context.Context, data []interface{}) error {
+		if len(data) == 0 {
+			return nil
+		}
+		return PublishEvent(ctx, a.OutboxModel, schema.EventUserBulkRolesChanged, uuid.NewID(), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// 分配角色并记录审计日志，返回更新后的数据及角色是否变更(需在事务中调用)
+func (a *User) assignRoles(ctx context.Context, id string, roleIDs []string, replace bool) (*schema.User, bool, error) {
+	oldItem, err := a.Get(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newItem := *oldItem
+	newItem.UserRoles = nil
+	if !replace {
+		newItem.UserRoles = append(newItem.UserRoles, oldItem.UserRoles...)
+	}
+	mOldUserRoles := oldItem.UserRoles.ToMap()
+	mNewUserRoles := newItem.UserRoles.ToMap()
+	for _, roleID := range roleIDs {
+		if _, ok := mNewUserRoles[roleID]; ok {
+			continue
+		}
+
+		urItem, ok := mOldUserRoles[roleID]
+		if !ok {
+			urItem = &schema.UserRole{UserID: id, RoleID: roleID}
+		}
+		mNewUserRoles[roleID] = urItem
+		newItem.UserRoles = append(newItem.UserRoles, urItem)
+	}
+
+	addUserRoles, delUserRoles := a.compareUserRoles(ctx, oldItem.UserRoles, newItem.UserRoles)
+	if len(addUserRoles) == 0 && len(delUserRoles) == 0 {
+		return &newItem, false, nil
+	}
+
+	for _, urItem := range addUserRoles {
+		urItem.ID = uuid.NewID()
+		err := a.UserRoleModel.Create(ctx, *urItem)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	for _, urItem := range delUserRoles {
+		err := a.UserRoleModel.Delete(ctx, urItem.ID)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	err = RecordAudit(ctx, a.AuditModel, schema.AuditEntityUser, id, schema.AuditUpdate, oldItem, newItem)
+	if err != nil {
+		return nil, false, err
+	}
+	return &newItem, true, nil
+}
+
+// Import 导入数据(按用户名新建或更新，dryRun为true时仅校验)
+// 任意行校验失败时不导入任何数据；校验通过后在同一事务中按创建及更新的规则逐行导入
+func (a *User) Import(ctx context.Context, rows []*schema.UserImportRow, dryRun bool) (*schema.ImportResult, error) {
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+	mRoles := make(map[string]*schema.Role)
+	for _, item := range roleResult.Data {
+		mRoles[item.Name] = item
+	}
+
+	result := &schema.ImportResult{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]*schema.ImportRowResult, len(rows)),
+	}
+	items := make([]*schema.User, len(rows))
+	mUserNames := make(map[string]int)
+	for i, row := range rows {
+		item, rowResult, err := a.checkImportRow(ctx, row, mRoles, mUserNames)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case rowResult.Status == schema.FailStatus:
+			result.Failed++
+		case rowResult.Action == schema.ImportCreate:
+			result.Created++
+		default:
+			result.Updated++
+		}
+		items[i] = item
+		result.Rows[i] = rowResult
+	}
+
+	if dryRun || result.Failed > 0 || len(items) == 0 {
+		return result, nil
+	}
+
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		for i, item := range items {
+			rowResult := result.Rows[i]
+			if rowResult.Action == schema.ImportUpdate {
+				err := a.Update(ctx, item.ID, *item)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			idResult, err := a.Create(ctx, *item)
+			if err != nil {
+				return err
+			}
+			rowResult.ID = idResult.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Applied = true
+	a.EventDispatcher.Notify()
+	return result, nil
+}
+
+// 校验导入行并转换为用户数据(已存在的用户更新，可选列为空时保留原值)
+func (a *User) checkImportRow(ctx context.Context, row *schema.UserImportRow, mRoles map[string]*schema.Role, mUserNames map[string]int) (*schema.User, *schema.ImportRowResult, error) {
+	result := &schema.ImportRowResult{
+		Row:    row.Row,
+		Key:    row.UserName,
+		Action: schema.ImportCreate,
+		Status: schema.OKStatus,
+	}
+	item := &schema.User{
+		UserName: row.UserName,
+		RealName: row.RealName,
+		Password: row.Password,
+		Phone:    row.Phone,
+		Email:    row.Email,
+		Status:   1,
+	}
+
+	var oldItem *schema.User
+	if v := item.UserName; v == "" {
+		result.AddError("user_name", errs.New400I18nResponse("error.import_required"))
+	} else if v == schema.GetRootUser().UserName {
+		result.AddError("user_name", errs.New400I18nResponse("error.user_name_not_allowed"))
+	} else if n, ok := mUserNames[v]; ok {
+		result.AddError("user_name", errs.New400I18nResponse("error.import_duplicate_row", n))
+	} else {
+		mUserNames[v] = row.Row
+
+		userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{UserName: v})
+		if err != nil {
+			return nil, nil, err
+		} else if len(userResult.Data) > 0 {
+			oldItem, err = a.Get(ctx, userResult.Data[0].ID)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if item.RealName == "" {
+		result.AddError("real_name", errs.New400I18nResponse("error.import_required"))
+	}
+
+	if oldItem != nil {
+		result.Action = schema.ImportUpdate
+		result.ID = oldItem.ID
+		item.ID = oldItem.ID
+		item.Status = oldItem.Status
+		if item.Phone == "" {
+			item.Phone = oldItem.Phone
+		}
+		if item.Email == "" {
+			item.Email = oldItem.Email
+		}
+	} else if item.Password == "" {
+		result.AddError("password", errs.New400I18nResponse("error.password_required"))
+	}
+
+	switch row.Status {
+	case "":
+	case "1", "2":
+		item.Status, _ = strconv.Atoi(row.Status)
+	default:
+		result.AddError("status", errs.New400I18nResponse("error.import_invalid_status"))
+	}
+
+	roleNames := splitImportNames(row.Roles)
+	if len(roleNames) == 0 {
+		if oldItem != nil {
+			item.UserRoles = oldItem.UserRoles
+		} else {
+			result.AddError("roles", errs.New400I18nResponse("error.import_required"))
+		}
+	}
+	for _, name := range roleNames {
+		role, ok := mRoles[name]
+		if !ok {
+			result.AddError("roles", errs.New400I18nResponse("error.import_unknown_role", name))
+			continue
+		}
+		item.UserRoles = append(item.UserRoles, &schema.UserRole{RoleID: role.ID})
+	}
+
+	return item, result, nil
+}
+
+// 拆分以逗号或分号分隔的名称列表(去除空白及重复项)
+func splitImportNames(s string) []string {
+	var names []string
+	m := make(map[string]struct{})
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == '，' || r == '；'
+	}) {
+		name = strings.TrimSpace(name)
+		if _, ok := m[name]; ok || name == "" {
+			continue
+		}
+		names = append(names, name)
+		m[name] = struct{}{}
+	}
+	return names
 }
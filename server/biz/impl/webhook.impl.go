@@ -0,0 +1,202 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/biz"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/webhook"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ biz.IWebhook = (*Webhook)(nil)
+
+// WebhookSet 注入Webhook
+var WebhookSet = wire.NewSet(wire.Struct(new(Webhook), "*"), wire.Bind(new(biz.IWebhook), new(*Webhook)))
+
+// Webhook webhook订阅管理
+type Webhook struct {
+	TransModel           model.ITrans
+	WebhookModel         model.IWebhook
+	WebhookDeliveryModel model.IWebhookDelivery
+	Deliverer            *webhook.Deliverer
+}
+
+// Query 查询数据(不返回签名密钥)
+func (a *Webhook) Query(ctx context.Context, params schema.WebhookQueryParam, opts ...schema.WebhookQueryOptions) (*schema.WebhookQueryResult, error) {
+	result, err := a.WebhookModel.Query(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range result.Data {
+		item.CleanSecure()
+	}
+	return result, nil
+}
+
+// Get 查询指定数据(不返回签名密钥)
+func (a *Webhook) Get(ctx context.Context, id string, opts ...schema.WebhookQueryOptions) (*schema.Webhook, error) {
+	item, err := a.WebhookModel.Get(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	return item.CleanSecure(), nil
+}
+
+// Create 创建数据(未指定签名密钥时自动生成，仅在创建时返回签名密钥)
+func (a *Webhook) Create(ctx context.Context, item schema.Webhook) (*schema.WebhookSecretResult, error) {
+	item.ID = uuid.NewID()
+	if item.Secret == "" {
+		item.Secret = webhook.NewSecret()
+	}
+
+	err := a.WebhookModel.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.WebhookSecretResult{ID: item.ID, Secret: item.Secret}, nil
+}
+
+// Update 更新数据(未指定签名密钥时保持不变)
+func (a *Webhook) Update(ctx context.Context, id string, item schema.Webhook) error {
+	oldItem, err := a.WebhookModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errs.ErrNotFound
+	}
+
+	item.ID = oldItem.ID
+	item.Creator = oldItem.Creator
+	item.CreatedAt = oldItem.CreatedAt
+	if item.Secret == "" {
+		item.Secret = oldItem.Secret
+	}
+
+	return a.WebhookModel.Update(ctx, id, item)
+}
+
+// Delete 删除数据(同时删除投递记录)
+func (a *Webhook) Delete(ctx context.Context, id string) error {
+	oldItem, err := a.WebhookModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errs.ErrNotFound
+	}
+
+	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.WebhookDeliveryModel.DeleteByWebhookID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return a.WebhookModel.Delete(ctx, id)
+	})
+}
+
+// UpdateStatus 更新状态
+func (a *Webhook) UpdateStatus(ctx context.Context, id string, status int) error {
+	oldItem, err := a.WebhookModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errs.ErrNotFound
+	}
+
+	return a.WebhookModel.UpdateStatus(ctx, id, status)
+}
+
+// RotateSecret 重新生成签名密钥(原密钥立即失效)
+func (a *Webhook) RotateSecret(ctx context.Context, id string) (*schema.WebhookSecretResult, error) {
+	item, err := a.WebhookModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	item.Secret = webhook.NewSecret()
+	err = a.WebhookModel.Update(ctx, id, *item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.WebhookSecretResult{ID: item.ID, Secret: item.Secret}, nil
+}
+
+// QueryDeliveries 查询投递记录
+func (a *Webhook) QueryDeliveries(ctx context.Context, params schema.WebhookDeliveryQueryParam, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDeliveryQueryResult, error) {
+	return a.WebhookDeliveryModel.Query(ctx, params, opts...)
+}
+
+// Ping 发送测试事件(创建待投递记录，由投递器异步投递)
+func (a *Webhook) Ping(ctx context.Context, id string) (*schema.WebhookDelivery, error) {
+	hook, err := a.WebhookModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if hook == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	data, err := util.JSONMarshal(&struct {
+		WebhookID string `json:"webhook_id"`
+	}{hook.ID})
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	eventID := uuid.NewID()
+	payload, err := webhook.NewPayload(eventID, schema.EventWebhookPing, time.Now(), data)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	item := webhook.NewDelivery(hook.ID, eventID, schema.EventWebhookPing, payload)
+	err = a.WebhookDeliveryModel.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Deliverer.Notify()
+	return &item, nil
+}
+
+// Redeliver 重新投递(将记录设为待投递并立即由投递器异步投递，死信记录也可以手动再投递一次)
+func (a *Webhook) Redeliver(ctx context.Context, id, deliveryID string) (*schema.WebhookDelivery, error) {
+	hook, err := a.WebhookModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if hook == nil {
+		return nil, errs.ErrNotFound
+	}
+
+	item, err := a.WebhookDeliveryModel.Get(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	} else if item == nil || item.WebhookID != hook.ID {
+		return nil, errs.ErrNotFound
+	} else if item.Status == schema.WebhookDeliverySucceeded {
+		return nil, errs.New400I18nResponse("error.delivery_succeeded")
+	}
+
+	item.Status = schema.WebhookDeliveryPending
+	item.NextRetryAt = time.Now()
+	err = a.WebhookDeliveryModel.Update(ctx, item.ID, *item)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Deliverer.Notify()
+	return item, nil
+}
@@ -0,0 +1,15 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// ILog 系统日志业务逻辑接口
+type ILog interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.LogQueryParam, opts ...schema.LogQueryOptions) (*schema.LogQueryResult, error)
+	// 查询指定跟踪ID的全部日志(按时间先后排序)
+	QueryTrace(ctx context.Context, traceID string) (schema.Logs, error)
+}
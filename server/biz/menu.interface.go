@@ -22,4 +22,10 @@ type IMenu interface {
 	Delete(ctx context.Context, id string) error
 	// 更新状态
 	UpdateStatus(ctx context.Context, id string, status int) error
+	// 批量创建数据
+	BulkCreate(ctx context.Context, items []*schema.Menu) (*schema.BulkResult, error)
+	// 批量删除数据
+	BulkDelete(ctx context.Context, ids []string) (*schema.BulkResult, error)
+	// 批量更新状态
+	BulkUpdateStatus(ctx context.Context, ids []string, status int) (*schema.BulkResult, error)
 }
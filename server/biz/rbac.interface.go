@@ -0,0 +1,17 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IRBAC RBAC模型配置业务逻辑接口
+type IRBAC interface {
+	// 导出菜单、动作、资源、角色及授权
+	Export(ctx context.Context) (*schema.RBACDocument, error)
+	// 对比当前数据并按业务标识导入(dryRun为true时仅返回差异)
+	Import(ctx context.Context, doc *schema.RBACDocument, params schema.RBACImportParam) (*schema.RBACImportResult, error)
+	// 合并菜单数据文件(新增缺少的菜单及动作并同步资源，prune为true时删除文件中不存在的菜单及动作)
+	MergeMenus(ctx context.Context, dataFile string, prune bool) (*schema.RBACImportResult, error)
+}
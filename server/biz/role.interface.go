@@ -12,6 +12,8 @@ type IRole interface {
 	Query(ctx context.Context, params schema.RoleQueryParam, opts ...schema.RoleQueryOptions) (*schema.RoleQueryResult, error)
 	// 查询指定数据
 	Get(ctx context.Context, id string, opts ...schema.RoleQueryOptions) (*schema.Role, error)
+	// 查询角色的菜单授权(按角色ID分组)
+	QueryGrants(ctx context.Context, roleIDs []string) (map[string]schema.RoleGrants, error)
 	// 创建数据
 	Create(ctx context.Context, item schema.Role) (*schema.IDResult, error)
 	// 更新数据
@@ -20,4 +22,10 @@ type IRole interface {
 	Delete(ctx context.Context, id string) error
 	// 更新状态
 	UpdateStatus(ctx context.Context, id string, status int) error
+	// 批量创建数据
+	BulkCreate(ctx context.Context, items []*schema.Role) (*schema.BulkResult, error)
+	// 批量删除数据
+	BulkDelete(ctx context.Context, ids []string) (*schema.BulkResult, error)
+	// 批量更新状态
+	BulkUpdateStatus(ctx context.Context, ids []string, status int) (*schema.BulkResult, error)
 }
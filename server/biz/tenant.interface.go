@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// ITenant 租户管理业务逻辑接口
+type ITenant interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, id string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error)
+	// 创建租户并开通租户管理员
+	Create(ctx context.Context, item schema.Tenant) (*schema.IDResult, error)
+	// 更新数据
+	Update(ctx context.Context, id string, item schema.Tenant) error
+	// 删除数据
+	Delete(ctx context.Context, id string) error
+	// 更新状态
+	UpdateStatus(ctx context.Context, id string, status int) error
+	// 按请求的Host识别租户(未识别时返回nil，结果会短暂缓存)
+	Resolve(ctx context.Context, host string) (*schema.Tenant, error)
+	// 查询指定租户(不存在时返回nil，结果会短暂缓存)
+	Lookup(ctx context.Context, id string) (*schema.Tenant, error)
+}
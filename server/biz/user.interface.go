@@ -22,4 +22,14 @@ type IUser interface {
 	Delete(ctx context.Context, id string) error
 	// 更新状态
 	UpdateStatus(ctx context.Context, id string, status int) error
+	// 批量创建数据
+	BulkCreate(ctx context.Context, items []*schema.User) (*schema.BulkResult, error)
+	// 批量删除数据
+	BulkDelete(ctx context.Context, ids []string) (*schema.BulkResult, error)
+	// 批量更新状态
+	BulkUpdateStatus(ctx context.Context, ids []string, status int) (*schema.BulkResult, error)
+	// 批量分配角色
+	BulkAssignRoles(ctx context.Context, ids []string, roleIDs []string, replace bool) (*schema.BulkResult, error)
+	// 导入数据
+	Import(ctx context.Context, rows []*schema.UserImportRow, dryRun bool) (*schema.ImportResult, error)
 }
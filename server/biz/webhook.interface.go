@@ -0,0 +1,31 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IWebhook webhook订阅业务逻辑接口
+type IWebhook interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.WebhookQueryParam, opts ...schema.WebhookQueryOptions) (*schema.WebhookQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, id string, opts ...schema.WebhookQueryOptions) (*schema.Webhook, error)
+	// 创建数据(返回签名密钥)
+	Create(ctx context.Context, item schema.Webhook) (*schema.WebhookSecretResult, error)
+	// 更新数据
+	Update(ctx context.Context, id string, item schema.Webhook) error
+	// 删除数据
+	Delete(ctx context.Context, id string) error
+	// 更新状态
+	UpdateStatus(ctx context.Context, id string, status int) error
+	// 重新生成签名密钥
+	RotateSecret(ctx context.Context, id string) (*schema.WebhookSecretResult, error)
+	// 查询投递记录
+	QueryDeliveries(ctx context.Context, params schema.WebhookDeliveryQueryParam, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDeliveryQueryResult, error)
+	// 发送测试事件(异步投递，返回待投递的记录)
+	Ping(ctx context.Context, id string) (*schema.WebhookDelivery, error)
+	// 重新投递(异步投递，返回待投递的记录)
+	Redeliver(ctx context.Context, id, deliveryID string) (*schema.WebhookDelivery, error)
+}
@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	// C 全局配置(需要先执行MustLoad，否则拿不到配置)
+	// C 启动时加载的全局配置(需要先执行MustLoad，否则拿不到配置)
+	// 热更新不会修改C，支持热更新的配置项(见reloadableFields)须通过Current读取或使用OnReload重建组件，其余配置项变更后需要重启服务
 	C    = new(Config)
 	once sync.Once
 )
@@ -19,29 +20,34 @@ var (
 // MustLoad 加载配置
 func MustLoad(fpaths ...string) {
 	once.Do(func() {
-		loaders := []multiconfig.Loader{
-			&multiconfig.TagLoader{},
-			&multiconfig.EnvironmentLoader{},
-		}
+		newLoader(fpaths...).MustLoad(C)
+		paths = fpaths
+		current.Store(C)
+	})
+}
 
-		for _, fpath := range fpaths {
-			if strings.HasSuffix(fpath, "toml") {
-				loaders = append(loaders, &multiconfig.TOMLLoader{Path: fpath})
-			}
-			if strings.HasSuffix(fpath, "json") {
-				loaders = append(loaders, &multiconfig.JSONLoader{Path: fpath})
-			}
-			if strings.HasSuffix(fpath, "yaml") {
-				loaders = append(loaders, &multiconfig.YAMLLoader{Path: fpath})
-			}
-		}
+func newLoader(fpaths ...string) *multiconfig.DefaultLoader {
+	loaders := []multiconfig.Loader{
+		&multiconfig.TagLoader{},
+		&multiconfig.EnvironmentLoader{},
+	}
 
-		m := multiconfig.DefaultLoader{
-			Loader:    multiconfig.MultiLoader(loaders...),
-			Validator: multiconfig.MultiValidator(&multiconfig.RequiredValidator{}),
+	for _, fpath := range fpaths {
+		if strings.HasSuffix(fpath, "toml") {
+			loaders = append(loaders, &multiconfig.TOMLLoader{Path: fpath})
 		}
-		m.MustLoad(C)
-	})
+		if strings.HasSuffix(fpath, "json") {
+			loaders = append(loaders, &multiconfig.JSONLoader{Path: fpath})
+		}
+		if strings.HasSuffix(fpath, "yaml") {
+			loaders = append(loaders, &multiconfig.YAMLLoader{Path: fpath})
+		}
+	}
+
+	return &multiconfig.DefaultLoader{
+		Loader:    multiconfig.MultiLoader(loaders...),
+		Validator: multiconfig.MultiValidator(&multiconfig.RequiredValidator{}),
+	}
 }
 
 // PrintWithJSON 基于JSON格式输出配置
@@ -62,16 +68,24 @@ type Config struct {
 	Static          string
 	Swagger      bool
 	PrintConfig  bool
+	Store        string
 	HTTP         HTTP
 	Menu         Menu
 	Casbin       Casbin
 	Log          Log
 	LogGormHook  LogGormHook
 	LogMongoHook LogMongoHook
+	LogRetention LogRetention
 	Root         Root
+	Tenant       Tenant
 	JWTAuth      JWTAuth
 	Monitor      Monitor
+	Trace        Trace
+	Metrics      Metrics
 	Captcha      Captcha
+	I18n         I18n
+	Bulk         Bulk
+	Import       Import
 	RateLimiter  RateLimiter
 	CORS         CORS
 	GZIP         GZIP
@@ -81,6 +95,8 @@ type Config struct {
 	Postgres     Postgres
 	Sqlite3      Sqlite3
 	Mongo        Mongo
+	Event        Event
+	Webhook      Webhook
 	UniqueID     struct {
 		Type      string
 		Snowflake struct {
@@ -99,6 +115,8 @@ func (c *Config) IsDebugMode() bool {
 type Menu struct {
 	Enable bool
 	Data   string
+	Merge  bool
+	Prune  bool
 }
 
 // Casbin casbin配置参数
@@ -110,6 +128,42 @@ type Casbin struct {
 	AutoLoadInternal int
 }
 
+// Event 领域事件配置参数
+type Event struct {
+	Interval         int
+	BatchSize        int
+	MaxRetries       int
+	RetryInterval    int
+	MaxRetryInterval int
+	LeaseTimeout     int
+	Webhook          EventWebhook
+	RedisStream      EventRedisStream
+}
+
+// EventWebhook 领域事件webhook投递配置参数(启动时注册为webhook订阅)
+type EventWebhook struct {
+	Enable bool
+	URLs   []string
+}
+
+// EventRedisStream 领域事件redis stream投递配置参数
+type EventRedisStream struct {
+	Enable  bool
+	RedisDB int
+	Stream  string
+	MaxLen  int64
+}
+
+// Webhook webhook订阅投递配置参数
+type Webhook struct {
+	Interval         int
+	BatchSize        int
+	MaxRetries       int
+	RetryInterval    int
+	MaxRetryInterval int
+	Timeout          int
+}
+
 // LogHook 日志钩子
 type LogHook string
 
@@ -123,17 +177,69 @@ func (h LogHook) IsMongo() bool {
 	return h == "mongo"
 }
 
+// CheckLogStore 检查日志钩子写入的存储与系统日志接口读取的存储(Store及Gorm.DBType)是否一致
+func (c *Config) CheckLogStore() error {
+	if !c.Log.EnableHook {
+		return nil
+	}
+
+	switch {
+	case c.Log.Hook.IsGorm():
+		if c.Store != "gorm" || c.LogGormHook.DBType != c.Gorm.DBType {
+			return fmt.Errorf("log hook writes to gorm(%s), but logs are queried from %s", c.LogGormHook.DBType, c.storeName())
+		}
+	case c.Log.Hook.IsMongo():
+		if c.Store != "mongo" {
+			return fmt.Errorf("log hook writes to mongo, but logs are queried from %s", c.storeName())
+		}
+	}
+	return nil
+}
+
+func (c *Config) storeName() string {
+	if c.Store == "gorm" {
+		return "gorm(" + c.Gorm.DBType + ")"
+	}
+	return c.Store
+}
+
 // Log 日志配置参数
 type Log struct {
-	Level         int
-	Format        string
-	Output        string
-	OutputFile    string
-	EnableHook    bool
-	HookLevels    []string
-	Hook          LogHook
-	HookMaxThread int
-	HookMaxBuffer int
+	Level             int
+	Format            string
+	Output            string
+	OutputFile        string
+	EnableHook        bool
+	HookLevels        []string
+	Hook              LogHook
+	HookMaxThread     int
+	HookMaxBuffer     int
+	HookBatchSize     int
+	HookFlushInterval int
+	HookOverflow      string
+	HookMaxRetries    int
+	HookRetryInterval int
+	HookSpoolDir      string
+	Rotate            LogRotate
+	Outputs           []LogOutput
+}
+
+// LogRotate 日志文件滚动配置
+type LogRotate struct {
+	MaxSize    int
+	MaxBackups int
+	MaxAge     int
+	Compress   bool
+	LocalTime  bool
+	Interval   int
+}
+
+// LogOutput 日志输出配置
+type LogOutput struct {
+	Output string
+	Level  int
+	Format string
+	File   string
 }
 
 // LogGormHook 日志gorm钩子配置
@@ -148,6 +254,16 @@ type LogGormHook struct {
 // LogMongoHook 日志mongo钩子配置
 type LogMongoHook struct {
 	Collection string
+	TTL        int
+}
+
+// LogRetention 日志保留策略
+type LogRetention struct {
+	Enable     bool
+	MaxAge     int
+	Interval   int
+	BatchSize  int
+	ArchiveDir string
 }
 
 // Root root用户
@@ -157,6 +273,12 @@ type Root struct {
 	RealName string
 }
 
+// Tenant 多租户配置参数
+type Tenant struct {
+	Enable bool
+	Domain string
+}
+
 // JWTAuth 用户认证
 type JWTAuth struct {
 	Enable        bool
@@ -176,7 +298,9 @@ type HTTP struct {
 	CertFile         string
 	KeyFile          string
 	ShutdownTimeout  int
+	ShutdownDelay    int
 	MaxContentLength int64
+	ProblemJSON      bool
 }
 
 // Monitor 监控配置参数
@@ -186,6 +310,26 @@ type Monitor struct {
 	ConfigDir string
 }
 
+// Metrics prometheus指标配置参数
+type Metrics struct {
+	Enable bool
+	Path   string
+	Addr   string
+}
+
+// Trace OpenTelemetry链路跟踪配置参数
+type Trace struct {
+	Enable      bool
+	ServiceName string
+	Exporter    string
+	Endpoint    string
+	URLPath     string
+	Insecure    bool
+	Headers     map[string]string
+	File        string
+	SampleRatio float64
+}
+
 // Captcha 图形验证码配置参数
 type Captcha struct {
 	Store       string
@@ -196,11 +340,44 @@ type Captcha struct {
 	RedisPrefix string
 }
 
+// I18n 多语言配置参数
+type I18n struct {
+	DefaultLocale string
+	Dir           string
+}
+
+// Bulk 批量操作配置参数
+type Bulk struct {
+	MaxItems int
+}
+
+// Import 数据导入配置参数
+type Import struct {
+	MaxRows int
+	MaxSize int64
+}
+
 // RateLimiter 请求频率限制配置参数
 type RateLimiter struct {
-	Enable  bool
-	Count   int64
-	RedisDB int
+	Enable         bool
+	Store          string
+	Count          int64
+	RedisDB        int
+	RedisPrefix    string
+	RedisTimeout   int
+	APIKeyHeader   string
+	TrustedProxies []string
+	Policies       []RateLimitPolicy
+}
+
+// RateLimitPolicy 限流策略配置参数
+type RateLimitPolicy struct {
+	Name       string
+	PathPrefix string
+	KeyBy      string
+	Count      int64
+	Period     int
+	Burst      int64
 }
 
 // CORS 跨域请求配置参数
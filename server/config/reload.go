@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 支持热更新的配置项(其余配置项变更后需要重启服务)
+// 热更新不会修改C，使用这些配置项的组件须通过Current读取或使用OnReload重建
+var reloadableFields = map[string]bool{
+	// 日志级别及格式(InitLogger注册OnReload)
+	"Log.Level":  true,
+	"Log.Format": true,
+	// 限流(RateLimiterMiddleware每次请求通过Current读取，启用开关及存储方式需要重启)
+	"RateLimiter.Count":          true,
+	"RateLimiter.APIKeyHeader":   true,
+	"RateLimiter.TrustedProxies": true,
+	"RateLimiter.Policies":       true,
+	// 跨域(CORSMiddleware在配置变更后重建，启用开关需要重启)
+	"CORS.AllowOrigins":     true,
+	"CORS.AllowMethods":     true,
+	"CORS.AllowHeaders":     true,
+	"CORS.AllowCredentials": true,
+	"CORS.MaxAge":           true,
+	// 定期加载策略(PolicyLoader通过Current读取，InitCasbin注册OnReload重置定时器)
+	"Casbin.AutoLoad":         true,
+	"Casbin.AutoLoadInternal": true,
+	// 默认语言(InitI18n注册OnReload)
+	"I18n.DefaultLocale": true,
+	// 以下配置项在每次请求时通过Current读取
+	"Captcha.Length":   true,
+	"Captcha.Width":    true,
+	"Captcha.Height":   true,
+	"HTTP.ProblemJSON": true,
+	"Bulk.MaxItems":    true,
+	"Import.MaxRows":   true,
+	"Import.MaxSize":   true,
+}
+
+var (
+	paths     []string
+	current   atomic.Value
+	reloadMu  sync.Mutex
+	hooks     []ReloadHook
+	overrides []func(*Config)
+)
+
+// ReloadHook 配置热更新后的回调
+type ReloadHook func(old, new *Config)
+
+// ReloadResult 配置热更新结果
+type ReloadResult struct {
+	Applied         []string `json:"applied"`          // 已生效的配置项
+	RestartRequired []string `json:"restart_required"` // 需要重启服务才能生效的配置项
+}
+
+// Current 获取当前生效的配置(支持热更新的配置项需通过该函数读取)
+func Current() *Config {
+	if c, ok := current.Load().(*Config); ok {
+		return c
+	}
+	return C
+}
+
+// Override 修改已加载的配置(如命令行参数)，重新加载配置时同样生效
+func Override(fn func(c *Config)) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	fn(C)
+	overrides = append(overrides, fn)
+}
+
+// OnReload 注册配置热更新后的回调
+func OnReload(hook ReloadHook) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// Reload 重新读取配置文件，校验后生效支持热更新的配置项
+func Reload() (*ReloadResult, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if len(paths) == 0 {
+		return nil, errors.New("config is not loaded from file")
+	}
+
+	c := new(Config)
+	if err := newLoader(paths...).Load(c); err != nil {
+		return nil, err
+	}
+	for _, fn := range overrides {
+		fn(c)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	old := Current()
+	next := *old
+	result := &ReloadResult{Applied: []string{}, RestartRequired: []string{}}
+	for _, name := range diff("", reflect.ValueOf(*old), reflect.ValueOf(*c)) {
+		if !reloadableFields[name] {
+			result.RestartRequired = append(result.RestartRequired, name)
+			continue
+		}
+		result.Applied = append(result.Applied, name)
+		setField(&next, c, name)
+	}
+
+	if len(result.Applied) > 0 {
+		current.Store(&next)
+		for _, hook := range hooks {
+			hook(old, &next)
+		}
+	}
+	return result, nil
+}
+
+// Validate 校验配置
+func (c *Config) Validate() error {
+	if c.Log.Level < 0 || c.Log.Level > 6 {
+		return errors.New("Log.Level must be between 0 and 6")
+	}
+	if f := c.Log.Format; f != "" && f != "text" && f != "json" {
+		return errors.New("Log.Format must be text or json")
+	}
+	if c.RateLimiter.Enable && c.RateLimiter.Count <= 0 && len(c.RateLimiter.Policies) == 0 {
+		return errors.New("RateLimiter.Count must be positive")
+	}
+	for _, p := range c.RateLimiter.Policies {
+		if p.Name == "" || p.Count <= 0 || p.Period <= 0 {
+			return errors.New("RateLimiter.Policies require Name, positive Count and Period")
+		}
+		switch p.KeyBy {
+		case "", "user", "ip", "api_key":
+		default:
+			return errors.New("RateLimiter.Policies KeyBy must be user, ip or api_key")
+		}
+	}
+	for _, v := range c.RateLimiter.TrustedProxies {
+		if _, _, err := net.ParseCIDR(v); err != nil && net.ParseIP(v) == nil {
+			return errors.New("RateLimiter.TrustedProxies must be IP or CIDR")
+		}
+	}
+	if c.Casbin.AutoLoad && c.Casbin.AutoLoadInternal <= 0 {
+		return errors.New("Casbin.AutoLoadInternal must be positive")
+	}
+	if c.Captcha.Length <= 0 || c.Captcha.Width <= 0 || c.Captcha.Height <= 0 {
+		return errors.New("Captcha.Length, Width and Height must be positive")
+	}
+	return nil
+}
+
+// diff 比较配置，返回变更的配置项名称(结构体逐级展开)
+func diff(prefix string, a, b reflect.Value) []string {
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var names []string
+	for i := 0; i < a.NumField(); i++ {
+		name := a.Type().Field(i).Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		names = append(names, diff(name, a.Field(i), b.Field(i))...)
+	}
+	return names
+}
+
+func setField(dst, src *Config, name string) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+	for _, field := range strings.Split(name, ".") {
+		d = d.FieldByName(field)
+		s = s.FieldByName(field)
+	}
+	d.Set(s)
+}
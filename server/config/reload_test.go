@@ -0,0 +1,123 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// 支持热更新的配置项须对应配置结构体的字段
+func TestReloadableFields(t *testing.T) {
+	for name := range reloadableFields {
+		v := reflect.ValueOf(Config{})
+		for _, field := range strings.Split(name, ".") {
+			if v.Kind() != reflect.Struct {
+				v = reflect.Value{}
+				break
+			}
+			v = v.FieldByName(field)
+			if !v.IsValid() {
+				break
+			}
+		}
+		if !v.IsValid() {
+			t.Errorf("%s: no such config field", name)
+		}
+	}
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 热更新只修改Current返回的配置，C保持启动时的值
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.toml")
+	base := "[Captcha]\nLength = 4\nWidth = 400\nHeight = 160\n"
+	writeConfig(t, path, base+"[Log]\nLevel = 4\n[HTTP]\nPort = 8000\n")
+
+	oldC, oldPaths, oldHooks := C, paths, hooks
+	defer func() {
+		C, paths, hooks = oldC, oldPaths, oldHooks
+		current.Store(oldC)
+	}()
+
+	C = new(Config)
+	if err := newLoader(path).Load(C); err != nil {
+		t.Fatal(err)
+	}
+	paths, hooks = []string{path}, nil
+	current.Store(C)
+
+	var called bool
+	OnReload(func(old, new *Config) {
+		called = old.Log.Level == 4 && new.Log.Level == 5
+	})
+
+	writeConfig(t, path, base+"[Log]\nLevel = 5\n[HTTP]\nPort = 8080\n")
+	result, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Applied, []string{"Log.Level"}) ||
+		!reflect.DeepEqual(result.RestartRequired, []string{"HTTP.Port"}) {
+		t.Fatalf("got %+v", result)
+	}
+	if !called {
+		t.Error("reload hook not called")
+	}
+	if cur := Current(); cur.Log.Level != 5 || cur.HTTP.Port != 8000 {
+		t.Errorf("current: got Log.Level=%d HTTP.Port=%d", cur.Log.Level, cur.HTTP.Port)
+	}
+	if C.Log.Level != 4 {
+		t.Errorf("C.Log.Level: got %d, want 4", C.Log.Level)
+	}
+
+	writeConfig(t, path, base+"[Log]\nLevel = 9\n")
+	if _, err := Reload(); err == nil {
+		t.Error("invalid config: got nil error")
+	} else if Current().Log.Level != 5 {
+		t.Errorf("invalid config applied: Log.Level=%d", Current().Log.Level)
+	}
+}
+
+// 热更新不会修改C，支持热更新的配置项不能通过C读取
+func TestReloadableFieldsNotReadFromC(t *testing.T) {
+	re := regexp.MustCompile(`config\.C\.([A-Za-z]+\.[A-Za-z]+)`)
+	for _, root := range []string{"..", "../../pkg"} {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, m := range re.FindAllStringSubmatch(string(data), -1) {
+				if reloadableFields[m[1]] {
+					t.Errorf("%s: reads %s from config.C, use config.Current()", path, m[1])
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
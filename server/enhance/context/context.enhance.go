@@ -10,6 +10,8 @@ type (
 	noTransCtx   struct{}
 	transLockCtx struct{}
 	userIDCtx    struct{}
+	tenantIDCtx  struct{}
+	allTenantCtx struct{}
 	traceIDCtx   struct{}
 )
 
@@ -62,6 +64,42 @@ func FromUserID(ctx context.Context) (string, bool) {
 	return "", false
 }
 
+// NewTenantID 创建租户ID的上下文
+func NewTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDCtx{}, tenantID)
+}
+
+// FromTenantID 从上下文中获取租户ID
+func FromTenantID(ctx context.Context) (string, bool) {
+	v := ctx.Value(tenantIDCtx{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s, s != ""
+		}
+	}
+	return "", false
+}
+
+// NewAllTenants 创建跨租户访问数据的上下文(仅供需要处理全部租户数据的后台任务使用)
+func NewAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantCtx{}, true)
+}
+
+// FromAllTenants 从上下文中获取是否跨租户访问数据
+func FromAllTenants(ctx context.Context) bool {
+	v := ctx.Value(allTenantCtx{})
+	return v != nil && v.(bool)
+}
+
+// FromTenantScope 从上下文中获取访问数据的租户范围(未指定租户时为平台数据，即租户ID为空；跨租户访问时scoped为false)
+func FromTenantScope(ctx context.Context) (tenantID string, scoped bool) {
+	if FromAllTenants(ctx) {
+		return "", false
+	}
+	tenantID, _ = FromTenantID(ctx)
+	return tenantID, true
+}
+
 // NewTraceID 创建追踪ID的上下文
 func NewTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, traceIDCtx{}, traceID)
@@ -0,0 +1,25 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/schema"
+)
+
+// ResBulk 响应批量操作结果(失败项的错误按请求的语言转换为错误项)
+func ResBulk(c *gin.Context, result *schema.BulkResult) {
+	locale := GetLocale(c)
+	for _, item := range result.Items {
+		if item.Err == nil {
+			continue
+		}
+
+		if res, ok := errs.Cause(item.Err).(*errs.ResponseError); ok {
+			item.Error = &schema.ErrorItem{Code: res.Code, ID: res.ID, Message: res.Localize(locale)}
+		} else {
+			item.Error = &schema.ErrorItem{Code: 500, Message: item.Err.Error()}
+		}
+	}
+	c.Header("Content-Language", locale)
+	ResSuccess(c, result)
+}
@@ -2,8 +2,9 @@ package gin
 
 
 import (
-	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,7 @@ import (
 const (
 	prefix           = "mag"
 	UserIDKey        = prefix + "/user-id"
+	TenantIDKey      = prefix + "/tenant-id"
 	ReqBodyKey       = prefix + "/req-body"
 	ResBodyKey       = prefix + "/res-body"
 	LoggerReqBodyKey = prefix + "/logger-req-body"
@@ -44,6 +46,16 @@ func SetUserID(c *gin.Context, userID string) {
 	c.Set(UserIDKey, userID)
 }
 
+// GetTenantID 获取租户ID
+func GetTenantID(c *gin.Context) string {
+	return c.GetString(TenantIDKey)
+}
+
+// SetTenantID 设定租户ID
+func SetTenantID(c *gin.Context, tenantID string) {
+	c.Set(TenantIDKey, tenantID)
+}
+
 // GetBody Get request body
 func GetBody(c *gin.Context) []byte {
 	if v, ok := c.Get(ReqBodyKey); ok {
@@ -57,7 +69,7 @@ func GetBody(c *gin.Context) []byte {
 // ParseJSON 解析请求JSON
 func ParseJSON(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindJSON(obj); err != nil {
-		return errs.Wrap400Response(err, fmt.Sprintf("解析请求参数发生错误 - %s", err.Error()))
+		return wrapParseError(err)
 	}
 	return nil
 }
@@ -65,15 +77,104 @@ func ParseJSON(c *gin.Context, obj interface{}) error {
 // ParseQuery 解析Query参数
 func ParseQuery(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindQuery(obj); err != nil {
-		return errs.Wrap400Response(err, fmt.Sprintf("解析请求参数发生错误 - %s", err.Error()))
+		return wrapParseError(err)
 	}
 	return nil
 }
 
+var queryFilterRegexp = regexp.MustCompile(`^filter\[(\w+)\](?:\[(\w+)\])?$`)
+
+// ParseQueryCondition 解析通用查询条件(filter[key][op]=v、sort=-a,b、fields=a,b)，并按白名单校验
+func ParseQueryCondition(c *gin.Context, wl schema.QueryWhitelist) (*schema.QueryCondition, error) {
+	query := c.Request.URL.Query()
+	cond := new(schema.QueryCondition)
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		matches := queryFilterRegexp.FindStringSubmatch(key)
+		if matches == nil {
+			continue
+		}
+
+		column, ok := wl.Filters[matches[1]]
+		if !ok {
+			return nil, errs.New400I18nResponse("error.unsupported_filter_field", matches[1])
+		}
+
+		op := schema.FilterEQ
+		if matches[2] != "" {
+			op = schema.FilterOperator(matches[2])
+			if !op.IsValid() {
+				return nil, errs.New400I18nResponse("error.unsupported_filter_operator", matches[2])
+			}
+		}
+
+		for _, v := range query[key] {
+			values := []string{v}
+			if op == schema.FilterIn {
+				values = strings.Split(v, ",")
+			}
+			cond.Filters = append(cond.Filters, &schema.FilterField{
+				Key:      column,
+				Operator: op,
+				Values:   values,
+			})
+		}
+	}
+
+	if v := c.Query("sort"); v != "" {
+		for _, key := range strings.Split(v, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+
+			d := schema.OrderByASC
+			if strings.HasPrefix(key, "-") {
+				d = schema.OrderByDESC
+				key = key[1:]
+			} else if strings.HasPrefix(key, "+") {
+				key = key[1:]
+			}
+
+			column, ok := wl.Sorts[key]
+			if !ok {
+				return nil, errs.New400I18nResponse("error.unsupported_sort_field", key)
+			}
+			cond.OrderFields = append(cond.OrderFields, schema.NewOrderField(column, d))
+		}
+	}
+
+	if v := c.Query("fields"); v != "" {
+		allowed := make(map[string]struct{}, len(wl.Fields))
+		for _, field := range wl.Fields {
+			allowed[field] = struct{}{}
+		}
+
+		for _, field := range strings.Split(v, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if _, ok := allowed[field]; !ok {
+				return nil, errs.New400I18nResponse("error.unsupported_select_field", field)
+			}
+			cond.Fields = append(cond.Fields, field)
+		}
+	}
+
+	return cond, nil
+}
+
 // ParseForm 解析Form请求
 func ParseForm(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindWith(obj, binding.Form); err != nil {
-		return errs.Wrap400Response(err, fmt.Sprintf("解析请求参数发生错误 - %s", err.Error()))
+		return wrapParseError(err)
 	}
 	return nil
 }
@@ -104,24 +205,28 @@ func ResSuccess(c *gin.Context, v interface{}) {
 
 // ResJSON 响应JSON数据
 func ResJSON(c *gin.Context, status int, v interface{}) {
+	resData(c, status, "application/json; charset=utf-8", v)
+}
+
+func resData(c *gin.Context, status int, contentType string, v interface{}) {
 	buf, err := util.JSONMarshal(v)
 	if err != nil {
 		panic(err)
 	}
 	c.Set(ResBodyKey, buf)
-	c.Data(status, "application/json; charset=utf-8", buf)
+	c.Data(status, contentType, buf)
 	c.Abort()
 }
 
-// ResError 响应错误
+// ResError 响应错误(错误消息按请求的语言翻译，请求头Accept包含application/problem+json或启用配置时使用RFC 7807格式)
 func ResError(c *gin.Context, err error, status ...int) {
 	ctx := c.Request.Context()
 	var res *errs.ResponseError
 	if err != nil {
-		if e, ok := err.(*errs.ResponseError); ok {
+		if e, ok := errs.Cause(err).(*errs.ResponseError); ok {
 			res = e
 		} else {
-			res = errs.UnWrapResponse(errs.Wrap500Response(err, "服务器错误"))
+			res = errs.UnWrapResponse(errs.Wrap500I18nResponse(err, "error.internal_server"))
 		}
 	} else {
 		res = errs.UnWrapResponse(errs.ErrInternalServer)
@@ -139,9 +244,17 @@ func ResError(c *gin.Context, err error, status ...int) {
 		}
 	}
 
+	locale := GetLocale(c)
+	c.Header("Content-Language", locale)
+	if wantProblem(c) {
+		resData(c, res.StatusCode, "application/problem+json; charset=utf-8", newProblem(c, res, locale))
+		return
+	}
+
 	eitem := schema.ErrorItem{
 		Code:    res.Code,
-		Message: res.Message,
+		ID:      res.ID,
+		Message: res.Localize(locale),
 	}
 	ResJSON(c, res.StatusCode, schema.ErrorResult{Error: eitem})
 }
@@ -0,0 +1,101 @@
+package gin
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/schema"
+)
+
+var testWhitelist = schema.QueryWhitelist{
+	Filters: map[string]string{"code": "code", "userName": "user_name", "status": "status"},
+	Sorts:   map[string]string{"code": "code", "createdAt": "created_at"},
+	Fields:  []string{"id", "code"},
+}
+
+func parseQueryCondition(rawQuery string) (*schema.QueryCondition, error) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/demos?"+rawQuery, nil)
+	return ParseQueryCondition(c, testWhitelist)
+}
+
+func TestParseQueryCondition(t *testing.T) {
+	filter := func(key string, op schema.FilterOperator, values ...string) *schema.FilterField {
+		return &schema.FilterField{Key: key, Operator: op, Values: values}
+	}
+
+	for _, c := range []struct {
+		name  string
+		query string
+		want  *schema.QueryCondition
+	}{
+		{"empty", "", &schema.QueryCondition{}},
+		{"default eq", "filter[code]=D001", &schema.QueryCondition{
+			Filters: []*schema.FilterField{filter("code", schema.FilterEQ, "D001")},
+		}},
+		{"mapped column", "filter[userName][like]=adm", &schema.QueryCondition{
+			Filters: []*schema.FilterField{filter("user_name", schema.FilterLike, "adm")},
+		}},
+		{"in", "filter[status][in]=1,2", &schema.QueryCondition{
+			Filters: []*schema.FilterField{filter("status", schema.FilterIn, "1", "2")},
+		}},
+		{"repeated", "filter[code][gte]=D001&filter[code][gte]=D005", &schema.QueryCondition{
+			Filters: []*schema.FilterField{filter("code", schema.FilterGTE, "D001"), filter("code", schema.FilterGTE, "D005")},
+		}},
+		{"sorted keys", "filter[status]=1&filter[code][ne]=D001", &schema.QueryCondition{
+			Filters: []*schema.FilterField{filter("code", schema.FilterNE, "D001"), filter("status", schema.FilterEQ, "1")},
+		}},
+		{"other params ignored", "current=2&filters[code]=x&filter=x", &schema.QueryCondition{}},
+		{"sort", "sort=-createdAt,+code,,", &schema.QueryCondition{
+			OrderFields: []*schema.OrderField{
+				schema.NewOrderField("created_at", schema.OrderByDESC),
+				schema.NewOrderField("code", schema.OrderByASC),
+			},
+		}},
+		{"select fields", "fields=code,+id", &schema.QueryCondition{Fields: []string{"code", "id"}}},
+	} {
+		got, err := parseQueryCondition(c.query)
+		if c.want == nil {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", c.name, got)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestParseQueryConditionRejected(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		query string
+		id    string
+	}{
+		{"unknown filter field", "filter[password]=x", "error.unsupported_filter_field"},
+		{"column name as filter field", "filter[user_name]=x", "error.unsupported_filter_field"},
+		{"unknown operator", "filter[code][regexp]=x", "error.unsupported_filter_operator"},
+		{"upper case operator", "filter[code][EQ]=x", "error.unsupported_filter_operator"},
+		{"unknown sort field", "sort=-password", "error.unsupported_sort_field"},
+		{"column name as sort field", "sort=created_at", "error.unsupported_sort_field"},
+		{"unknown select field", "fields=id,password", "error.unsupported_select_field"},
+	} {
+		_, err := parseQueryCondition(c.query)
+		e, ok := errs.Cause(err).(*errs.ResponseError)
+		if !ok {
+			t.Errorf("%s: got %v, want response error %s", c.name, err, c.id)
+			continue
+		}
+		if e.StatusCode != 400 || e.ID != c.id {
+			t.Errorf("%s: got %d %s, want 400 %s", c.name, e.StatusCode, e.ID, c.id)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package gin
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/i18n"
+)
+
+// LocaleKey 上下文中协商后的语言
+const LocaleKey = prefix + "/locale"
+
+func init() {
+	// 校验错误中的字段名称使用json(或form)标签
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, key := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(key), ",", 2)[0]
+				if name != "" && name != "-" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	}
+}
+
+// GetLocale 获取请求的语言(根据Accept-Language请求头协商)
+func GetLocale(c *gin.Context) string {
+	if v := c.GetString(LocaleKey); v != "" {
+		return v
+	}
+
+	locale := i18n.Match(c.GetHeader("Accept-Language"))
+	c.Set(LocaleKey, locale)
+	return locale
+}
+
+// 包装请求参数的解析错误(校验错误按请求的语言翻译)
+func wrapParseError(err error) error {
+	if ves, ok := err.(validator.ValidationErrors); ok {
+		return errs.Wrap400I18nResponse(err, "error.invalid_request", validationMessage(ves))
+	}
+	return errs.Wrap400I18nResponse(err, "error.invalid_request", err.Error())
+}
+
+// 校验错误消息(消息标识为validation.<标签>，不存在时使用validation.default)
+type validationMessage validator.ValidationErrors
+
+func (a validationMessage) Localize(locale string) string {
+	msgs := make([]string, len(a))
+	for i, fe := range a {
+		msgs[i] = localizeFieldError(locale, fe)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// 翻译单个字段的校验错误
+func localizeFieldError(locale string, fe validator.FieldError) string {
+	id := "validation." + fe.Tag()
+	if !i18n.Has(locale, id) {
+		id = "validation.default"
+	}
+	return i18n.T(locale, id, fe.Field(), fe.Param(), fe.Tag())
+}
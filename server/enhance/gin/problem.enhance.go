@@ -0,0 +1,58 @@
+package gin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/config"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 是否使用problem+json格式响应错误(请求头Accept指定或配置启用)
+func wantProblem(c *gin.Context) bool {
+	if strings.Contains(c.GetHeader("Accept"), "application/problem+json") {
+		return true
+	}
+	return config.Current().HTTP.ProblemJSON
+}
+
+// 创建problem+json格式的响应错误(类型以消息标识区分，校验错误按字段展开)
+func newProblem(c *gin.Context, res *errs.ResponseError, locale string) *schema.ProblemResult {
+	problem := &schema.ProblemResult{
+		Type:   "about:blank",
+		Title:  http.StatusText(res.StatusCode),
+		Status: res.StatusCode,
+		Detail: res.Localize(locale),
+		Code:   res.Code,
+	}
+	if res.ID != "" {
+		problem.Type = "urn:mag:" + res.ID
+	}
+	if traceID, ok := icontext.FromTraceID(c.Request.Context()); ok {
+		problem.TraceID = traceID
+	}
+
+	if ves, ok := errs.Cause(res.ERR).(validator.ValidationErrors); ok {
+		for _, fe := range ves {
+			problem.Errors = append(problem.Errors, &schema.FieldError{
+				Field:   fieldPath(fe),
+				Rule:    fe.Tag(),
+				Message: localizeFieldError(locale, fe),
+			})
+		}
+	}
+	return problem
+}
+
+// 字段路径(去掉顶层结构体名称，如User.roles[0].id为roles[0].id)
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
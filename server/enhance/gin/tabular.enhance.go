@@ -0,0 +1,243 @@
+package gin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/module/tabular"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 未配置时导入的限制
+const (
+	defaultImportMaxRows = 1000
+	defaultImportMaxSize = 10
+)
+
+// 导出参数
+const (
+	ExportBatchSize  = 500                   // 每批查询的数据量(基于游标分页，不加载全部数据)
+	ExportTimeLayout = "2006-01-02 15:04:05" // 时间格式
+)
+
+// ExportFunc 导出数据的写入函数
+type ExportFunc func(w tabular.Writer) error
+
+// ResExport 以附件流式响应导出数据(查询参数format指定csv或xlsx，默认为csv)
+// fn写入的第一行为表头(列名)，fields不为空时仅输出指定的列；开始输出前发生的错误按普通错误响应，输出过程中的错误只能中断响应
+func ResExport(c *gin.Context, name string, fields []string, fn ExportFunc) {
+	format, err := tabular.ParseFormat(c.DefaultQuery("format", string(tabular.CSV)))
+	if err != nil {
+		ResError(c, errs.New400I18nResponse("error.unsupported_format"))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s%s", name, time.Now().Format("20060102150405"), format.Ext())
+	header := c.Writer.Header()
+	header.Set("Content-Type", format.ContentType())
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q; filename*=UTF-8''%s", filename, url.PathEscape(filename)))
+
+	w, err := tabular.NewWriter(format, c.Writer)
+	if err == nil {
+		if len(fields) > 0 {
+			err = fn(&selectWriter{Writer: w, fields: fields})
+		} else {
+			err = fn(w)
+		}
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		c.Abort()
+		return
+	}
+
+	if !c.Writer.Written() {
+		header.Del("Content-Type")
+		header.Del("Content-Disposition")
+		ResError(c, err)
+		return
+	}
+	logger.ErrorStack(c.Request.Context(), err)
+	c.Abort()
+}
+
+// 按表头选择输出的列
+type selectWriter struct {
+	tabular.Writer
+	fields []string
+	index  []int
+}
+
+func (a *selectWriter) Write(record []string) error {
+	if a.index == nil {
+		a.index = make([]int, 0, len(a.fields))
+		for _, field := range a.fields {
+			for i, column := range record {
+				if column == field {
+					a.index = append(a.index, i)
+					break
+				}
+			}
+		}
+	}
+
+	values := make([]string, len(a.index))
+	for i, n := range a.index {
+		if n < len(record) {
+			values[i] = record[n]
+		}
+	}
+	return a.Writer.Write(values)
+}
+
+// ImportRecord 导入文件中的数据行
+type ImportRecord struct {
+	Row    int            // 行号(含表头，从1开始)
+	values []string       // 单元格的值
+	index  map[string]int // 列名对应的序号
+}
+
+// Get 获取指定列的值(去除首尾空白，不存在的列为空)
+func (a *ImportRecord) Get(column string) string {
+	i, ok := a.index[column]
+	if !ok || i >= len(a.values) {
+		return ""
+	}
+	return strings.TrimSpace(a.values[i])
+}
+
+// ParseImportFile 解析上传的导入文件(表单字段file，按扩展名识别csv或xlsx格式)
+// 第一行为表头(列名不区分大小写)，跳过空行，required为必需的列
+func ParseImportFile(c *gin.Context, required []string) ([]*ImportRecord, error) {
+	maxSize, maxRows := importLimits()
+
+	// 限制请求数据的大小(预留表单的其他内容)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, (maxSize+1)<<20)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		if c.Request.ContentLength > maxSize<<20 {
+			return nil, errs.New400I18nResponse("error.import_file_too_large", maxSize)
+		}
+		return nil, errs.New400I18nResponse("error.import_invalid_file")
+	} else if fh.Size > maxSize<<20 {
+		return nil, errs.New400I18nResponse("error.import_file_too_large", maxSize)
+	}
+
+	format, err := tabular.ParseFormat(filepath.Ext(fh.Filename))
+	if err != nil {
+		return nil, errs.New400I18nResponse("error.unsupported_format")
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return nil, errs.Wrap400I18nResponse(err, "error.import_invalid_file")
+	}
+	defer f.Close()
+
+	r, err := tabular.NewReader(format, f, fh.Size)
+	if err != nil {
+		return nil, errs.Wrap400I18nResponse(err, "error.import_invalid_file")
+	}
+
+	var (
+		list  []*ImportRecord
+		index map[string]int
+	)
+	for row := 1; ; row++ {
+		values, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errs.Wrap400I18nResponse(err, "error.import_invalid_file")
+		} else if isBlankRecord(values) {
+			continue
+		}
+
+		if index == nil {
+			index = make(map[string]int, len(values))
+			for i, v := range values {
+				index[strings.ToLower(strings.TrimSpace(v))] = i
+			}
+			for _, column := range required {
+				if _, ok := index[column]; !ok {
+					return nil, errs.New400I18nResponse("error.import_missing_column", column)
+				}
+			}
+			continue
+		}
+
+		if len(list) >= maxRows {
+			return nil, errs.New400I18nResponse("error.import_too_many_rows", maxRows)
+		}
+		list = append(list, &ImportRecord{Row: row, values: values, index: index})
+	}
+
+	if index == nil {
+		return nil, errs.New400I18nResponse("error.import_missing_column", strings.Join(required, ","))
+	}
+	return list, nil
+}
+
+// ReadImportBody 读取导入的请求数据(大小限制与导入文件相同)
+func ReadImportBody(c *gin.Context) ([]byte, error) {
+	maxSize, _ := importLimits()
+	buf, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSize<<20))
+	if err != nil {
+		if int64(len(buf)) >= maxSize<<20 {
+			return nil, errs.New400I18nResponse("error.import_file_too_large", maxSize)
+		}
+		return nil, errs.Wrap400I18nResponse(err, "error.import_invalid_file")
+	}
+	return buf, nil
+}
+
+// 导入限制(文件大小(MB)及行数，支持热更新)
+func importLimits() (int64, int) {
+	cfg := config.Current().Import
+	maxSize, maxRows := cfg.MaxSize, cfg.MaxRows
+	if maxSize <= 0 {
+		maxSize = defaultImportMaxSize
+	}
+	if maxRows <= 0 {
+		maxRows = defaultImportMaxRows
+	}
+	return maxSize, maxRows
+}
+
+func isBlankRecord(values []string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ResImport 响应导入结果(失败行的错误按请求的语言转换为错误列表)
+func ResImport(c *gin.Context, result *schema.ImportResult) {
+	locale := GetLocale(c)
+	for _, item := range result.Rows {
+		for _, fe := range item.FieldErrs {
+			ferr := &schema.FieldError{Field: fe.Field, Message: fe.Err.Error()}
+			if res, ok := errs.Cause(fe.Err).(*errs.ResponseError); ok {
+				ferr.Rule = res.ID
+				ferr.Message = res.Localize(locale)
+			}
+			item.Errors = append(item.Errors, ferr)
+		}
+	}
+	c.Header("Content-Language", locale)
+	ResSuccess(c, result)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/key7men/mag/server/biz"
 	"github.com/key7men/mag/server/config"
 	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/module/metrics"
 	"github.com/key7men/mag/server/schema"
 	"github.com/key7men/mag/pkg/logger"
 	"github.com/gin-gonic/gin"
@@ -27,7 +28,7 @@ type Login struct {
 // GetCaptchaId 获取验证码ID
 func (l *Login) GetCaptchaId(c *gin.Context) {
 	ctx := c.Request.Context()
-	item, err := l.LoginBiz.GetCaptchaId(ctx, config.C.Captcha.Length)
+	item, err := l.LoginBiz.GetCaptchaId(ctx, config.Current().Captcha.Length)
 	if err != nil {
 		egin.ResError(c, err)
 		return
@@ -40,18 +41,18 @@ func (l *Login) GetCaptchaPic(c *gin.Context) {
 	ctx := c.Request.Context()
 	captchaID := c.Query("id")
 	if captchaID == "" {
-		egin.ResError(c,errs.New400Response("请提供验证码ID"))
+		egin.ResError(c,errs.New400I18nResponse("error.captcha_id_required"))
 		return
 	}
 
 	if c.Query("reload") != "" {
 		if !captcha.Reload(captchaID) {
-			egin.ResError(c,errs.New400Response("未找到验证码ID"))
+			egin.ResError(c,errs.New400I18nResponse("error.captcha_id_not_found"))
 			return
 		}
 	}
 
-	cfg := config.C.Captcha
+	cfg := config.Current().Captcha
 	err := l.LoginBiz.GetCaptchaPic(ctx, c.Writer, captchaID, cfg.Width, cfg.Height)
 	if err != nil {
 		egin.ResError(c,err)
@@ -70,6 +71,7 @@ func (l *Login) Login(c *gin.Context) {
 
 	user, err := l.LoginBiz.Verify(ctx, item.UserName, item.Password)
 	if err != nil {
+		metrics.AuthFailures.WithLabelValues("login").Inc()
 		egin.ResError(c, err)
 		return
 	}
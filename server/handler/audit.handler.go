@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/key7men/mag/server/biz"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/schema"
+)
+
+// AuditSet 注入Audit
+var AuditSet = wire.NewSet(wire.Struct(new(Audit), "*"))
+
+// Audit 审计日志
+type Audit struct {
+	AuditBiz biz.IAudit
+}
+
+// Query 查询数据
+func (a *Audit) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.AuditQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	cond, err := egin.ParseQueryCondition(c, schema.AuditQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+
+	params.Pagination = true
+	result, err := a.AuditBiz.Query(ctx, params, schema.AuditQueryOptions{
+		OrderFields: cond.MergeOrderFields(schema.NewOrderField("created_at", schema.OrderByDESC)),
+	})
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	list, err := cond.SelectFields(result.Data)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, list, result.PageResult)
+}
+
+// Get 查询指定数据
+func (a *Audit) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.AuditBiz.Get(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, item)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/server/config"
+	egin "github.com/key7men/mag/server/enhance/gin"
+)
+
+// ConfigSet 注入Config
+var ConfigSet = wire.NewSet(wire.Struct(new(Config), "*"))
+
+// Config 系统配置
+type Config struct {
+}
+
+// Reload 重新加载配置文件(仅生效支持热更新的配置项，返回需重启生效的配置项)
+func (a *Config) Reload(c *gin.Context) {
+	ctx := c.Request.Context()
+	result, err := config.Reload()
+	if err != nil {
+		egin.ResError(c, errs.Wrap400I18nResponse(err, "error.config_reload_failed", err.Error()))
+		return
+	}
+
+	logger.StartSpan(ctx, logger.SetSpanTitle("重新加载配置"), logger.SetSpanFuncName("Reload")).
+		Infof("已生效：%v，需重启生效：%v", result.Applied, result.RestartRequired)
+	egin.ResSuccess(c, result)
+}
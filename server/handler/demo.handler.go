@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 	"github.com/key7men/mag/server/biz"
 	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/module/tabular"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -25,14 +28,28 @@ func (a *Demo) Query(c *gin.Context) {
 		return
 	}
 
+	cond, err := egin.ParseQueryCondition(c, schema.DemoQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+
 	params.Pagination = true
-	result, err := a.DemoBiz.Query(ctx, params)
+	result, err := a.DemoBiz.Query(ctx, params, schema.DemoQueryOptions{
+		OrderFields: cond.MergeOrderFields(),
+	})
 	if err != nil {
 		egin.ResError(c, err)
 		return
 	}
 
-	egin.ResPage(c, result.Data, result.PageResult)
+	list, err := cond.SelectFields(result.Data)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, list, result.PageResult)
 }
 
 // Get 查询指定数据
@@ -113,3 +130,58 @@ func (a *Demo) Disable(c *gin.Context) {
 	}
 	egin.ResOK(c)
 }
+
+// Export 导出数据(查询条件及选择字段与Query相同，format指定csv或xlsx)
+func (a *Demo) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.DemoQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	cond, err := egin.ParseQueryCondition(c, schema.DemoQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+	params.PaginationParam = schema.PaginationParam{Pagination: true, UseCursor: true, NoCount: true, PageSize: egin.ExportBatchSize}
+
+	egin.ResExport(c, "demos", cond.Fields, func(w tabular.Writer) error {
+		err := w.Write([]string{"id", "code", "name", "memo", "status", "creator", "created_at", "updated_at"})
+		if err != nil {
+			return err
+		}
+
+		for {
+			result, err := a.DemoBiz.Query(ctx, params, schema.DemoQueryOptions{
+				OrderFields: cond.MergeOrderFields(),
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, item := range result.Data {
+				err := w.Write([]string{
+					item.ID,
+					item.Code,
+					item.Name,
+					item.Memo,
+					strconv.Itoa(item.Status),
+					item.Creator,
+					item.CreatedAt.Format(egin.ExportTimeLayout),
+					item.UpdatedAt.Format(egin.ExportTimeLayout),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if result.PageResult == nil || result.PageResult.NextCursor == "" {
+				return nil
+			}
+			params.Cursor = result.PageResult.NextCursor
+		}
+	})
+}
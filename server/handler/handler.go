@@ -4,9 +4,16 @@ import "github.com/google/wire"
 
 // APISet 注入api
 var HandlerSet = wire.NewSet(
+	AuditSet,
+	ConfigSet,
+	HealthSet,
 	DemoSet,
+	LogSet,
 	LoginSet,
 	MenuSet,
+	RBACSet,
 	RoleSet,
+	TenantSet,
 	UserSet,
+	WebhookSet,
 )
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/module/health"
+	"github.com/key7men/mag/server/schema"
+)
+
+// HealthSet 注入Health
+var HealthSet = wire.NewSet(wire.Struct(new(Health), "*"))
+
+// Health 健康检查
+type Health struct {
+	Health *health.Health
+}
+
+// Live 存活检查
+func (a *Health) Live(c *gin.Context) {
+	egin.ResSuccess(c, a.Health.Live())
+}
+
+// Ready 就绪检查
+func (a *Health) Ready(c *gin.Context) {
+	result := a.Health.Ready(c.Request.Context())
+	if result.Status != schema.OKStatus {
+		egin.ResJSON(c, http.StatusServiceUnavailable, result)
+		return
+	}
+	egin.ResSuccess(c, result)
+}
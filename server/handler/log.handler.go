@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/key7men/mag/server/biz"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/schema"
+)
+
+// LogSet 注入Log
+var LogSet = wire.NewSet(wire.Struct(new(Log), "*"))
+
+// Log 系统日志
+type Log struct {
+	LogBiz biz.ILog
+}
+
+// Query 查询数据(使用游标分页)
+func (a *Log) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.LogQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	cond, err := egin.ParseQueryCondition(c, schema.LogQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	params.Filters = cond.Filters
+	params.Pagination = true
+	params.UseCursor = true
+	result, err := a.LogBiz.Query(ctx, params, schema.LogQueryOptions{
+		OrderFields: cond.MergeOrderFields(schema.NewOrderField("created_at", schema.OrderByDESC)),
+	})
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	list, err := cond.SelectFields(result.Data)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, list, result.PageResult)
+}
+
+// QueryTrace 查询指定跟踪ID的全部日志
+func (a *Log) QueryTrace(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := a.LogBiz.QueryTrace(ctx, c.Param("traceID"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResList(c, list)
+}
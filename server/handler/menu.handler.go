@@ -25,15 +25,27 @@ func (a *Menu) Query(c *gin.Context) {
 		return
 	}
 
+	cond, err := egin.ParseQueryCondition(c, schema.MenuQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+
 	params.Pagination = true
 	result, err := a.MenuBll.Query(ctx, params, schema.MenuQueryOptions{
-		OrderFields: schema.NewOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+		OrderFields: cond.MergeOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
 	})
 	if err != nil {
 		egin.ResError(c, err)
 		return
 	}
-	egin.ResPage(c, result.Data, result.PageResult)
+	list, err := cond.SelectFields(result.Data)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, list, result.PageResult)
 }
 
 // QueryTree 查询菜单树
@@ -133,3 +145,66 @@ func (a *Menu) Disable(c *gin.Context) {
 	}
 	egin.ResOK(c)
 }
+
+// BulkCreate 批量创建数据
+func (a *Menu) BulkCreate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkMenuCreateParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	for _, item := range params.Items {
+		item.Creator = egin.GetUserID(c)
+	}
+	result, err := a.MenuBll.BulkCreate(ctx, params.Items)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkDelete 批量删除数据
+func (a *Menu) BulkDelete(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkIDParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.MenuBll.BulkDelete(ctx, params.IDs)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkEnable 批量启用数据
+func (a *Menu) BulkEnable(c *gin.Context) {
+	a.bulkUpdateStatus(c, 1)
+}
+
+// BulkDisable 批量禁用数据
+func (a *Menu) BulkDisable(c *gin.Context) {
+	a.bulkUpdateStatus(c, 2)
+}
+
+func (a *Menu) bulkUpdateStatus(c *gin.Context, status int) {
+	ctx := c.Request.Context()
+	var params schema.BulkIDParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.MenuBll.BulkUpdateStatus(ctx, params.IDs, status)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/biz"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/schema"
+)
+
+// RBACSet 注入RBAC
+var RBACSet = wire.NewSet(wire.Struct(new(RBAC), "*"))
+
+// RBAC RBAC模型配置管理
+type RBAC struct {
+	RBACBiz biz.IRBAC
+}
+
+// Export 导出菜单、动作、资源、角色及授权(format指定yaml或json，默认为yaml)
+func (a *RBAC) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	format, err := schema.ParseRBACFormat(c.Query("format"))
+	if err != nil {
+		egin.ResError(c, errs.New400I18nResponse("error.rbac_unsupported_format"))
+		return
+	}
+
+	doc, err := a.RBACBiz.Export(ctx)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	buf, err := schema.MarshalRBACDocument(doc, format)
+	if err != nil {
+		egin.ResError(c, errs.WithStack(err))
+		return
+	}
+
+	filename := fmt.Sprintf("rbac-%s.%s", time.Now().Format("20060102150405"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q; filename*=UTF-8''%s", filename, url.PathEscape(filename)))
+	c.Data(http.StatusOK, rbacContentType(format), buf)
+	c.Abort()
+}
+
+// Import 导入菜单、动作、资源、角色及授权(请求数据按Content-Type解析为yaml或json，dryRun为true时仅返回差异)
+func (a *RBAC) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RBACImportParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	format, err := schema.ParseRBACFormat(c.ContentType())
+	if err != nil {
+		egin.ResError(c, errs.New400I18nResponse("error.rbac_unsupported_format"))
+		return
+	}
+
+	buf, err := egin.ReadImportBody(c)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	doc, err := schema.UnmarshalRBACDocument(buf, format)
+	if err != nil {
+		egin.ResError(c, errs.Wrap400I18nResponse(err, "error.rbac_invalid_document", err.Error()))
+		return
+	}
+
+	result, err := a.RBACBiz.Import(ctx, doc, params)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, result)
+}
+
+func rbacContentType(format string) string {
+	if format == schema.RBACFormatJSON {
+		return "application/json; charset=utf-8"
+	}
+	return "application/x-yaml; charset=utf-8"
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 	"github.com/key7men/mag/server/biz"
 	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/module/tabular"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -25,15 +29,27 @@ func (a *Role) Query(c *gin.Context) {
 		return
 	}
 
+	cond, err := egin.ParseQueryCondition(c, schema.RoleQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+
 	params.Pagination = true
 	result, err := a.RoleBll.Query(ctx, params, schema.RoleQueryOptions{
-		OrderFields: schema.NewOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+		OrderFields: cond.MergeOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
 	})
 	if err != nil {
 		egin.ResError(c, err)
 		return
 	}
-	egin.ResPage(c, result.Data, result.PageResult)
+	list, err := cond.SelectFields(result.Data)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, list, result.PageResult)
 }
 
 // QuerySelect 查询选择数据
@@ -133,3 +149,130 @@ func (a *Role) Disable(c *gin.Context) {
 	}
 	egin.ResOK(c)
 }
+
+// BulkCreate 批量创建数据
+func (a *Role) BulkCreate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkRoleCreateParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	for _, item := range params.Items {
+		item.Creator = egin.GetUserID(c)
+	}
+	result, err := a.RoleBll.BulkCreate(ctx, params.Items)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkDelete 批量删除数据
+func (a *Role) BulkDelete(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkIDParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.RoleBll.BulkDelete(ctx, params.IDs)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkEnable 批量启用数据
+func (a *Role) BulkEnable(c *gin.Context) {
+	a.bulkUpdateStatus(c, 1)
+}
+
+// BulkDisable 批量禁用数据
+func (a *Role) BulkDisable(c *gin.Context) {
+	a.bulkUpdateStatus(c, 2)
+}
+
+func (a *Role) bulkUpdateStatus(c *gin.Context, status int) {
+	ctx := c.Request.Context()
+	var params schema.BulkIDParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.RoleBll.BulkUpdateStatus(ctx, params.IDs, status)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// Export 导出数据及菜单授权(查询条件及选择字段与Query相同，format指定csv或xlsx)
+func (a *Role) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RoleQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	cond, err := egin.ParseQueryCondition(c, schema.RoleQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+	params.PaginationParam = schema.PaginationParam{Pagination: true, UseCursor: true, NoCount: true, PageSize: egin.ExportBatchSize}
+
+	egin.ResExport(c, "roles", cond.Fields, func(w tabular.Writer) error {
+		err := w.Write([]string{"id", "name", "sequence", "memo", "status", "role_menus", "created_at", "updated_at"})
+		if err != nil {
+			return err
+		}
+
+		for {
+			result, err := a.RoleBll.Query(ctx, params, schema.RoleQueryOptions{
+				OrderFields: cond.MergeOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+			})
+			if err != nil {
+				return err
+			}
+
+			ids := make([]string, len(result.Data))
+			for i, item := range result.Data {
+				ids[i] = item.ID
+			}
+			mGrants, err := a.RoleBll.QueryGrants(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			for _, item := range result.Data {
+				err := w.Write([]string{
+					item.ID,
+					item.Name,
+					strconv.Itoa(item.Sequence),
+					item.Memo,
+					strconv.Itoa(item.Status),
+					strings.Join(mGrants[item.ID].ToStrings(), ";"),
+					item.CreatedAt.Format(egin.ExportTimeLayout),
+					item.UpdatedAt.Format(egin.ExportTimeLayout),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if result.PageResult == nil || result.PageResult.NextCursor == "" {
+				return nil
+			}
+			params.Cursor = result.PageResult.NextCursor
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/key7men/mag/server/biz"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/schema"
+)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"))
+
+// Tenant 租户管理
+type Tenant struct {
+	TenantBiz biz.ITenant
+}
+
+// Query 查询数据
+func (a *Tenant) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.TenantQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.TenantBiz.Query(ctx, params)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, result.Data, result.PageResult)
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.TenantBiz.Get(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, item)
+}
+
+// Create 创建数据(同时开通租户管理员)
+func (a *Tenant) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Tenant
+	if err := egin.ParseJSON(c, &item); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	item.Creator = egin.GetUserID(c)
+	result, err := a.TenantBiz.Create(ctx, item)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, result)
+}
+
+// Update 更新数据
+func (a *Tenant) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Tenant
+	if err := egin.ParseJSON(c, &item); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	err := a.TenantBiz.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantBiz.Delete(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// Enable 启用数据
+func (a *Tenant) Enable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantBiz.UpdateStatus(ctx, c.Param("id"), 1)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// Disable 禁用数据
+func (a *Tenant) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantBiz.UpdateStatus(ctx, c.Param("id"), 2)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -8,6 +9,7 @@ import (
 	"github.com/key7men/mag/pkg/errs"
 	"github.com/key7men/mag/server/biz"
 	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/module/tabular"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -31,13 +33,27 @@ func (a *User) Query(c *gin.Context) {
 		params.RoleIDs = strings.Split(v, ",")
 	}
 
+	cond, err := egin.ParseQueryCondition(c, schema.UserQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+
 	params.Pagination = true
-	result, err := a.UserBll.QueryShow(ctx, params)
+	result, err := a.UserBll.QueryShow(ctx, params, schema.UserQueryOptions{
+		OrderFields: cond.MergeOrderFields(),
+	})
 	if err != nil {
 		egin.ResError(c, err)
 		return
 	}
-	egin.ResPage(c, result.Data, result.PageResult)
+	list, err := cond.SelectFields(result.Data)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, list, result.PageResult)
 }
 
 // Get 查询指定数据
@@ -59,7 +75,7 @@ func (a *User) Create(c *gin.Context) {
 		egin.ResError(c, err)
 		return
 	} else if item.Password == "" {
-		egin.ResError(c, errs.New400Response("密码不能为空"))
+		egin.ResError(c, errs.New400I18nResponse("error.password_required"))
 		return
 	}
 
@@ -121,3 +137,182 @@ func (a *User) Disable(c *gin.Context) {
 	}
 	egin.ResOK(c)
 }
+
+// BulkCreate 批量创建数据
+func (a *User) BulkCreate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkUserCreateParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	for _, item := range params.Items {
+		item.Creator = egin.GetUserID(c)
+	}
+	result, err := a.UserBll.BulkCreate(ctx, params.Items)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkDelete 批量删除数据
+func (a *User) BulkDelete(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkIDParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.UserBll.BulkDelete(ctx, params.IDs)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkEnable 批量启用数据
+func (a *User) BulkEnable(c *gin.Context) {
+	a.bulkUpdateStatus(c, 1)
+}
+
+// BulkDisable 批量禁用数据
+func (a *User) BulkDisable(c *gin.Context) {
+	a.bulkUpdateStatus(c, 2)
+}
+
+func (a *User) bulkUpdateStatus(c *gin.Context, status int) {
+	ctx := c.Request.Context()
+	var params schema.BulkIDParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.UserBll.BulkUpdateStatus(ctx, params.IDs, status)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// BulkAssignRoles 批量分配角色
+func (a *User) BulkAssignRoles(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.BulkUserRoleParam
+	if err := egin.ParseJSON(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	result, err := a.UserBll.BulkAssignRoles(ctx, params.IDs, params.RoleIDs, params.Replace)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResBulk(c, result)
+}
+
+// Export 导出数据(查询条件及选择字段与Query相同，format指定csv或xlsx)
+func (a *User) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.UserQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	if v := c.Query("roleIDs"); v != "" {
+		params.RoleIDs = strings.Split(v, ",")
+	}
+
+	cond, err := egin.ParseQueryCondition(c, schema.UserQueryWhitelist)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	params.Filters = cond.Filters
+	params.PaginationParam = schema.PaginationParam{Pagination: true, UseCursor: true, NoCount: true, PageSize: egin.ExportBatchSize}
+
+	egin.ResExport(c, "users", cond.Fields, func(w tabular.Writer) error {
+		err := w.Write([]string{"id", "user_name", "real_name", "phone", "email", "status", "roles", "created_at"})
+		if err != nil {
+			return err
+		}
+
+		for {
+			result, err := a.UserBll.QueryShow(ctx, params, schema.UserQueryOptions{
+				OrderFields: cond.MergeOrderFields(),
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, item := range result.Data {
+				roleNames := make([]string, len(item.Roles))
+				for i, role := range item.Roles {
+					roleNames[i] = role.Name
+				}
+				err := w.Write([]string{
+					item.ID,
+					item.UserName,
+					item.RealName,
+					item.Phone,
+					item.Email,
+					strconv.Itoa(item.Status),
+					strings.Join(roleNames, ";"),
+					item.CreatedAt.Format(egin.ExportTimeLayout),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if result.PageResult == nil || result.PageResult.NextCursor == "" {
+				return nil
+			}
+			params.Cursor = result.PageResult.NextCursor
+		}
+	})
+}
+
+// Import 导入数据(上传csv或xlsx文件，按用户名新建或更新，dryRun为true时仅校验)
+func (a *User) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.ImportParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	records, err := egin.ParseImportFile(c, schema.UserImportRequiredColumns)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	rows := make([]*schema.UserImportRow, len(records))
+	for i, record := range records {
+		rows[i] = &schema.UserImportRow{
+			Row:      record.Row,
+			UserName: record.Get("user_name"),
+			RealName: record.Get("real_name"),
+			Password: record.Get("password"),
+			Phone:    record.Get("phone"),
+			Email:    record.Get("email"),
+			Status:   record.Get("status"),
+			Roles:    record.Get("roles"),
+		}
+	}
+
+	result, err := a.UserBll.Import(ctx, rows, params.DryRun)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResImport(c, result)
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/key7men/mag/server/biz"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/schema"
+)
+
+// WebhookSet 注入Webhook
+var WebhookSet = wire.NewSet(wire.Struct(new(Webhook), "*"))
+
+// Webhook webhook订阅管理
+type Webhook struct {
+	WebhookBiz biz.IWebhook
+}
+
+// Query 查询数据
+func (a *Webhook) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.WebhookQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.WebhookBiz.Query(ctx, params)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, result.Data, result.PageResult)
+}
+
+// Get 查询指定数据
+func (a *Webhook) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.WebhookBiz.Get(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, item)
+}
+
+// Create 创建数据(响应中返回签名密钥)
+func (a *Webhook) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Webhook
+	if err := egin.ParseJSON(c, &item); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	item.Creator = egin.GetUserID(c)
+	result, err := a.WebhookBiz.Create(ctx, item)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, result)
+}
+
+// Update 更新数据
+func (a *Webhook) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Webhook
+	if err := egin.ParseJSON(c, &item); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	err := a.WebhookBiz.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// Delete 删除数据
+func (a *Webhook) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.WebhookBiz.Delete(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// Enable 启用数据
+func (a *Webhook) Enable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.WebhookBiz.UpdateStatus(ctx, c.Param("id"), 1)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// Disable 禁用数据
+func (a *Webhook) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.WebhookBiz.UpdateStatus(ctx, c.Param("id"), 2)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResOK(c)
+}
+
+// RotateSecret 重新生成签名密钥
+func (a *Webhook) RotateSecret(c *gin.Context) {
+	ctx := c.Request.Context()
+	result, err := a.WebhookBiz.RotateSecret(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, result)
+}
+
+// Ping 发送测试事件(异步投递)
+func (a *Webhook) Ping(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.WebhookBiz.Ping(ctx, c.Param("id"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, item)
+}
+
+// QueryDeliveries 查询投递记录
+func (a *Webhook) QueryDeliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.WebhookDeliveryQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	if _, err := a.WebhookBiz.Get(ctx, c.Param("id")); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	params.WebhookID = c.Param("id")
+	result, err := a.WebhookBiz.QueryDeliveries(ctx, params)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, result.Data, result.PageResult)
+}
+
+// QueryDeadLetters 查询所有订阅中超过最大重试次数的投递记录
+func (a *Webhook) QueryDeadLetters(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.WebhookDeliveryQueryParam
+	if err := egin.ParseQuery(c, &params); err != nil {
+		egin.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	params.Status = int(schema.WebhookDeliveryDead)
+	result, err := a.WebhookBiz.QueryDeliveries(ctx, params)
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResPage(c, result.Data, result.PageResult)
+}
+
+// Redeliver 重新投递(异步投递)
+func (a *Webhook) Redeliver(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.WebhookBiz.Redeliver(ctx, c.Param("id"), c.Param("deliveryID"))
+	if err != nil {
+		egin.ResError(c, err)
+		return
+	}
+	egin.ResSuccess(c, item)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/key7men/mag/pkg/logger"
 	"github.com/key7men/mag/server/config"
 	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/module/metrics"
 
 	egin "github.com/key7men/mag/server/enhance/gin"
 )
@@ -33,7 +34,7 @@ func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc
 			return
 		}
 
-		userID, err := a.ParseUserID(c.Request.Context(), egin.GetToken(c))
+		userID, tenantID, err := a.ParseSubject(c.Request.Context(), egin.GetToken(c))
 		if err != nil {
 			if err == auth.ErrInvalidToken {
 				if config.C.IsDebugMode() {
@@ -41,14 +42,19 @@ func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc
 					c.Next()
 					return
 				}
+				metrics.AuthFailures.WithLabelValues("invalid_token").Inc()
 				egin.ResError(c, errs.ErrInvalidToken)
 				return
 			}
+			metrics.AuthFailures.WithLabelValues("error").Inc()
 			egin.ResError(c, errs.WithStack(err))
 			return
 		}
 
 		wrapUserAuthContext(c, userID)
+		if config.C.Tenant.Enable {
+			egin.SetTenantID(c, tenantID)
+		}
 		c.Next()
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/key7men/mag/pkg/errs"
 	"github.com/key7men/mag/server/config"
 	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/module/metrics"
 )
 
 // CasbinMiddleware casbin中间件
@@ -23,10 +24,11 @@ func CasbinMiddleware(enforcer *casbin.SyncedEnforcer, skippers ...SkipperFunc)
 
 		p := c.Request.URL.Path
 		m := c.Request.Method
-		if b, err := enforcer.Enforce(egin.GetUserID(c), p, m); err != nil {
+		if b, err := enforcer.Enforce(egin.GetUserID(c), egin.GetTenantID(c), p, m); err != nil {
 			egin.ResError(c, errs.WithStack(err))
 			return
 		} else if !b {
+			metrics.CasbinDenials.WithLabelValues(m, metricsRoute(c)).Inc()
 			egin.ResError(c, errs.ErrNoPerm)
 			return
 		}
@@ -1,16 +1,34 @@
 package middleware
 
 import (
+	"sync/atomic"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
 	"github.com/key7men/mag/server/config"
 )
 
-// CORSMiddleware 跨域请求中间件
+// CORSMiddleware 跨域请求中间件(配置热更新后自动重建)
 func CORSMiddleware() gin.HandlerFunc {
-	cfg := config.C.CORS
+	type corsHandler struct {
+		cfg     *config.Config
+		handler gin.HandlerFunc
+	}
+
+	var v atomic.Value
+	return func(c *gin.Context) {
+		cur := config.Current()
+		h, ok := v.Load().(*corsHandler)
+		if !ok || h.cfg != cur {
+			h = &corsHandler{cfg: cur, handler: newCORSHandler(cur.CORS)}
+			v.Store(h)
+		}
+		h.handler(c)
+	}
+}
+
+func newCORSHandler(cfg config.CORS) gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     cfg.AllowOrigins,
 		AllowMethods:     cfg.AllowMethods,
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/module/metrics"
+)
+
+// MetricsMiddleware 请求指标中间件(按路由模板统计请求数及耗时)
+func MetricsMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
+	if !config.C.Metrics.Enable {
+		return EmptyMiddleware()
+	}
+
+	return func(c *gin.Context) {
+		if SkipHandler(c, skippers...) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := metricsRoute(c)
+		method := c.Request.Method
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// 获取路由模板(未匹配的路由统一归类，避免指标维度无限增长)
+func metricsRoute(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
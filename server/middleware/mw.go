@@ -71,6 +71,21 @@ func AllowMethodAndPathPrefixSkipper(prefixes ...string) SkipperFunc {
 	}
 }
 
+// AllowMethodAndPathPrefixNoSkipper 检查请求方法和路径是否包含指定的前缀，如果包含则不跳过
+func AllowMethodAndPathPrefixNoSkipper(prefixes ...string) SkipperFunc {
+	return func(c *gin.Context) bool {
+		path := JoinRouter(c.Request.Method, c.Request.URL.Path)
+		pathLen := len(path)
+
+		for _, p := range prefixes {
+			if pl := len(p); pathLen >= pl && path[:pl] == p {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 // JoinRouter 拼接路由
 func JoinRouter(method, path string) string {
 	if len(path) > 0 && path[0] != '/' {
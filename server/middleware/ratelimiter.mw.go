@@ -1,37 +1,60 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis"
 	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/pkg/util"
 	"github.com/key7men/mag/server/config"
 	egin "github.com/key7men/mag/server/enhance/gin"
-
-	"github.com/go-redis/redis_rate"
-	"golang.org/x/time/rate"
+	"github.com/key7men/mag/server/module/metrics"
+	"github.com/key7men/mag/server/module/ratelimit"
 )
 
-// RateLimiterMiddleware 请求频率限制中间件
+const defaultRateLimitRedisTimeout = 100 * time.Millisecond
+
+// RateLimiterMiddleware 请求频率限制中间件(按路由前缀匹配限流策略，redis不可用时使用内存令牌桶)
 func RateLimiterMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
 	cfg := config.C.RateLimiter
 	if !cfg.Enable {
 		return EmptyMiddleware()
 	}
 
-	rc := config.C.Redis
-	ring := redis.NewRing(&redis.RingOptions{
-		Addrs: map[string]string{
-			"server1": rc.Addr,
-		},
-		Password: rc.Password,
-		DB:       cfg.RedisDB,
-	})
+	var store ratelimit.Store
+	if cfg.Store == "redis" {
+		// 使用较短的超时，redis不可用时尽快使用内存令牌桶
+		timeout := time.Duration(cfg.RedisTimeout) * time.Millisecond
+		if timeout <= 0 {
+			timeout = defaultRateLimitRedisTimeout
+		}
+		rc := config.C.Redis
+		store = ratelimit.NewRedisStore(redis.NewClient(&redis.Options{
+			Addr:         rc.Addr,
+			Password:     rc.Password,
+			DB:           cfg.RedisDB,
+			DialTimeout:  timeout,
+			ReadTimeout:  timeout,
+			WriteTimeout: timeout,
+			PoolTimeout:  timeout,
+		}), cfg.RedisPrefix)
+	}
 
-	limiter := redis_rate.NewLimiter(ring)
-	limiter.Fallback = rate.NewLimiter(rate.Inf, 0)
+	// redis异常时每分钟最多记录一次日志
+	var lastErr int64
+	limiter := ratelimit.NewLimiter(store, ratelimit.NewMemoryStore(), func(err error) {
+		now := time.Now().Unix()
+		if last := atomic.LoadInt64(&lastErr); now-last >= 60 && atomic.CompareAndSwapInt64(&lastErr, last, now) {
+			logger.Errorf(context.Background(), "Rate limiter redis error, fallback to memory: %s", err.Error())
+		}
+	})
 
 	return func(c *gin.Context) {
 		if SkipHandler(c, skippers...) {
@@ -39,21 +62,122 @@ func RateLimiterMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
 			return
 		}
 
-		userID := egin.GetUserID(c)
-		if userID != "" {
-			limit := cfg.Count
-			rate, delay, allowed := limiter.AllowMinute(userID, limit)
-			if !allowed {
-				h := c.Writer.Header()
-				h.Set("X-RateLimit-Limit", strconv.FormatInt(limit, 10))
-				h.Set("X-RateLimit-Remaining", strconv.FormatInt(limit-rate, 10))
-				delaySec := int64(delay / time.Second)
-				h.Set("X-RateLimit-Delay", strconv.FormatInt(delaySec, 10))
-				egin.ResError(c, errs.ErrTooManyRequests)
-				return
-			}
+		cfg := config.Current().RateLimiter
+		p, ok := matchRateLimitPolicy(cfg, c.Request.URL.Path)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		result := limiter.Allow(c.Request.Context(), rateLimitKey(c, cfg, p), ratelimit.Policy{
+			Name:   p.Name,
+			Count:  p.Count,
+			Period: time.Duration(p.Period) * time.Second,
+			Burst:  p.Burst,
+		})
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+		h.Set("RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+		h.Set("RateLimit-Reset", strconv.FormatInt(ceilSeconds(result.ResetAfter), 10))
+		if !result.Allowed {
+			metrics.RateLimitRejections.WithLabelValues(p.Name).Inc()
+			h.Set("Retry-After", strconv.FormatInt(ceilSeconds(result.RetryAfter), 10))
+			egin.ResError(c, errs.ErrTooManyRequests)
+			return
 		}
 
 		c.Next()
 	}
 }
+
+// 按路由前缀最长匹配限流策略(未配置策略时按用户每分钟Count次)
+func matchRateLimitPolicy(cfg config.RateLimiter, path string) (config.RateLimitPolicy, bool) {
+	if len(cfg.Policies) == 0 {
+		return config.RateLimitPolicy{
+			Name:   "default",
+			KeyBy:  "user",
+			Count:  cfg.Count,
+			Period: 60,
+		}, true
+	}
+
+	var (
+		matched config.RateLimitPolicy
+		ok      bool
+	)
+	for _, p := range cfg.Policies {
+		if strings.HasPrefix(path, p.PathPrefix) && (!ok || len(p.PathPrefix) > len(matched.PathPrefix)) {
+			matched, ok = p, true
+		}
+	}
+	return matched, ok
+}
+
+// 获取限流的键(无法获取用户或API密钥时按客户端IP)
+func rateLimitKey(c *gin.Context, cfg config.RateLimiter, p config.RateLimitPolicy) string {
+	switch p.KeyBy {
+	case "api_key":
+		if key := c.GetHeader(cfg.APIKeyHeader); cfg.APIKeyHeader != "" && key != "" {
+			return "key:" + util.SHA1HashString(key)
+		}
+	case "ip":
+		return "ip:" + rateLimitClientIP(c, cfg.TrustedProxies)
+	}
+
+	if userID := egin.GetUserID(c); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + rateLimitClientIP(c, cfg.TrustedProxies)
+}
+
+// 获取客户端IP(仅当连接的对端为受信任的代理时，按X-Forwarded-For从右向左取第一个非受信任代理的地址，或取X-Real-IP)
+func rateLimitClientIP(c *gin.Context, trustedProxies []string) string {
+	remoteIP := c.Request.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
+	}
+	if !isTrustedProxy(remoteIP, trustedProxies) {
+		return remoteIP
+	}
+
+	if v := c.GetHeader("X-Forwarded-For"); v != "" {
+		ips := strings.Split(v, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if net.ParseIP(ip) == nil {
+				break
+			}
+			if !isTrustedProxy(ip, trustedProxies) {
+				return ip
+			}
+			remoteIP = ip
+		}
+		return remoteIP
+	}
+	if ip := strings.TrimSpace(c.GetHeader("X-Real-IP")); net.ParseIP(ip) != nil {
+		return ip
+	}
+	return remoteIP
+}
+
+func isTrustedProxy(ip string, trustedProxies []string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, v := range trustedProxies {
+		if _, ipNet, err := net.ParseCIDR(v); err == nil {
+			if ipNet.Contains(addr) {
+				return true
+			}
+		} else if proxy := net.ParseIP(v); proxy != nil && proxy.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
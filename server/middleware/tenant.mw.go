@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/biz"
+	"github.com/key7men/mag/server/config"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	egin "github.com/key7men/mag/server/enhance/gin"
+)
+
+// TenantMiddleware 租户识别中间件(优先使用令牌中的租户，未登录时按请求的Host识别租户)
+func TenantMiddleware(t biz.ITenant, skippers ...SkipperFunc) gin.HandlerFunc {
+	if !config.C.Tenant.Enable {
+		return EmptyMiddleware()
+	}
+
+	return func(c *gin.Context) {
+		if SkipHandler(c, skippers...) {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		hostTenant, err := t.Resolve(ctx, c.Request.Host)
+		if err != nil {
+			egin.ResError(c, err)
+			return
+		}
+
+		tenantID := egin.GetTenantID(c)
+		if hostTenant != nil {
+			if tenantID != "" && tenantID != hostTenant.ID {
+				// 令牌与访问的租户不一致
+				egin.ResError(c, errs.ErrInvalidToken)
+				return
+			}
+			tenantID = hostTenant.ID
+		}
+
+		if tenantID == "" {
+			c.Next()
+			return
+		}
+
+		tenant, err := t.Lookup(ctx, tenantID)
+		if err != nil {
+			egin.ResError(c, err)
+			return
+		} else if tenant == nil {
+			egin.ResError(c, errs.ErrInvalidToken)
+			return
+		} else if tenant.Status != 1 {
+			egin.ResError(c, errs.ErrTenantDisable)
+			return
+		}
+
+		egin.SetTenantID(c, tenantID)
+		c.Request = c.Request.WithContext(icontext.NewTenantID(ctx, tenantID))
+		c.Next()
+	}
+}
+
+// PlatformMiddleware 平台接口中间件(租户管理、菜单维护等平台级接口不允许租户内访问)
+func PlatformMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
+	if !config.C.Tenant.Enable {
+		return EmptyMiddleware()
+	}
+
+	return func(c *gin.Context) {
+		if SkipHandler(c, skippers...) {
+			c.Next()
+			return
+		}
+
+		if egin.GetTenantID(c) != "" {
+			egin.ResError(c, errs.ErrNoPerm)
+			return
+		}
+		c.Next()
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/key7men/mag/pkg/logger"
 	"github.com/key7men/mag/pkg/trace"
+	"github.com/key7men/mag/server/config"
 	icontext "github.com/key7men/mag/server/enhance/context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
-// TraceMiddleware 跟踪ID中间件
+// TraceMiddleware 跟踪ID中间件(创建请求的跟踪单元，支持W3C traceparent请求头)
 func TraceMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if SkipHandler(c, skippers...) {
@@ -15,17 +21,43 @@ func TraceMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
 			return
 		}
 
-		// 优先从请求头中获取请求ID
-		traceID := c.GetHeader("X-Request-Id")
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := trace.StartSpan(ctx, JoinRouter(c.Request.Method, route),
+			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+			oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(config.C.Trace.ServiceName, route, c.Request)...),
+		)
+		defer span.End()
+
+		// 优先使用OpenTelemetry跟踪ID，保证日志与跟踪单元关联；其次从请求头中获取请求ID
+		requestID := c.GetHeader("X-Request-Id")
+		traceID := trace.IDFromContext(ctx)
+		if traceID == "" {
+			traceID = requestID
+		}
 		if traceID == "" {
 			traceID = trace.NewID()
 		}
+		if requestID != "" {
+			span.SetAttributes(attribute.String("http.request_id", requestID))
+		}
 
-		ctx := icontext.NewTraceID(c.Request.Context(), traceID)
+		ctx = icontext.NewTraceID(ctx, traceID)
 		ctx = logger.NewTraceIDContext(ctx, traceID)
 		c.Request = c.Request.WithContext(ctx)
 		c.Writer.Header().Set("X-Trace-Id", traceID)
 
 		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(status))
+		if len(c.Errors) > 0 {
+			span.SetAttributes(attribute.String("gin.errors", c.Errors.String()))
+		}
 	}
 }
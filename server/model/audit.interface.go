@@ -0,0 +1,17 @@
+package model
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IAudit 审计日志存储
+type IAudit interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.AuditQueryParam, opts ...schema.AuditQueryOptions) (*schema.AuditQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, id string, opts ...schema.AuditQueryOptions) (*schema.Audit, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.Audit) error
+}
@@ -0,0 +1,163 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/biz/impl"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/dao"
+	"github.com/key7men/mag/server/model/modeltest"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 失败项回滚到各自的保存点，不影响同一事务中的其他项
+func testExecBulk(t *testing.T, m *modeltest.Models) {
+	ctx := context.Background()
+	ids := []string{"demo-000", "demo-001", "demo-002"}
+	result, err := impl.ExecBulk(ctx, m.Trans, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		err := m.Demo.Create(ctx, schema.Demo{ID: ids[i], Code: ids[i], Name: ids[i], Status: 1})
+		if err != nil {
+			return ids[i], nil, err
+		}
+		if i == 1 {
+			// 语句执行失败(主键冲突)后返回业务错误，postgres中须回滚到保存点事务才能继续
+			if err := m.Demo.Create(ctx, schema.Demo{ID: ids[0], Code: "dup", Name: "dup", Status: 1}); err != nil {
+				return ids[i], nil, errs.New400Response("duplicate")
+			}
+		}
+		return ids[i], ids[i], nil
+	}, nil)
+	must(t, err)
+	if result.Succeeded != 2 || result.Failed != 1 || result.Items[1].Status != schema.FailStatus {
+		t.Fatalf("result: got %+v", result)
+	}
+
+	for i, want := range []bool{true, false, true} {
+		item, err := m.Demo.Get(ctx, ids[i])
+		must(t, err)
+		if got := item != nil; got != want {
+			t.Errorf("%s exists: got %v, want %v", ids[i], got, want)
+		}
+	}
+}
+
+func TestSqlite3ExecBulk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+	testExecBulk(t, m)
+}
+
+// 设置环境变量 MAG_TEST_POSTGRES_DSN 后运行(注意：会清空库中的数据表)
+func TestPostgresExecBulk(t *testing.T) {
+	dsn := os.Getenv("MAG_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("MAG_TEST_POSTGRES_DSN not set")
+	}
+	m, cleanFunc := newModels(t, "postgres", dsn, 10)
+	defer cleanFunc()
+	testExecBulk(t, m)
+}
+
+var errRetry = errors.New("retry")
+
+// 模拟mongo的WithTransaction：首次执行后回滚并重新执行事务
+type retryTrans struct {
+	model.ITrans
+}
+
+func (a retryTrans) Exec(ctx context.Context, fn func(context.Context) error) error {
+	err := a.ITrans.Exec(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		return errRetry
+	})
+	if errs.Cause(err) != errRetry {
+		return err
+	}
+	return a.ITrans.Exec(ctx, fn)
+}
+
+// 事务重试时批量操作的结果及事件数据不重复
+func TestSqlite3ExecBulkRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+
+	ctx := context.Background()
+	ids := []string{"demo-000", "demo-001"}
+	var data []interface{}
+	result, err := impl.ExecBulk(ctx, retryTrans{m.Trans}, len(ids), func(ctx context.Context, i int) (string, interface{}, error) {
+		err := m.Demo.Create(ctx, schema.Demo{ID: ids[i], Code: ids[i], Name: ids[i], Status: 1})
+		return ids[i], ids[i], err
+	}, func(ctx context.Context, d []interface{}) error {
+		data = d
+		return nil
+	})
+	must(t, err)
+	if result.Succeeded != 2 || len(result.Items) != 2 {
+		t.Fatalf("result: got %+v", result)
+	}
+	if !reflect.DeepEqual(data, []interface{}{"demo-000", "demo-001"}) {
+		t.Errorf("data: got %v", data)
+	}
+}
+
+// 事务重试时创建的用户密码只计算一次哈希
+func TestSqlite3UserCreateRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+
+	ctx := context.Background()
+	db := m.Trans.(*dao.Trans).DB
+	userBiz := &impl.User{
+		TransModel:    retryTrans{m.Trans},
+		UserModel:     m.User,
+		UserRoleModel: m.UserRole,
+		RoleModel:     m.Role,
+		AuditModel:    &dao.Audit{DB: db},
+		OutboxModel:   &dao.Outbox{DB: db},
+		TenantModel:   m.Tenant,
+	}
+
+	result, err := userBiz.Create(ctx, schema.User{UserName: "alice", RealName: "alice", Password: "secret", Status: 1})
+	must(t, err)
+	user, err := m.User.Get(ctx, result.ID)
+	must(t, err)
+	if user == nil || user.Password != util.SHA1HashString("secret") {
+		t.Fatalf("user: got %+v", user)
+	}
+
+	user.Password = ""
+	must(t, userBiz.Update(ctx, result.ID, *user))
+	user, err = m.User.Get(ctx, result.ID)
+	must(t, err)
+	if user.Password != util.SHA1HashString("secret") {
+		t.Errorf("password after update: got %q", user.Password)
+	}
+}
@@ -0,0 +1,117 @@
+package gorm_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/server/model/gorm/dao"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 按可为NULL的字段游标分页时，NULL值视为最小值，前后翻页均不遗漏或重复
+func TestSqlite3CursorNull(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-cursor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+
+	ctx := context.Background()
+	type demo struct {
+		code string
+		memo *string
+	}
+	var demos []demo
+	for i := 0; i < 7; i++ {
+		code := fmt.Sprintf("D%03d", i)
+		must(t, m.Demo.Create(ctx, schema.Demo{ID: fmt.Sprintf("demo-%03d", i), Code: code, Name: code, Memo: fmt.Sprintf("m%d", i%2), Status: 1}))
+		memo := fmt.Sprintf("m%d", i%2)
+		d := demo{code: code, memo: &memo}
+		if i%3 == 0 {
+			d.memo = nil
+		}
+		demos = append(demos, d)
+	}
+	db := m.Trans.(*dao.Trans).DB
+	must(t, db.Model(new(entity.Demo)).Where("code IN (?)", []string{"D000", "D003", "D006"}).Update("memo", gorm.Expr("NULL")).Error)
+
+	for _, d := range []schema.OrderDirection{schema.OrderByASC, schema.OrderByDESC} {
+		// 期望的顺序：NULL最小，相同时按ID降序(查询默认追加的排序)
+		want := make([]demo, len(demos))
+		for i, item := range demos {
+			want[len(demos)-1-i] = item
+		}
+		sort.SliceStable(want, func(i, j int) bool {
+			a, b := want[i].memo, want[j].memo
+			switch {
+			case a == nil || b == nil:
+				if (a == nil) == (b == nil) {
+					return false
+				}
+				return (a == nil) == (d == schema.OrderByASC)
+			case *a == *b:
+				return false
+			}
+			return (*a < *b) == (d == schema.OrderByASC)
+		})
+		codes := make([]string, len(want))
+		for i, item := range want {
+			codes[i] = item.code
+		}
+		wantCodes := strings.Join(codes, ",")
+
+		opt := schema.DemoQueryOptions{OrderFields: schema.NewOrderFields(schema.NewOrderField("memo", d))}
+		query := func(cursor string) *schema.DemoQueryResult {
+			t.Helper()
+			result, err := m.Demo.Query(ctx, schema.DemoQueryParam{
+				PaginationParam: schema.PaginationParam{Pagination: true, UseCursor: true, Cursor: cursor, PageSize: 2},
+			}, opt)
+			must(t, err)
+			return result
+		}
+
+		var pages []*schema.DemoQueryResult
+		for result := query(""); ; result = query(result.PageResult.NextCursor) {
+			pages = append(pages, result)
+			if result.PageResult.NextCursor == "" || len(pages) > len(demos) {
+				break
+			}
+		}
+		var forward []string
+		for _, page := range pages {
+			for _, item := range page.Data {
+				forward = append(forward, item.Code)
+			}
+		}
+		if got := strings.Join(forward, ","); got != wantCodes {
+			t.Fatalf("memo %v forward: got %s, want %s", d, got, wantCodes)
+		}
+
+		// 从最后一页向前翻页
+		result := pages[len(pages)-1]
+		for i := len(pages) - 2; i >= 0; i-- {
+			result = query(result.PageResult.PrevCursor)
+			got, want := make([]string, len(result.Data)), make([]string, len(pages[i].Data))
+			for j, item := range result.Data {
+				got[j] = item.Code
+			}
+			for j, item := range pages[i].Data {
+				want[j] = item.Code
+			}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Fatalf("memo %v backward page %d: got %v, want %v", d, i, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ model.IAudit = (*Audit)(nil)
+
+// AuditSet 注入Audit
+var AuditSet = wire.NewSet(wire.Struct(new(Audit), "*"), wire.Bind(new(model.IAudit), new(*Audit)))
+
+// Audit 审计日志存储
+type Audit struct {
+	DB *gorm.DB
+}
+
+func (a *Audit) getQueryOption(opts ...schema.AuditQueryOptions) schema.AuditQueryOptions {
+	var opt schema.AuditQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Audit) Query(ctx context.Context, params schema.AuditQueryParam, opts ...schema.AuditQueryOptions) (*schema.AuditQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetAuditDB(ctx, a.DB)
+	if v := params.EntityType; v != "" {
+		db = db.Where("entity_type=?", v)
+	}
+	if v := params.EntityID; v != "" {
+		db = db.Where("entity_id=?", v)
+	}
+	if v := params.ActorID; v != "" {
+		db = db.Where("actor_id=?", v)
+	}
+	if v := params.Action; v != "" {
+		db = db.Where("action=?", v)
+	}
+	if v := params.StartTime; !v.IsZero() {
+		db = db.Where("created_at>=?", v)
+	}
+	if v := params.EndTime; !v.IsZero() {
+		db = db.Where("created_at<=?", v)
+	}
+
+	db, err := WrapFilterQuery(db, params.Filters)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Audits
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	qr := &schema.AuditQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaAudits(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Audit) Get(ctx context.Context, id string, opts ...schema.AuditQueryOptions) (*schema.Audit, error) {
+	db := entity.GetAuditDB(ctx, a.DB).Where("id=?", id)
+	var item entity.Audit
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaAudit(), nil
+}
+
+// Create 创建数据
+func (a *Audit) Create(ctx context.Context, item schema.Audit) error {
+	eitem := entity.SchemaAudit(item).ToAudit()
+	result := entity.GetAuditDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -2,12 +2,17 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/util"
 	icontext "github.com/key7men/mag/server/enhance/context"
 	"github.com/key7men/mag/server/schema"
-	"github.com/jinzhu/gorm"
 )
 
 // TransFunc 定义事务执行函数
@@ -27,8 +32,8 @@ func ExecTransWithLock(ctx context.Context, db *gorm.DB, fn TransFunc) error {
 	return ExecTrans(ctx, db, fn)
 }
 
-// WrapPageQuery 包装带有分页的查询
-func WrapPageQuery(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, out interface{}) (*schema.PaginationResult, error) {
+// WrapPageQuery 包装带有排序及分页的查询
+func WrapPageQuery(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, orderFields []*schema.OrderField, out interface{}) (*schema.PaginationResult, error) {
 	if pp.OnlyCount {
 		var count int
 		err := db.Count(&count).Error
@@ -37,11 +42,13 @@ func WrapPageQuery(ctx context.Context, db *gorm.DB, pp schema.PaginationParam,
 		}
 		return &schema.PaginationResult{Total: count}, nil
 	} else if !pp.Pagination {
-		err := db.Find(out).Error
+		err := db.Order(ParseOrder(orderFields)).Find(out).Error
 		return nil, err
+	} else if pp.IsCursor() {
+		return FindCursorPage(ctx, db, pp, orderFields, out)
 	}
 
-	total, err := FindPage(ctx, db, pp, out)
+	total, err := FindPage(ctx, db.Order(ParseOrder(orderFields)), pp, out)
 	if err != nil {
 		return nil, err
 	}
@@ -56,11 +63,13 @@ func WrapPageQuery(ctx context.Context, db *gorm.DB, pp schema.PaginationParam,
 // FindPage 查询分页数据
 func FindPage(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, out interface{}) (int, error) {
 	var count int
-	err := db.Count(&count).Error
-	if err != nil {
-		return 0, err
-	} else if count == 0 {
-		return count, nil
+	if !pp.NoCount {
+		err := db.Count(&count).Error
+		if err != nil {
+			return 0, err
+		} else if count == 0 {
+			return count, nil
+		}
 	}
 
 	current, pageSize := pp.GetCurrent(), pp.GetPageSize()
@@ -70,10 +79,222 @@ func FindPage(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, out i
 		db = db.Limit(pageSize)
 	}
 
-	err = db.Find(out).Error
+	err := db.Find(out).Error
 	return count, err
 }
 
+// FindCursorPage 基于游标(排序字段+ID)查询分页数据，避免大表的OFFSET扫描
+func FindCursorPage(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, orderFields []*schema.OrderField, out interface{}) (*schema.PaginationResult, error) {
+	orderFields = withIDOrderField(orderFields)
+	fields, err := getCursorFields(db, orderFields, out)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &schema.PaginationResult{PageSize: pp.GetPageSize()}
+	if !pp.NoCount {
+		err := db.Count(&pr.Total).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cursor *schema.PageCursor
+	if v := pp.Cursor; v != "" {
+		cursor, err = schema.DecodeCursor(v)
+		if err != nil || !cursor.Match(orderFields) {
+			return nil, errs.ErrInvalidCursor
+		}
+
+		query, args, err := cursorWhere(orderFields, fields, cursor)
+		if err != nil {
+			return nil, errs.ErrInvalidCursor
+		}
+		db = db.Where(query, args...)
+	}
+
+	backward := cursor != nil && cursor.Prev
+	queryFields := orderFields
+	if backward {
+		queryFields = reverseOrderFields(orderFields)
+	}
+
+	pageSize := pp.GetPageSize()
+	err = db.Order(cursorOrder(queryFields, fields)).Limit(pageSize + 1).Find(out).Error
+	if err != nil {
+		return nil, err
+	}
+
+	list := reflect.ValueOf(out).Elem()
+	hasMore := uint(list.Len()) > pageSize
+	if hasMore {
+		list.Set(list.Slice(0, int(pageSize)))
+	}
+	if backward {
+		reverseSlice(list)
+	}
+
+	if n := list.Len(); n > 0 {
+		// 向后翻页时，只要存在更多数据或来自上一页就有下一页；向前翻页时同理
+		order := schema.FormatCursorOrder(orderFields)
+		if hasMore || backward {
+			pr.NextCursor = encodeCursor(list.Index(n-1), fields, order, false)
+		}
+		if cursor != nil && (!backward || hasMore) {
+			pr.PrevCursor = encodeCursor(list.Index(0), fields, order, true)
+		}
+	}
+
+	return pr, nil
+}
+
+// 确保排序字段以ID结尾，保证排序的唯一性
+func withIDOrderField(items []*schema.OrderField) []*schema.OrderField {
+	for _, item := range items {
+		if item.Key == "id" {
+			return items
+		}
+	}
+	return append(items[:len(items):len(items)], schema.NewOrderField("id", schema.OrderByASC))
+}
+
+func reverseOrderFields(items []*schema.OrderField) []*schema.OrderField {
+	list := make([]*schema.OrderField, len(items))
+	for i, item := range items {
+		d := schema.OrderByDESC
+		if item.Direction == schema.OrderByDESC {
+			d = schema.OrderByASC
+		}
+		list[i] = schema.NewOrderField(item.Key, d)
+	}
+	return list
+}
+
+func reverseSlice(v reflect.Value) {
+	for i, j := 0, v.Len()-1; i < j; i, j = i+1, j-1 {
+		vi, vj := v.Index(i).Interface(), v.Index(j).Interface()
+		v.Index(i).Set(reflect.ValueOf(vj))
+		v.Index(j).Set(reflect.ValueOf(vi))
+	}
+}
+
+// 获取排序字段对应的实体字段
+func getCursorFields(db *gorm.DB, orderFields []*schema.OrderField, out interface{}) ([]*gorm.StructField, error) {
+	ms := db.NewScope(out).GetModelStruct()
+	fields := make([]*gorm.StructField, len(orderFields))
+	for i, item := range orderFields {
+		for _, sf := range ms.StructFields {
+			if sf.IsNormal && sf.DBName == item.Key {
+				fields[i] = sf
+				break
+			}
+		}
+		if fields[i] == nil {
+			return nil, errs.Errorf("unknown cursor order field: %s", item.Key)
+		}
+	}
+	return fields, nil
+}
+
+// 是否为可为NULL的字段(指针类型)
+func isNullableField(sf *gorm.StructField) bool {
+	return sf.Struct.Type.Kind() == reflect.Ptr
+}
+
+// 游标分页的排序(NULL值视为最小值：升序时在前，降序时在后，与定位条件保持一致)
+func cursorOrder(orderFields []*schema.OrderField, fields []*gorm.StructField) string {
+	orders := make([]string, 0, len(orderFields))
+	for i, item := range orderFields {
+		direction := "ASC"
+		if item.Direction == schema.OrderByDESC {
+			direction = "DESC"
+		}
+		if isNullableField(fields[i]) {
+			nullDirection := "DESC"
+			if direction == "DESC" {
+				nullDirection = "ASC"
+			}
+			orders = append(orders, fmt.Sprintf("%s IS NULL %s", item.Key, nullDirection))
+		}
+		orders = append(orders, fmt.Sprintf("%s %s", item.Key, direction))
+	}
+	return strings.Join(orders, ",")
+}
+
+// 构建游标的定位条件，形如：(a > ?) OR (a = ? AND b > ?)
+// NULL值视为最小值：a为NULL时，大于条件为a IS NOT NULL，不存在小于的记录；a不为NULL时，可为NULL的字段的小于条件包含a IS NULL
+func cursorWhere(orderFields []*schema.OrderField, fields []*gorm.StructField, cursor *schema.PageCursor) (string, []interface{}, error) {
+	values := make([]interface{}, len(fields))
+	for i, sf := range fields {
+		if cursor.IsNull(i) {
+			continue
+		}
+		t := sf.Struct.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		v := reflect.New(t)
+		if err := util.JSONUnmarshal(cursor.Values[i], v.Interface()); err != nil {
+			return "", nil, err
+		}
+		values[i] = v.Elem().Interface()
+	}
+
+	var (
+		ors  []string
+		args []interface{}
+	)
+	for i, item := range orderFields {
+		less := (item.Direction == schema.OrderByDESC) != cursor.Prev
+		var cmp string
+		switch {
+		case values[i] == nil && less:
+			continue
+		case values[i] == nil:
+			cmp = fmt.Sprintf("%s IS NOT NULL", item.Key)
+		case less && isNullableField(fields[i]):
+			cmp = fmt.Sprintf("(%s < ? OR %s IS NULL)", item.Key, item.Key)
+		case less:
+			cmp = fmt.Sprintf("%s < ?", item.Key)
+		default:
+			cmp = fmt.Sprintf("%s > ?", item.Key)
+		}
+
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				ands = append(ands, fmt.Sprintf("%s IS NULL", orderFields[j].Key))
+				continue
+			}
+			ands = append(ands, fmt.Sprintf("%s = ?", orderFields[j].Key))
+			args = append(args, values[j])
+		}
+		ands = append(ands, cmp)
+		if values[i] != nil {
+			args = append(args, values[i])
+		}
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	if len(ors) == 0 {
+		return "1 = 0", nil, nil
+	}
+	return strings.Join(ors, " OR "), args, nil
+}
+
+func encodeCursor(item reflect.Value, fields []*gorm.StructField, order string, prev bool) string {
+	item = reflect.Indirect(item)
+	values := make([]json.RawMessage, len(fields))
+	for i, sf := range fields {
+		buf, err := util.JSONMarshal(item.FieldByName(sf.Name).Interface())
+		if err != nil {
+			return ""
+		}
+		values[i] = buf
+	}
+	return schema.EncodeCursor(&schema.PageCursor{Prev: prev, Order: order, Values: values})
+}
+
 // FindOne 查询单条数据
 func FindOne(ctx context.Context, db *gorm.DB, out interface{}) (bool, error) {
 	result := db.First(out)
@@ -96,6 +317,43 @@ func Check(ctx context.Context, db *gorm.DB) (bool, error) {
 	return count > 0, nil
 }
 
+var columnRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var filterOperators = map[schema.FilterOperator]string{
+	schema.FilterEQ:   "=",
+	schema.FilterNE:   "<>",
+	schema.FilterGT:   ">",
+	schema.FilterGTE:  ">=",
+	schema.FilterLT:   "<",
+	schema.FilterLTE:  "<=",
+	schema.FilterLike: "LIKE",
+	schema.FilterIn:   "IN",
+}
+
+// WrapFilterQuery 包装通用过滤条件(列名仅允许小写蛇形，值均以参数绑定)
+func WrapFilterQuery(db *gorm.DB, filters []*schema.FilterField) (*gorm.DB, error) {
+	for _, item := range filters {
+		op, ok := filterOperators[item.Operator]
+		if !ok {
+			return nil, errs.Errorf("invalid filter operator: %s", item.Operator)
+		} else if !columnRegexp.MatchString(item.Key) {
+			return nil, errs.Errorf("invalid filter column: %s", item.Key)
+		} else if len(item.Values) == 0 {
+			continue
+		}
+
+		switch item.Operator {
+		case schema.FilterIn:
+			db = db.Where(fmt.Sprintf("%s IN (?)", item.Key), item.Values)
+		case schema.FilterLike:
+			db = db.Where(fmt.Sprintf("%s LIKE ?", item.Key), "%"+item.Values[0]+"%")
+		default:
+			db = db.Where(fmt.Sprintf("%s %s ?", item.Key, op), item.Values[0])
+		}
+	}
+	return db, nil
+}
+
 // OrderFieldFunc 排序字段转换函数
 type OrderFieldFunc func(string) string
 
@@ -42,11 +42,15 @@ func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...
 		db = db.Where("code LIKE ? OR name LIKE ? OR memo LIKE ?", v, v, v)
 	}
 
+	db, err := WrapFilterQuery(db, params.Filters)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
 
 	var list entity.Demos
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ model.ILog = (*Log)(nil)
+
+// LogSet 注入Log
+var LogSet = wire.NewSet(wire.Struct(new(Log), "*"), wire.Bind(new(model.ILog), new(*Log)))
+
+// Log 系统日志存储
+type Log struct {
+	DB *gorm.DB
+}
+
+func (a *Log) getQueryOption(opts ...schema.LogQueryOptions) schema.LogQueryOptions {
+	var opt schema.LogQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// 日志表由gorm日志钩子创建，未启用钩子时不存在
+func (a *Log) hasTable() bool {
+	return a.DB.HasTable(entity.Log{})
+}
+
+// Query 查询数据
+func (a *Log) Query(ctx context.Context, params schema.LogQueryParam, opts ...schema.LogQueryOptions) (*schema.LogQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+	if !a.hasTable() {
+		return &schema.LogQueryResult{PageResult: &schema.PaginationResult{}, Data: []*schema.Log{}}, nil
+	}
+
+	db := entity.GetLogDB(ctx, a.DB)
+	if v := params.IDs; len(v) > 0 {
+		db = db.Where("id IN (?)", v)
+	}
+	if v := params.Levels; len(v) > 0 {
+		db = db.Where("level IN (?)", v)
+	}
+	if v := params.TraceID; v != "" {
+		db = db.Where("trace_id=?", v)
+	}
+	if v := params.UserID; v != "" {
+		db = db.Where("user_id=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("message LIKE ? OR data LIKE ?", v, v)
+	}
+	if v := params.StartTime; !v.IsZero() {
+		db = db.Where("created_at>=?", v)
+	}
+	if v := params.EndTime; !v.IsZero() {
+		db = db.Where("created_at<=?", v)
+	}
+	if v := params.Before; !v.IsZero() {
+		db = db.Where("created_at<?", v)
+	}
+
+	db, err := WrapFilterQuery(db, params.Filters)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Logs
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	qr := &schema.LogQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaLogs(),
+	}
+
+	return qr, nil
+}
+
+// DeleteByIDs 删除指定数据
+func (a *Log) DeleteByIDs(ctx context.Context, ids []string) error {
+	if len(ids) == 0 || !a.hasTable() {
+		return nil
+	}
+
+	result := entity.GetLogDB(ctx, a.DB).Where("id IN (?)", ids).Delete(entity.Log{})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
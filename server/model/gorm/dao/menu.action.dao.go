@@ -42,10 +42,9 @@ func (a *MenuAction) Query(ctx context.Context, params schema.MenuActionQueryPar
 	}
 
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
-	db = db.Order(ParseOrder(opt.OrderFields))
 
 	var list entity.MenuActions
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -47,10 +47,9 @@ func (a *MenuActionResource) Query(ctx context.Context, params schema.MenuAction
 	}
 
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
-	db = db.Order(ParseOrder(opt.OrderFields))
 
 	var list entity.MenuActionResources
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -107,7 +106,7 @@ func (a *MenuActionResource) Delete(ctx context.Context, id string) error {
 
 // DeleteByActionID 根据动作ID删除数据
 func (a *MenuActionResource) DeleteByActionID(ctx context.Context, actionID string) error {
-	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id =?", actionID).Delete(entity.MenuAction{})
+	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id =?", actionID).Delete(entity.MenuActionResource{})
 	if err := result.Error; err != nil {
 		return errs.WithStack(err)
 	}
@@ -117,7 +116,7 @@ func (a *MenuActionResource) DeleteByActionID(ctx context.Context, actionID stri
 // DeleteByMenuID 根据菜单ID删除数据
 func (a *MenuActionResource) DeleteByMenuID(ctx context.Context, menuID string) error {
 	subQuery := entity.GetMenuActionDB(ctx, a.DB).Where("menu_id=?", menuID).Select("id").SubQuery()
-	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id IN ?", subQuery).Delete(entity.MenuAction{})
+	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id IN ?", subQuery).Delete(entity.MenuActionResource{})
 	if err := result.Error; err != nil {
 		return errs.WithStack(err)
 	}
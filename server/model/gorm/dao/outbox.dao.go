@@ -0,0 +1,96 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ model.IOutbox = (*Outbox)(nil)
+
+// OutboxSet 注入Outbox
+var OutboxSet = wire.NewSet(wire.Struct(new(Outbox), "*"), wire.Bind(new(model.IOutbox), new(*Outbox)))
+
+// Outbox 事件发件箱存储
+type Outbox struct {
+	DB *gorm.DB
+}
+
+func (a *Outbox) getQueryOption(opts ...schema.EventQueryOptions) schema.EventQueryOptions {
+	var opt schema.EventQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Outbox) Query(ctx context.Context, params schema.EventQueryParam, opts ...schema.EventQueryOptions) (*schema.EventQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetOutboxDB(ctx, a.DB)
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.RetryBefore; !v.IsZero() {
+		db = db.Where("next_retry_at<=?", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
+
+	var list entity.Outboxes
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	qr := &schema.EventQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaEvents(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *Outbox) Create(ctx context.Context, item schema.Event) error {
+	eitem := entity.SchemaEvent(item).ToOutbox()
+	result := entity.GetOutboxDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(仅更新投递状态，零值同样写入)
+func (a *Outbox) Update(ctx context.Context, id string, item schema.Event) error {
+	eitem := entity.SchemaEvent(item).ToOutbox()
+	result := entity.GetOutboxDB(ctx, a.DB).Where("id=?", id).
+		Updates(map[string]interface{}{
+			"status":        eitem.Status,
+			"attempts":      eitem.Attempts,
+			"last_error":    eitem.LastError,
+			"next_retry_at": eitem.NextRetryAt,
+			"sent_sinks":    eitem.SentSinks,
+		})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Claim 领取到期的待投递事件(条件更新，同一事件只有一个实例领取成功)
+func (a *Outbox) Claim(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	result := entity.GetOutboxDB(ctx, a.DB).
+		Where("id=? AND status=? AND next_retry_at<=?", id, schema.EventPending, now).
+		Update("next_retry_at", leaseUntil)
+	if err := result.Error; err != nil {
+		return false, errs.WithStack(err)
+	}
+	return result.RowsAffected > 0, nil
+}
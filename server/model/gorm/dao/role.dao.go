@@ -52,11 +52,15 @@ func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...
 		db = db.Where("name LIKE ? OR memo LIKE ?", v, v)
 	}
 
+	db, err := WrapFilterQuery(db, params.Filters)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
 
 	var list entity.Roles
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -42,10 +42,9 @@ func (a *RoleMenu) Query(ctx context.Context, params schema.RoleMenuQueryParam,
 	}
 
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
 
 	var list entity.RoleMenus
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -108,3 +107,21 @@ func (a *RoleMenu) DeleteByRoleID(ctx context.Context, roleID string) error {
 	}
 	return nil
 }
+
+// DeleteByMenuID 根据菜单ID删除数据
+func (a *RoleMenu) DeleteByMenuID(ctx context.Context, menuID string) error {
+	result := entity.GetRoleMenuDB(ctx, a.DB).Where("menu_id=?", menuID).Delete(entity.RoleMenu{})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByActionID 根据动作ID删除数据
+func (a *RoleMenu) DeleteByActionID(ctx context.Context, actionID string) error {
+	result := entity.GetRoleMenuDB(ctx, a.DB).Where("action_id=?", actionID).Delete(entity.RoleMenu{})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ model.ITenant = (*Tenant)(nil)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"), wire.Bind(new(model.ITenant), new(*Tenant)))
+
+// Tenant 租户存储
+type Tenant struct {
+	DB *gorm.DB
+}
+
+func (a *Tenant) getQueryOption(opts ...schema.TenantQueryOptions) schema.TenantQueryOptions {
+	var opt schema.TenantQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetTenantDB(ctx, a.DB)
+	if v := params.Code; v != "" {
+		db = db.Where("code=?", v)
+	}
+	if v := params.Domain; v != "" {
+		db = db.Where("domain=?", v)
+	}
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("code LIKE ? OR name LIKE ? OR domain LIKE ? OR memo LIKE ?", v, v, v, v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Tenants
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	qr := &schema.TenantQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaTenants(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, id string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	db := entity.GetTenantDB(ctx, a.DB).Where("id=?", id)
+	var item entity.Tenant
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaTenant(), nil
+}
+
+// Create 创建数据
+func (a *Tenant) Create(ctx context.Context, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	result := entity.GetTenantDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(绑定的域名允许清空，因此显式更新)
+func (a *Tenant) Update(ctx context.Context, id string, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	result := entity.GetTenantDB(ctx, a.DB).Where("id=?", id).Updates(map[string]interface{}{
+		"code":     eitem.Code,
+		"name":     eitem.Name,
+		"domain":   eitem.Domain,
+		"status":   eitem.Status,
+		"memo":     eitem.Memo,
+		"admin_id": eitem.AdminID,
+	})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(ctx context.Context, id string) error {
+	result := entity.GetTenantDB(ctx, a.DB).Where("id=?", id).Delete(entity.Tenant{})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Tenant) UpdateStatus(ctx context.Context, id string, status int) error {
+	result := entity.GetTenantDB(ctx, a.DB).Where("id=?", id).Update("status", status)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
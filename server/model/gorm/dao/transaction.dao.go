@@ -2,6 +2,8 @@ package dao
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/google/wire"
 	"github.com/jinzhu/gorm"
@@ -34,3 +36,36 @@ func (a *Trans) Exec(ctx context.Context, fn func(context.Context) error) error
 	}
 	return nil
 }
+
+// 保存点序号(保证嵌套的保存点名称唯一)
+var savepointSeq uint64
+
+// Savepoint 在当前事务中以保存点执行，返回错误时回滚到保存点，事务可继续执行其他操作
+// (postgres中语句失败后事务将中止，必须回滚到保存点后才能继续)
+func (a *Trans) Savepoint(ctx context.Context, fn func(context.Context) error) error {
+	trans, ok := icontext.FromTrans(ctx)
+	if !ok {
+		return a.Exec(ctx, fn)
+	}
+	db, ok := trans.(*gorm.DB)
+	if !ok {
+		return fn(ctx)
+	}
+
+	name := fmt.Sprintf("sp_%d", atomic.AddUint64(&savepointSeq, 1))
+	if err := db.Exec("SAVEPOINT " + name).Error; err != nil {
+		return errs.WithStack(err)
+	}
+
+	if err := fn(ctx); err != nil {
+		if rerr := db.Exec("ROLLBACK TO SAVEPOINT " + name).Error; rerr != nil {
+			return errs.WithStack(rerr)
+		}
+		return err
+	}
+
+	if err := db.Exec("RELEASE SAVEPOINT " + name).Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ model.IWebhook = (*Webhook)(nil)
+
+// WebhookSet 注入Webhook
+var WebhookSet = wire.NewSet(wire.Struct(new(Webhook), "*"), wire.Bind(new(model.IWebhook), new(*Webhook)))
+
+// Webhook webhook订阅存储
+type Webhook struct {
+	DB *gorm.DB
+}
+
+func (a *Webhook) getQueryOption(opts ...schema.WebhookQueryOptions) schema.WebhookQueryOptions {
+	var opt schema.WebhookQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Webhook) Query(ctx context.Context, params schema.WebhookQueryParam, opts ...schema.WebhookQueryOptions) (*schema.WebhookQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetWebhookDB(ctx, a.DB)
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.URL; v != "" {
+		db = db.Where("url=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("name LIKE ? OR url LIKE ? OR memo LIKE ?", v, v, v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Webhooks
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	qr := &schema.WebhookQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaWebhooks(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Webhook) Get(ctx context.Context, id string, opts ...schema.WebhookQueryOptions) (*schema.Webhook, error) {
+	db := entity.GetWebhookDB(ctx, a.DB).Where("id=?", id)
+	var item entity.Webhook
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaWebhook(), nil
+}
+
+// Create 创建数据
+func (a *Webhook) Create(ctx context.Context, item schema.Webhook) error {
+	eitem := entity.SchemaWebhook(item).ToWebhook()
+	result := entity.GetWebhookDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(订阅的事件类型允许清空，因此显式更新)
+func (a *Webhook) Update(ctx context.Context, id string, item schema.Webhook) error {
+	eitem := entity.SchemaWebhook(item).ToWebhook()
+	result := entity.GetWebhookDB(ctx, a.DB).Where("id=?", id).Updates(map[string]interface{}{
+		"name":   eitem.Name,
+		"url":    eitem.URL,
+		"secret": eitem.Secret,
+		"events": eitem.Events,
+		"status": eitem.Status,
+		"memo":   eitem.Memo,
+	})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Webhook) Delete(ctx context.Context, id string) error {
+	result := entity.GetWebhookDB(ctx, a.DB).Where("id=?", id).Delete(entity.Webhook{})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Webhook) UpdateStatus(ctx context.Context, id string, status int) error {
+	result := entity.GetWebhookDB(ctx, a.DB).Where("id=?", id).Update("status", status)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
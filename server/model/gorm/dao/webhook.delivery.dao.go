@@ -0,0 +1,116 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ model.IWebhookDelivery = (*WebhookDelivery)(nil)
+
+// WebhookDeliverySet 注入WebhookDelivery
+var WebhookDeliverySet = wire.NewSet(wire.Struct(new(WebhookDelivery), "*"), wire.Bind(new(model.IWebhookDelivery), new(*WebhookDelivery)))
+
+// WebhookDelivery webhook投递记录存储
+type WebhookDelivery struct {
+	DB *gorm.DB
+}
+
+func (a *WebhookDelivery) getQueryOption(opts ...schema.WebhookDeliveryQueryOptions) schema.WebhookDeliveryQueryOptions {
+	var opt schema.WebhookDeliveryQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *WebhookDelivery) Query(ctx context.Context, params schema.WebhookDeliveryQueryParam, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDeliveryQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetWebhookDeliveryDB(ctx, a.DB)
+	if v := params.WebhookID; v != "" {
+		db = db.Where("webhook_id=?", v)
+	}
+	if v := params.EventID; v != "" {
+		db = db.Where("event_id=?", v)
+	}
+	if v := params.EventType; v != "" {
+		db = db.Where("event_type=?", v)
+	}
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.RetryBefore; !v.IsZero() {
+		db = db.Where("next_retry_at<=?", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.WebhookDeliveries
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	qr := &schema.WebhookDeliveryQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaWebhookDeliveries(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *WebhookDelivery) Get(ctx context.Context, id string, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDelivery, error) {
+	db := entity.GetWebhookDeliveryDB(ctx, a.DB).Where("id=?", id)
+	var item entity.WebhookDelivery
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaWebhookDelivery(), nil
+}
+
+// Create 创建数据
+func (a *WebhookDelivery) Create(ctx context.Context, item schema.WebhookDelivery) error {
+	eitem := entity.SchemaWebhookDelivery(item).ToWebhookDelivery()
+	result := entity.GetWebhookDeliveryDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(仅更新投递状态，零值同样写入)
+func (a *WebhookDelivery) Update(ctx context.Context, id string, item schema.WebhookDelivery) error {
+	eitem := entity.SchemaWebhookDelivery(item).ToWebhookDelivery()
+	result := entity.GetWebhookDeliveryDB(ctx, a.DB).Where("id=?", id).Updates(map[string]interface{}{
+		"status":          eitem.Status,
+		"attempts":        eitem.Attempts,
+		"response_status": eitem.ResponseStatus,
+		"response_body":   eitem.ResponseBody,
+		"last_error":      eitem.LastError,
+		"next_retry_at":   eitem.NextRetryAt,
+	})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByWebhookID 根据webhook订阅ID删除数据
+func (a *WebhookDelivery) DeleteByWebhookID(ctx context.Context, webhookID string) error {
+	result := entity.GetWebhookDeliveryDB(ctx, a.DB).Where("webhook_id=?", webhookID).Delete(entity.WebhookDelivery{})
+	if err := result.Error; err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
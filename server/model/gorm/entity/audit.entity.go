@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+)
+
+// GetAuditDB 获取审计日志存储
+func GetAuditDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Audit))
+}
+
+// SchemaAudit 审计日志对象
+type SchemaAudit schema.Audit
+
+// ToAudit 转换为审计日志实体
+func (a SchemaAudit) ToAudit() *Audit {
+	item := new(Audit)
+	util.StructMapToStruct(a, item)
+	item.Changes = util.JSONMarshalToString(a.Changes)
+	return item
+}
+
+// Audit 审计日志实体
+type Audit struct {
+	Model
+	EntityType string `gorm:"column:entity_type;size:50;index;default:'';not null;"` // 实体类型
+	EntityID   string `gorm:"column:entity_id;size:36;index;default:'';not null;"`   // 实体ID
+	Action     string `gorm:"column:action;size:20;index;default:'';not null;"`      // 审计动作
+	ActorID    string `gorm:"column:actor_id;size:36;index;default:'';not null;"`    // 操作人
+	TraceID    string `gorm:"column:trace_id;size:100;index;default:'';not null;"`   // 追踪ID
+	Changes    string `gorm:"column:changes;type:text;"`                             // 变更字段列表(JSON)
+}
+
+// TableName 表名
+func (a Audit) TableName() string {
+	return a.Model.TableName("audit")
+}
+
+// ToSchemaAudit 转换为审计日志对象
+func (a Audit) ToSchemaAudit() *schema.Audit {
+	item := new(schema.Audit)
+	util.StructMapToStruct(a, item)
+	item.Changes = nil
+	if a.Changes != "" {
+		_ = util.JSONUnmarshal([]byte(a.Changes), &item.Changes)
+	}
+	return item
+}
+
+// Audits 审计日志实体列表
+type Audits []*Audit
+
+// ToSchemaAudits 转换为审计日志对象列表
+func (a Audits) ToSchemaAudits() []*schema.Audit {
+	list := make([]*schema.Audit, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaAudit()
+	}
+	return list
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/trace"
 	"github.com/key7men/mag/server/config"
 	icontext "github.com/key7men/mag/server/enhance/context"
 )
@@ -16,6 +17,20 @@ type Model struct {
 	CreatedAt time.Time  `gorm:"column:created_at;index;"`
 	UpdatedAt time.Time  `gorm:"column:updated_at;index;"`
 	DeletedAt *time.Time `gorm:"column:deleted_at;index;"`
+	TenantID  string     `gorm:"column:tenant_id;size:36;index;default:'';not null;"`
+}
+
+// TenantShared 不按租户隔离的实体(全局数据，如菜单、动作、资源及租户)
+type TenantShared interface {
+	TenantShared() bool
+}
+
+// IsTenantScoped 检查实体是否按租户隔离
+func IsTenantScoped(m interface{}) bool {
+	if v, ok := m.(TenantShared); ok {
+		return !v.TenantShared()
+	}
+	return true
 }
 
 // TableName table name
@@ -23,6 +38,9 @@ func (Model) TableName(name string) string {
 	return fmt.Sprintf("%s%s", config.C.Gorm.TablePrefix, name)
 }
 
+// 上下文在gorm作用域中的键名(供链路跟踪回调获取)
+const scopeContextKey = "mag:context"
+
 // GetDB ...
 func GetDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	trans, ok := icontext.FromTrans(ctx)
@@ -35,13 +53,35 @@ func GetDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 					db = db.Set("gorm:query_option", "FOR UPDATE")
 				}
 			}
-			return db
+			return withScopeContext(ctx, db)
 		}
 	}
-	return defDB
+	return withScopeContext(ctx, defDB)
 }
 
-// GetDBWithModel ...
+// 仅在回调需要时(存在记录中的跟踪单元或租户ID)将上下文写入gorm作用域，避免每次调用都复制*gorm.DB
+func withScopeContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if _, ok := icontext.FromTenantID(ctx); ok || trace.SpanFromContext(ctx).IsRecording() {
+		return db.Set(scopeContextKey, ctx)
+	}
+	return db
+}
+
+// FromScopeContext 获取gorm作用域中的上下文
+func FromScopeContext(scope *gorm.Scope) (context.Context, bool) {
+	v, ok := scope.Get(scopeContextKey)
+	if !ok {
+		return nil, false
+	}
+	ctx, ok := v.(context.Context)
+	return ctx, ok
+}
+
+// GetDBWithModel 获取指定实体的存储(按租户隔离的实体仅查询及修改上下文中租户的数据，未指定租户时为平台数据)
 func GetDBWithModel(ctx context.Context, defDB *gorm.DB, m interface{}) *gorm.DB {
-	return GetDB(ctx, defDB).Model(m)
+	db := GetDB(ctx, defDB).Model(m)
+	if tenantID, ok := icontext.FromTenantScope(ctx); ok && IsTenantScoped(m) {
+		db = db.Where("tenant_id=?", tenantID)
+	}
+	return db
 }
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/schema"
+)
+
+// GetLogDB 获取系统日志存储
+func GetLogDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Log))
+}
+
+// Log 系统日志实体(表由gorm日志钩子创建，与钩子的表结构保持一致)
+type Log struct {
+	ID           uint      `gorm:"column:id;primary_key;auto_increment;"` // id
+	Level        string    `gorm:"column:level;size:20;index;"`           // 日志级别
+	Message      string    `gorm:"column:message;size:1024;"`             // 消息
+	TraceID      string    `gorm:"column:trace_id;size:128;index;"`       // 跟踪ID
+	UserID       string    `gorm:"column:user_id;size:36;index;"`         // 用户ID
+	SpanTitle    string    `gorm:"column:span_title;size:256;"`           // 跟踪单元标题
+	SpanFunction string    `gorm:"column:span_function;size:256;"`        // 跟踪单元函数名
+	Data         string    `gorm:"column:data;type:text;"`                // 日志数据(json)
+	Version      string    `gorm:"column:version;index;size:32;"`         // 服务版本号
+	CreatedAt    time.Time `gorm:"column:created_at;index"`               // 创建时间
+}
+
+// TenantShared 系统日志不按租户隔离
+func (a Log) TenantShared() bool {
+	return true
+}
+
+// TableName 表名(与日志钩子配置的表名一致，未配置时使用默认表名)
+func (a Log) TableName() string {
+	if v := config.C.LogGormHook.Table; v != "" {
+		return v
+	}
+	return Model{}.TableName("logger")
+}
+
+// ToSchemaLog 转换为系统日志对象
+func (a Log) ToSchemaLog() *schema.Log {
+	return &schema.Log{
+		ID:           strconv.FormatUint(uint64(a.ID), 10),
+		Level:        a.Level,
+		Message:      a.Message,
+		TraceID:      a.TraceID,
+		UserID:       a.UserID,
+		SpanTitle:    a.SpanTitle,
+		SpanFunction: a.SpanFunction,
+		Data:         a.Data,
+		Version:      a.Version,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// Logs 系统日志实体列表
+type Logs []*Log
+
+// ToSchemaLogs 转换为系统日志对象列表
+func (a Logs) ToSchemaLogs() []*schema.Log {
+	list := make([]*schema.Log, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaLog()
+	}
+	return list
+}
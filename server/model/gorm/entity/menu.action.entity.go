@@ -31,6 +31,11 @@ type MenuAction struct {
 	Name   string `gorm:"column:name;size:100;default:'';not null;"`         // 动作名称
 }
 
+// TenantShared 菜单动作为全局数据(不按租户隔离)
+func (a MenuAction) TenantShared() bool {
+	return true
+}
+
 // TableName 表名
 func (a MenuAction) TableName() string {
 	return a.Model.TableName("menu_action")
@@ -31,6 +31,11 @@ Method   string `gorm:"column:method;size:100;default:'';not null;"`         //
 Path     string `gorm:"column:path;size:100;default:'';not null;"`           // 资源请求路径（支持/:id匹配）
 }
 
+// TenantShared 菜单资源为全局数据(不按租户隔离)
+func (a MenuActionResource) TenantShared() bool {
+	return true
+}
+
 // TableName 表名
 func (a MenuActionResource) TableName() string {
 return a.Model.TableName("menu_action_resource")
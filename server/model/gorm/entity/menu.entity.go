@@ -38,6 +38,11 @@ type Menu struct {
 	Creator    string  `gorm:"column:creator;size:36;"`                        // 创建人
 }
 
+// TenantShared 菜单为全局数据(不按租户隔离)
+func (a Menu) TenantShared() bool {
+	return true
+}
+
 // TableName 表名
 func (a Menu) TableName() string {
 	return a.Model.TableName("menu")
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+)
+
+// GetOutboxDB 获取事件发件箱存储
+func GetOutboxDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Outbox))
+}
+
+// SchemaEvent 领域事件对象
+type SchemaEvent schema.Event
+
+// ToOutbox 转换为事件发件箱实体
+func (a SchemaEvent) ToOutbox() *Outbox {
+	item := new(Outbox)
+	util.StructMapToStruct(a, item)
+	item.Status = int(a.Status)
+	item.Payload = string(a.Payload)
+	item.SentSinks = strings.Join(a.SentSinks, ",")
+	return item
+}
+
+// Outbox 事件发件箱实体
+type Outbox struct {
+	Model
+	Type        string    `gorm:"column:type;size:100;index;default:'';not null;"`        // 事件类型
+	AggregateID string    `gorm:"column:aggregate_id;size:36;index;default:'';not null;"` // 聚合(实体)ID
+	Payload     string    `gorm:"column:payload;type:text;"`                              // 事件数据(JSON)
+	ActorID     string    `gorm:"column:actor_id;size:36;default:'';not null;"`           // 操作人
+	TraceID     string    `gorm:"column:trace_id;size:100;default:'';not null;"`          // 追踪ID
+	Status      int       `gorm:"column:status;index;default:0;not null;"`                // 投递状态(1:待投递 2:已投递 3:超过最大重试次数)
+	Attempts    int       `gorm:"column:attempts;default:0;not null;"`                    // 投递次数
+	LastError   string    `gorm:"column:last_error;size:1024;default:'';not null;"`       // 最后一次投递错误
+	SentSinks   string    `gorm:"column:sent_sinks;size:255;default:'';not null;"`        // 已投递成功的目标(逗号分隔)
+	NextRetryAt time.Time `gorm:"column:next_retry_at;index;"`                            // 下次投递时间
+}
+
+// TableName 表名
+func (a Outbox) TableName() string {
+	return a.Model.TableName("outbox")
+}
+
+// ToSchemaEvent 转换为领域事件对象
+func (a Outbox) ToSchemaEvent() *schema.Event {
+	item := new(schema.Event)
+	util.StructMapToStruct(a, item)
+	item.Status = schema.EventStatus(a.Status)
+	item.Payload = []byte(a.Payload)
+	item.SentSinks = nil
+	if a.SentSinks != "" {
+		item.SentSinks = strings.Split(a.SentSinks, ",")
+	}
+	return item
+}
+
+// Outboxes 事件发件箱实体列表
+type Outboxes []*Outbox
+
+// ToSchemaEvents 转换为领域事件对象列表
+func (a Outboxes) ToSchemaEvents() []*schema.Event {
+	list := make([]*schema.Event, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaEvent()
+	}
+	return list
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+)
+
+// GetTenantDB 获取租户存储
+func GetTenantDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Tenant))
+}
+
+// SchemaTenant 租户对象
+type SchemaTenant schema.Tenant
+
+// ToTenant 转换为租户实体
+func (a SchemaTenant) ToTenant() *Tenant {
+	item := new(Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenant 租户实体
+type Tenant struct {
+	Model
+	Code    string  `gorm:"column:code;size:50;index;default:'';not null;"`    // 租户编号
+	Name    string  `gorm:"column:name;size:100;index;default:'';not null;"`   // 租户名称
+	Domain  string  `gorm:"column:domain;size:255;index;default:'';not null;"` // 绑定的域名
+	Status  int     `gorm:"column:status;index;default:0;not null;"`           // 状态(1:启用 2:禁用)
+	Memo    *string `gorm:"column:memo;size:1024;"`                            // 备注
+	AdminID string  `gorm:"column:admin_id;size:36;default:'';not null;"`      // 租户管理员
+	Creator string  `gorm:"column:creator;size:36;"`                           // 创建者
+}
+
+// TenantShared 租户为全局数据(不按租户隔离)
+func (a Tenant) TenantShared() bool {
+	return true
+}
+
+// TableName 表名
+func (a Tenant) TableName() string {
+	return a.Model.TableName("tenant")
+}
+
+// ToSchemaTenant 转换为租户对象
+func (a Tenant) ToSchemaTenant() *schema.Tenant {
+	item := new(schema.Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenants 租户实体列表
+type Tenants []*Tenant
+
+// ToSchemaTenants 转换为租户对象列表
+func (a Tenants) ToSchemaTenants() []*schema.Tenant {
+	list := make([]*schema.Tenant, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaTenant()
+	}
+	return list
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+)
+
+// GetWebhookDeliveryDB 获取webhook投递记录存储
+func GetWebhookDeliveryDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(WebhookDelivery))
+}
+
+// SchemaWebhookDelivery webhook投递记录对象
+type SchemaWebhookDelivery schema.WebhookDelivery
+
+// ToWebhookDelivery 转换为webhook投递记录实体
+func (a SchemaWebhookDelivery) ToWebhookDelivery() *WebhookDelivery {
+	item := new(WebhookDelivery)
+	util.StructMapToStruct(a, item)
+	item.Status = int(a.Status)
+	item.Payload = string(a.Payload)
+	return item
+}
+
+// WebhookDelivery webhook投递记录实体
+type WebhookDelivery struct {
+	Model
+	WebhookID      string    `gorm:"column:webhook_id;size:36;index;default:'';not null;"`  // webhook订阅ID
+	EventID        string    `gorm:"column:event_id;size:36;index;default:'';not null;"`    // 事件ID
+	EventType      string    `gorm:"column:event_type;size:100;index;default:'';not null;"` // 事件类型
+	Payload        string    `gorm:"column:payload;type:text;"`                             // 投递内容(JSON)
+	Status         int       `gorm:"column:status;index;default:0;not null;"`               // 投递状态(1:待投递 2:投递成功 3:死信)
+	Attempts       int       `gorm:"column:attempts;default:0;not null;"`                   // 投递次数
+	ResponseStatus int       `gorm:"column:response_status;default:0;not null;"`            // 最后一次响应状态码
+	ResponseBody   string    `gorm:"column:response_body;size:1024;default:'';not null;"`   // 最后一次响应内容
+	LastError      string    `gorm:"column:last_error;size:1024;default:'';not null;"`      // 最后一次投递错误
+	NextRetryAt    time.Time `gorm:"column:next_retry_at;index;"`                           // 下次投递时间
+}
+
+// TableName 表名
+func (a WebhookDelivery) TableName() string {
+	return a.Model.TableName("webhook_delivery")
+}
+
+// ToSchemaWebhookDelivery 转换为webhook投递记录对象
+func (a WebhookDelivery) ToSchemaWebhookDelivery() *schema.WebhookDelivery {
+	item := new(schema.WebhookDelivery)
+	util.StructMapToStruct(a, item)
+	item.Status = schema.WebhookDeliveryStatus(a.Status)
+	item.Payload = []byte(a.Payload)
+	return item
+}
+
+// WebhookDeliveries webhook投递记录实体列表
+type WebhookDeliveries []*WebhookDelivery
+
+// ToSchemaWebhookDeliveries 转换为webhook投递记录对象列表
+func (a WebhookDeliveries) ToSchemaWebhookDeliveries() []*schema.WebhookDelivery {
+	list := make([]*schema.WebhookDelivery, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaWebhookDelivery()
+	}
+	return list
+}
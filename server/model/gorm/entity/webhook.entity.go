@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+)
+
+// GetWebhookDB 获取webhook订阅存储
+func GetWebhookDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Webhook))
+}
+
+// SchemaWebhook webhook订阅对象
+type SchemaWebhook schema.Webhook
+
+// ToWebhook 转换为webhook订阅实体
+func (a SchemaWebhook) ToWebhook() *Webhook {
+	item := new(Webhook)
+	util.StructMapToStruct(a, item)
+	item.Events = strings.Join(a.Events, ",")
+	return item
+}
+
+// Webhook webhook订阅实体
+type Webhook struct {
+	Model
+	Name    string  `gorm:"column:name;size:100;index;default:'';not null;"` // 名称
+	URL     string  `gorm:"column:url;size:1024;default:'';not null;"`       // 投递地址
+	Secret  string  `gorm:"column:secret;size:255;default:'';not null;"`     // 签名密钥
+	Events  string  `gorm:"column:events;size:2048;default:'';not null;"`    // 订阅的事件类型(逗号分隔)
+	Status  int     `gorm:"column:status;index;default:0;not null;"`         // 状态(1:启用 2:禁用)
+	Memo    *string `gorm:"column:memo;size:1024;"`                          // 备注
+	Creator string  `gorm:"column:creator;size:36;"`                         // 创建者
+}
+
+// TableName 表名
+func (a Webhook) TableName() string {
+	return a.Model.TableName("webhook")
+}
+
+// ToSchemaWebhook 转换为webhook订阅对象
+func (a Webhook) ToSchemaWebhook() *schema.Webhook {
+	item := new(schema.Webhook)
+	util.StructMapToStruct(a, item)
+	item.Events = nil
+	if a.Events != "" {
+		item.Events = strings.Split(a.Events, ",")
+	}
+	return item
+}
+
+// Webhooks webhook订阅实体列表
+type Webhooks []*Webhook
+
+// ToSchemaWebhooks 转换为webhook订阅对象列表
+func (a Webhooks) ToSchemaWebhooks() []*schema.Webhook {
+	list := make([]*schema.Webhook, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaWebhook()
+	}
+	return list
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/key7men/mag/server/model/gorm/entity"
 
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 )
 
 // Config 配置参数
@@ -59,13 +61,18 @@ func AutoMigrate(db *gorm.DB) error {
 	}
 
 	return db.AutoMigrate(
+		new(entity.Audit),
 		new(entity.Demo),
 		new(entity.MenuAction),
 		new(entity.MenuActionResource),
 		new(entity.Menu),
+		new(entity.Outbox),
 		new(entity.RoleMenu),
 		new(entity.Role),
+		new(entity.Tenant),
 		new(entity.UserRole),
 		new(entity.User),
+		new(entity.WebhookDelivery),
+		new(entity.Webhook),
 	).Error
 }
@@ -0,0 +1,107 @@
+package gorm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/server/config"
+	igorm "github.com/key7men/mag/server/model/gorm"
+	"github.com/key7men/mag/server/model/gorm/dao"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"github.com/key7men/mag/server/model/modeltest"
+)
+
+func TestSqlite3(t *testing.T) {
+	modeltest.Run(t, func(t *testing.T) (*modeltest.Models, func()) {
+		dir, err := ioutil.TempDir("", "mag-modeltest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// sqlite仅允许单个写连接，限制连接数以避免事务内外的锁竞争
+		m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+		return m, func() {
+			cleanFunc()
+			_ = os.RemoveAll(dir)
+		}
+	})
+}
+
+// 设置环境变量 MAG_TEST_MYSQL_DSN 后运行(注意：会清空库中的数据表)
+func TestMySQL(t *testing.T) {
+	dsn := os.Getenv("MAG_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("MAG_TEST_MYSQL_DSN not set")
+	}
+	modeltest.Run(t, func(t *testing.T) (*modeltest.Models, func()) {
+		return newModels(t, "mysql", dsn, 10)
+	})
+}
+
+// 设置环境变量 MAG_TEST_POSTGRES_DSN 后运行(注意：会清空库中的数据表)
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("MAG_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("MAG_TEST_POSTGRES_DSN not set")
+	}
+	modeltest.Run(t, func(t *testing.T) (*modeltest.Models, func()) {
+		return newModels(t, "postgres", dsn, 10)
+	})
+}
+
+func newModels(t *testing.T, dbType, dsn string, maxOpenConns int) (*modeltest.Models, func()) {
+	config.C.Gorm.DBType = dbType
+	db, cleanFunc, err := igorm.NewDB(&igorm.Config{
+		DBType:       dbType,
+		DSN:          dsn,
+		MaxOpenConns: maxOpenConns,
+		MaxIdleConns: maxOpenConns,
+	})
+	if err != nil {
+		if cleanFunc != nil {
+			cleanFunc()
+		}
+		t.Fatal(err)
+	}
+
+	err = resetTables(db)
+	if err != nil {
+		cleanFunc()
+		t.Fatal(err)
+	}
+
+	return &modeltest.Models{
+		Trans:              &dao.Trans{DB: db},
+		Demo:               &dao.Demo{DB: db},
+		User:               &dao.User{DB: db},
+		UserRole:           &dao.UserRole{DB: db},
+		Role:               &dao.Role{DB: db},
+		RoleMenu:           &dao.RoleMenu{DB: db},
+		Menu:               &dao.Menu{DB: db},
+		MenuAction:         &dao.MenuAction{DB: db},
+		MenuActionResource: &dao.MenuActionResource{DB: db},
+		Tenant:             &dao.Tenant{DB: db},
+		Outbox:             &dao.Outbox{DB: db},
+	}, cleanFunc
+}
+
+func resetTables(db *gorm.DB) error {
+	err := db.DropTableIfExists(
+		new(entity.Demo),
+		new(entity.Outbox),
+		new(entity.MenuAction),
+		new(entity.MenuActionResource),
+		new(entity.Menu),
+		new(entity.RoleMenu),
+		new(entity.Role),
+		new(entity.Tenant),
+		new(entity.UserRole),
+		new(entity.User),
+	).Error
+	if err != nil {
+		return err
+	}
+	return igorm.AutoMigrate(db)
+}
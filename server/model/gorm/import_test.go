@@ -0,0 +1,123 @@
+package gorm_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/server/biz/impl"
+	egin "github.com/key7men/mag/server/enhance/gin"
+	"github.com/key7men/mag/server/model/gorm/dao"
+	"github.com/key7men/mag/server/module/tabular"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 导出的用户文件可重新导入(仅校验时不写入数据)
+func TestSqlite3UserImportRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, format := range []tabular.Format{tabular.CSV, tabular.XLSX} {
+		t.Run(string(format), func(t *testing.T) {
+			m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, string(format)+".db"), 1)
+			defer cleanFunc()
+
+			ctx := context.Background()
+			db := m.Trans.(*dao.Trans).DB
+			userBiz := &impl.User{
+				TransModel:    m.Trans,
+				UserModel:     m.User,
+				UserRoleModel: m.UserRole,
+				RoleModel:     m.Role,
+				AuditModel:    &dao.Audit{DB: db},
+				OutboxModel:   &dao.Outbox{DB: db},
+				TenantModel:   m.Tenant,
+			}
+			must(t, m.Role.Create(ctx, schema.Role{ID: "role-1", Name: "=staff", Status: 1}))
+
+			var buf bytes.Buffer
+			w, err := tabular.NewWriter(format, &buf)
+			must(t, err)
+			for _, record := range [][]string{
+				{"user_name", "real_name", "password", "phone", "roles"},
+				{"alice", "=1+1", "secret", "+8613800000000", "=staff"},
+				{"", ""},
+				{"bob", "@bob", "secret", "", "=staff"},
+			} {
+				must(t, w.Write(record))
+			}
+			must(t, w.Close())
+
+			importUsers := func(dryRun bool) *schema.ImportResult {
+				t.Helper()
+				records, err := parseImportFile(t, "users"+format.Ext(), buf.Bytes())
+				must(t, err)
+
+				rows := make([]*schema.UserImportRow, len(records))
+				for i, record := range records {
+					rows[i] = &schema.UserImportRow{
+						Row:      record.Row,
+						UserName: record.Get("user_name"),
+						RealName: record.Get("real_name"),
+						Password: record.Get("password"),
+						Phone:    record.Get("phone"),
+						Roles:    record.Get("roles"),
+					}
+				}
+				result, err := userBiz.Import(ctx, rows, dryRun)
+				must(t, err)
+				return result
+			}
+
+			result := importUsers(true)
+			if !result.DryRun || result.Applied || result.Created != 2 || result.Failed != 0 {
+				t.Fatalf("dry run: got %+v", result)
+			}
+			if result.Rows[1].Row != 4 {
+				t.Errorf("row of bob: got %d, want 4", result.Rows[1].Row)
+			}
+			users, err := m.User.Query(ctx, schema.UserQueryParam{})
+			must(t, err)
+			if len(users.Data) != 0 {
+				t.Fatalf("dry run created %d users", len(users.Data))
+			}
+
+			result = importUsers(false)
+			if !result.Applied || result.Created != 2 {
+				t.Fatalf("import: got %+v", result)
+			}
+			users, err = m.User.Query(ctx, schema.UserQueryParam{UserName: "alice"})
+			must(t, err)
+			if len(users.Data) != 1 || users.Data[0].RealName != "=1+1" || users.Data[0].Phone != "+8613800000000" {
+				t.Fatalf("imported user: got %+v", users.Data)
+			}
+		})
+	}
+}
+
+// 按上传文件的请求解析导入数据
+func parseImportFile(t *testing.T, filename string, data []byte) ([]*egin.ImportRecord, error) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", filename)
+	must(t, err)
+	_, err = fw.Write(data)
+	must(t, err)
+	must(t, mw.Close())
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/import", &body)
+	c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+	return egin.ParseImportFile(c, schema.UserImportRequiredColumns)
+}
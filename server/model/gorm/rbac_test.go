@@ -0,0 +1,72 @@
+package gorm_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/key7men/mag/server/biz/impl"
+	"github.com/key7men/mag/server/model/gorm/dao"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 事务重试时导入结果的差异项及计数不重复
+func TestSqlite3RBACImportRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-rbac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+
+	db := m.Trans.(*dao.Trans).DB
+	trans := retryTrans{m.Trans}
+	auditModel, outboxModel := &dao.Audit{DB: db}, &dao.Outbox{DB: db}
+	rbacBiz := &impl.RBAC{
+		TransModel:              trans,
+		MenuModel:               m.Menu,
+		MenuActionModel:         m.MenuAction,
+		MenuActionResourceModel: m.MenuActionResource,
+		RoleModel:               m.Role,
+		RoleMenuModel:           m.RoleMenu,
+		AuditModel:              auditModel,
+		OutboxModel:             outboxModel,
+		MenuBiz: &impl.Menu{
+			TransModel:              trans,
+			MenuModel:               m.Menu,
+			MenuActionModel:         m.MenuAction,
+			MenuActionResourceModel: m.MenuActionResource,
+			RoleMenuModel:           m.RoleMenu,
+			AuditModel:              auditModel,
+			OutboxModel:             outboxModel,
+		},
+		RoleBiz: &impl.Role{
+			TransModel:      trans,
+			RoleModel:       m.Role,
+			RoleMenuModel:   m.RoleMenu,
+			MenuModel:       m.Menu,
+			MenuActionModel: m.MenuAction,
+			UserModel:       m.User,
+			AuditModel:      auditModel,
+			OutboxModel:     outboxModel,
+		},
+	}
+
+	doc := &schema.RBACDocument{
+		Menus: schema.RBACMenus{
+			{Name: "系统管理", Sequence: 1, ShowStatus: 1, Status: 1},
+		},
+		Roles: schema.RBACRoles{
+			{Name: "管理员", Sequence: 1, Status: 1},
+		},
+	}
+	result, err := rbacBiz.Import(context.Background(), doc, schema.RBACImportParam{})
+	must(t, err)
+	if !result.Applied || result.Created != 2 || len(result.Changes) != 2 {
+		t.Fatalf("result: got %+v", result)
+	}
+}
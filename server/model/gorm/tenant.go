@@ -0,0 +1,28 @@
+package gorm
+
+import (
+	"github.com/jinzhu/gorm"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model/gorm/entity"
+)
+
+// 注册租户回调，创建按租户隔离的数据时填充上下文中的租户ID(查询、更新及删除的租户条件由entity.GetDBWithModel添加)
+func init() {
+	gorm.DefaultCallback.Create().Before("gorm:create").Register("tenant:before_create", setTenantID)
+}
+
+func setTenantID(scope *gorm.Scope) {
+	ctx, ok := entity.FromScopeContext(scope)
+	if !ok || !entity.IsTenantScoped(scope.Value) {
+		return
+	}
+
+	tenantID, ok := icontext.FromTenantID(ctx)
+	if !ok {
+		return
+	}
+
+	if field, ok := scope.FieldByName("TenantID"); ok && field.IsBlank {
+		_ = field.Set(tenantID)
+	}
+}
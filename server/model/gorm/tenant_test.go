@@ -0,0 +1,160 @@
+package gorm_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/key7men/mag/server/biz/impl"
+	"github.com/key7men/mag/server/config"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/module/rbac"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 按租户加载的casbin策略(sub, dom, obj, act)仅在所属租户内生效
+func TestSqlite3CasbinTenantDomain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-casbin-tenant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+
+	ctx := context.Background()
+	ctxA := icontext.NewTenantID(ctx, "tenant-a")
+	ctxB := icontext.NewTenantID(ctx, "tenant-b")
+
+	// 全局的菜单动作及资源
+	for _, item := range []struct {
+		actionID, method, path string
+	}{
+		{"action-users", "GET", "/api/v1/users"},
+		{"action-roles", "POST", "/api/v1/roles"},
+	} {
+		must(t, m.MenuAction.Create(ctx, schema.MenuAction{ID: item.actionID, MenuID: "menu", Code: item.actionID, Name: item.actionID}))
+		must(t, m.MenuActionResource.Create(ctx, schema.MenuActionResource{ID: item.actionID, ActionID: item.actionID, Method: item.method, Path: item.path}))
+	}
+
+	// 租户a的用户可查询用户，租户b的用户可创建角色，租户a的用户同时关联了租户b的角色
+	for _, item := range []struct {
+		ctx                      context.Context
+		userID, roleID, actionID string
+	}{
+		{ctxA, "user-a", "role-a", "action-users"},
+		{ctxB, "user-b", "role-b", "action-roles"},
+	} {
+		must(t, m.Role.Create(item.ctx, schema.Role{ID: item.roleID, Name: "role", Status: 1}))
+		must(t, m.RoleMenu.Create(item.ctx, schema.RoleMenu{ID: item.roleID, RoleID: item.roleID, MenuID: "menu", ActionID: item.actionID}))
+		must(t, m.User.Create(item.ctx, schema.User{ID: item.userID, UserName: "user", RealName: "user", Password: "secret", Status: 1}))
+		must(t, m.UserRole.Create(item.ctx, schema.UserRole{ID: item.userID, UserID: item.userID, RoleID: item.roleID}))
+	}
+	must(t, m.UserRole.Create(ctxA, schema.UserRole{ID: "user-a-role-b", UserID: "user-a", RoleID: "role-b"}))
+
+	// 租户a的管理员拥有租户a的全部权限，租户b的管理员已停用，租户c的管理员已删除
+	must(t, m.User.Create(ctxA, schema.User{ID: "admin-a", UserName: "admin", RealName: "admin", Password: "secret", Status: 1}))
+	must(t, m.User.Create(ctxB, schema.User{ID: "admin-b", UserName: "admin", RealName: "admin", Password: "secret", Status: 2}))
+	must(t, m.Tenant.Create(ctx, schema.Tenant{ID: "tenant-a", Code: "a", Name: "a", Status: 1, AdminID: "admin-a"}))
+	must(t, m.Tenant.Create(ctx, schema.Tenant{ID: "tenant-b", Code: "b", Name: "b", Status: 1, AdminID: "admin-b"}))
+	must(t, m.Tenant.Create(ctx, schema.Tenant{ID: "tenant-c", Code: "c", Name: "c", Status: 1, AdminID: "admin-c"}))
+
+	e, err := casbin.NewSyncedEnforcer(filepath.Join("..", "..", "..", "conf", "casbin.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	must(t, e.InitWithModelAndAdapter(e.GetModel(), &rbac.CasbinAdapter{
+		RoleModel:         m.Role,
+		RoleMenuModel:     m.RoleMenu,
+		MenuResourceModel: m.MenuActionResource,
+		UserModel:         m.User,
+		UserRoleModel:     m.UserRole,
+		TenantModel:       m.Tenant,
+	}))
+
+	for _, tc := range []struct {
+		sub, dom, obj, act string
+		want               bool
+	}{
+		{"user-a", "tenant-a", "/api/v1/users", "GET", true},
+		{"user-a", "tenant-b", "/api/v1/users", "GET", false},
+		{"user-a", "", "/api/v1/users", "GET", false},
+		{"user-a", "tenant-a", "/api/v1/roles", "POST", false},
+		{"user-a", "tenant-b", "/api/v1/roles", "POST", false},
+		{"user-b", "tenant-b", "/api/v1/roles", "POST", true},
+		{"user-b", "tenant-b", "/api/v1/users", "GET", false},
+		{"user-b", "tenant-a", "/api/v1/roles", "POST", false},
+		{"admin-a", "tenant-a", "/api/v1/roles/1", "DELETE", true},
+		{"admin-a", "tenant-b", "/api/v1/roles/1", "DELETE", false},
+		{"admin-a", "", "/api/v1/tenants", "GET", false},
+		{"admin-b", "tenant-b", "/api/v1/roles/1", "DELETE", false},
+		{"admin-c", "tenant-c", "/api/v1/roles/1", "DELETE", false},
+	} {
+		got, err := e.Enforce(tc.sub, tc.dom, tc.obj, tc.act)
+		must(t, err)
+		if got != tc.want {
+			t.Errorf("Enforce(%s, %s, %s, %s): got %v, want %v", tc.sub, tc.dom, tc.obj, tc.act, got, tc.want)
+		}
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+// 按Host识别租户：绑定的域名及上级域名下的租户编号，未识别的结果不缓存
+func TestSqlite3TenantResolve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-tenant-resolve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, cleanFunc := newModels(t, "sqlite3", filepath.Join(dir, "data.db"), 1)
+	defer cleanFunc()
+
+	domain := config.C.Tenant.Domain
+	config.C.Tenant.Domain = "example.com"
+	defer func() { config.C.Tenant.Domain = domain }()
+
+	ctx := context.Background()
+	must(t, m.Tenant.Create(ctx, schema.Tenant{ID: "tenant-a", Code: "a", Name: "a", Domain: "a.test", Status: 1}))
+	tenantBiz := &impl.Tenant{TenantModel: m.Tenant}
+
+	resolve := func(host string) string {
+		t.Helper()
+		item, err := tenantBiz.Resolve(ctx, host)
+		must(t, err)
+		if item == nil {
+			return ""
+		}
+		return item.ID
+	}
+
+	for _, tc := range []struct {
+		host, want string
+	}{
+		{"a.test:8000", "tenant-a"},
+		{"a.example.com", "tenant-a"},
+		{"b.example.com", ""},
+		{"b.test", ""},
+		{"example.com", ""},
+	} {
+		if got := resolve(tc.host); got != tc.want {
+			t.Errorf("Resolve(%s): got %q, want %q", tc.host, got, tc.want)
+		}
+	}
+
+	// 未识别的租户创建后可立即识别
+	must(t, m.Tenant.Create(ctx, schema.Tenant{ID: "tenant-b", Code: "b", Name: "b", Status: 1}))
+	if got := resolve("b.example.com"); got != "tenant-b" {
+		t.Errorf("Resolve(b.example.com) after create: got %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+package gorm
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/pkg/trace"
+	"github.com/key7men/mag/server/model/gorm/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// 跟踪单元在gorm作用域中的键名
+const scopeSpanKey = "mag:span"
+
+// 注册链路跟踪回调，每条SQL语句创建一个跟踪单元(仅在上下文中存在记录中的跟踪单元时生效)
+func init() {
+	cb := gorm.DefaultCallback
+	cb.Create().Before("gorm:create").Register("trace:before_create", beforeTrace("create"))
+	cb.Create().After("gorm:create").Register("trace:after_create", afterTrace)
+	cb.Query().Before("gorm:query").Register("trace:before_query", beforeTrace("query"))
+	cb.Query().After("gorm:query").Register("trace:after_query", afterTrace)
+	cb.Update().Before("gorm:update").Register("trace:before_update", beforeTrace("update"))
+	cb.Update().After("gorm:update").Register("trace:after_update", afterTrace)
+	cb.Delete().Before("gorm:delete").Register("trace:before_delete", beforeTrace("delete"))
+	cb.Delete().After("gorm:delete").Register("trace:after_delete", afterTrace)
+	cb.RowQuery().Before("gorm:row_query").Register("trace:before_row_query", beforeTrace("row_query"))
+	cb.RowQuery().After("gorm:row_query").Register("trace:after_row_query", afterTrace)
+}
+
+func dbSystem(dialect string) attribute.KeyValue {
+	switch dialect {
+	case "mysql":
+		return semconv.DBSystemMySQL
+	case "postgres":
+		return semconv.DBSystemPostgreSQL
+	case "sqlite3":
+		return semconv.DBSystemSqlite
+	}
+	return semconv.DBSystemKey.String(dialect)
+}
+
+func beforeTrace(operation string) func(*gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		ctx, ok := entity.FromScopeContext(scope)
+		if !ok || !trace.SpanFromContext(ctx).IsRecording() {
+			return
+		}
+
+		_, span := trace.StartSpan(ctx, "gorm."+operation,
+			oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+			oteltrace.WithAttributes(
+				dbSystem(scope.Dialect().GetName()),
+				semconv.DBOperationKey.String(operation),
+				semconv.DBSQLTableKey.String(scope.TableName()),
+			))
+		scope.Set(scopeSpanKey, span)
+	}
+}
+
+func afterTrace(scope *gorm.Scope) {
+	v, ok := scope.Get(scopeSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(oteltrace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBStatementKey.String(scope.SQL),
+		attribute.Int64("db.rows_affected", scope.DB().RowsAffected),
+	)
+	if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
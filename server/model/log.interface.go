@@ -0,0 +1,15 @@
+package model
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// ILog 系统日志存储(读取日志钩子写入的数据)
+type ILog interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.LogQueryParam, opts ...schema.LogQueryOptions) (*schema.LogQueryResult, error)
+	// 删除指定数据
+	DeleteByIDs(ctx context.Context, ids []string) error
+}
@@ -0,0 +1,302 @@
+package modeltest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// 创建n条示例数据，编号依次为D000、D001...，偶数行启用、奇数行停用
+func createDemos(t *testing.T, m *Models, n int) {
+	t.Helper()
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		status := 1
+		if i%2 == 1 {
+			status = 2
+		}
+		must(t, m.Demo.Create(ctx, schema.Demo{
+			ID:     newID("demo", i),
+			Code:   fmt.Sprintf("D%03d", i),
+			Name:   fmt.Sprintf("name%d", i),
+			Memo:   fmt.Sprintf("memo%d", i),
+			Status: status,
+		}))
+	}
+}
+
+func demoCodes(list []*schema.Demo) string {
+	codes := make([]string, len(list))
+	for i, item := range list {
+		codes[i] = item.Code
+	}
+	return strings.Join(codes, ",")
+}
+
+func codeRange(from, to int) string {
+	var codes []string
+	for i := from; i < to; i++ {
+		codes = append(codes, fmt.Sprintf("D%03d", i))
+	}
+	return strings.Join(codes, ",")
+}
+
+func orderByCode(d schema.OrderDirection) schema.DemoQueryOptions {
+	return schema.DemoQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("code", d)),
+	}
+}
+
+func testCRUD(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 1)
+	id := newID("demo", 0)
+
+	item, err := m.Demo.Get(ctx, id)
+	must(t, err)
+	if item == nil {
+		t.Fatalf("created item %s not found", id)
+	}
+	expect(t, "code", item.Code, "D000")
+	expect(t, "name", item.Name, "name0")
+	expect(t, "status", item.Status, 1)
+	if item.CreatedAt.IsZero() || item.UpdatedAt.IsZero() {
+		t.Fatalf("timestamps not set: %+v", item)
+	}
+
+	// 字符串字段按传入值覆盖(包括空值)，数值零值字段保持不变
+	must(t, m.Demo.Update(ctx, id, schema.Demo{Code: "D000", Name: "renamed"}))
+	item, err = m.Demo.Get(ctx, id)
+	must(t, err)
+	expect(t, "updated name", item.Name, "renamed")
+	expect(t, "cleared memo", item.Memo, "")
+	expect(t, "unchanged status", item.Status, 1)
+
+	must(t, m.Demo.UpdateStatus(ctx, id, 2))
+	item, err = m.Demo.Get(ctx, id)
+	must(t, err)
+	expect(t, "updated status", item.Status, 2)
+
+	item, err = m.Demo.Get(ctx, "not-exists")
+	must(t, err)
+	if item != nil {
+		t.Fatalf("expected nil for missing item, got %+v", item)
+	}
+}
+
+func testSoftDelete(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 3)
+	id := newID("demo", 1)
+
+	must(t, m.Demo.Delete(ctx, id))
+	item, err := m.Demo.Get(ctx, id)
+	must(t, err)
+	if item != nil {
+		t.Fatalf("deleted item still visible: %+v", item)
+	}
+
+	result, err := m.Demo.Query(ctx, schema.DemoQueryParam{}, orderByCode(schema.OrderByASC))
+	must(t, err)
+	expect(t, "codes", demoCodes(result.Data), "D000,D002")
+
+	result, err = m.Demo.Query(ctx, schema.DemoQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+	})
+	must(t, err)
+	expect(t, "count", result.PageResult.Total, 2)
+
+	// 已删除数据不可被更新恢复
+	must(t, m.Demo.UpdateStatus(ctx, id, 1))
+	item, err = m.Demo.Get(ctx, id)
+	must(t, err)
+	if item != nil {
+		t.Fatalf("deleted item restored by update: %+v", item)
+	}
+
+	// 重复删除不报错
+	must(t, m.Demo.Delete(ctx, id))
+}
+
+func testPagination(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 25)
+	opt := orderByCode(schema.OrderByASC)
+
+	for _, c := range []struct {
+		current uint
+		codes   string
+	}{
+		{1, codeRange(0, 10)},
+		{2, codeRange(10, 20)},
+		{3, codeRange(20, 25)},
+		{4, ""},
+	} {
+		result, err := m.Demo.Query(ctx, schema.DemoQueryParam{
+			PaginationParam: schema.PaginationParam{Pagination: true, Current: c.current, PageSize: 10},
+		}, opt)
+		must(t, err)
+		expect(t, fmt.Sprintf("page %d codes", c.current), demoCodes(result.Data), c.codes)
+		expect(t, fmt.Sprintf("page %d total", c.current), result.PageResult.Total, 25)
+		expect(t, fmt.Sprintf("page %d current", c.current), result.PageResult.Current, c.current)
+		expect(t, fmt.Sprintf("page %d size", c.current), result.PageResult.PageSize, 10)
+	}
+
+	result, err := m.Demo.Query(ctx, schema.DemoQueryParam{
+		PaginationParam: schema.PaginationParam{Pagination: true, Current: 1, PageSize: 10, NoCount: true},
+	}, opt)
+	must(t, err)
+	expect(t, "no count total", result.PageResult.Total, 0)
+	expect(t, "no count codes", demoCodes(result.Data), codeRange(0, 10))
+
+	result, err = m.Demo.Query(ctx, schema.DemoQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+	})
+	must(t, err)
+	expect(t, "only count total", result.PageResult.Total, 25)
+	expect(t, "only count data", len(result.Data), 0)
+
+	result, err = m.Demo.Query(ctx, schema.DemoQueryParam{}, opt)
+	must(t, err)
+	if result.PageResult != nil {
+		t.Fatalf("unexpected page result without pagination: %+v", result.PageResult)
+	}
+	expect(t, "all codes", demoCodes(result.Data), codeRange(0, 25))
+}
+
+func testCursorPagination(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 25)
+	opt := orderByCode(schema.OrderByASC)
+
+	query := func(cursor string) *schema.DemoQueryResult {
+		t.Helper()
+		result, err := m.Demo.Query(ctx, schema.DemoQueryParam{
+			PaginationParam: schema.PaginationParam{Pagination: true, UseCursor: true, Cursor: cursor, PageSize: 10},
+		}, opt)
+		must(t, err)
+		return result
+	}
+
+	page1 := query("")
+	expect(t, "page 1 codes", demoCodes(page1.Data), codeRange(0, 10))
+	expect(t, "page 1 total", page1.PageResult.Total, 25)
+	expect(t, "page 1 prev cursor", page1.PageResult.PrevCursor, "")
+	if page1.PageResult.NextCursor == "" {
+		t.Fatal("page 1 missing next cursor")
+	}
+
+	page2 := query(page1.PageResult.NextCursor)
+	expect(t, "page 2 codes", demoCodes(page2.Data), codeRange(10, 20))
+
+	page3 := query(page2.PageResult.NextCursor)
+	expect(t, "page 3 codes", demoCodes(page3.Data), codeRange(20, 25))
+	expect(t, "page 3 next cursor", page3.PageResult.NextCursor, "")
+
+	back := query(page3.PageResult.PrevCursor)
+	expect(t, "back to page 2 codes", demoCodes(back.Data), codeRange(10, 20))
+
+	back = query(back.PageResult.PrevCursor)
+	expect(t, "back to page 1 codes", demoCodes(back.Data), codeRange(0, 10))
+	expect(t, "back to page 1 prev cursor", back.PageResult.PrevCursor, "")
+
+	_, err := m.Demo.Query(ctx, schema.DemoQueryParam{
+		PaginationParam: schema.PaginationParam{Pagination: true, Cursor: "invalid", PageSize: 10},
+	}, opt)
+	if err == nil {
+		t.Fatal("expected error for invalid cursor")
+	}
+
+	// 游标与查询的排序规则不一致时无效
+	_, err = m.Demo.Query(ctx, schema.DemoQueryParam{
+		PaginationParam: schema.PaginationParam{Pagination: true, Cursor: page1.PageResult.NextCursor, PageSize: 10},
+	}, orderByCode(schema.OrderByDESC))
+	if err == nil {
+		t.Fatal("expected error for cursor of another order")
+	}
+}
+
+func testOrdering(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 5)
+
+	result, err := m.Demo.Query(ctx, schema.DemoQueryParam{}, orderByCode(schema.OrderByDESC))
+	must(t, err)
+	expect(t, "code desc", demoCodes(result.Data), "D004,D003,D002,D001,D000")
+
+	result, err = m.Demo.Query(ctx, schema.DemoQueryParam{}, schema.DemoQueryOptions{
+		OrderFields: schema.NewOrderFields(
+			schema.NewOrderField("status", schema.OrderByDESC),
+			schema.NewOrderField("code", schema.OrderByASC),
+		),
+	})
+	must(t, err)
+	expect(t, "status desc, code asc", demoCodes(result.Data), "D001,D003,D000,D002,D004")
+
+	// 未指定排序时，按ID降序排列
+	result, err = m.Demo.Query(ctx, schema.DemoQueryParam{})
+	must(t, err)
+	expect(t, "default order", demoCodes(result.Data), "D004,D003,D002,D001,D000")
+}
+
+func testFilters(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 25)
+	opt := orderByCode(schema.OrderByASC)
+
+	filter := func(key string, op schema.FilterOperator, values ...string) *schema.FilterField {
+		return &schema.FilterField{Key: key, Operator: op, Values: values}
+	}
+
+	for _, c := range []struct {
+		name    string
+		filters []*schema.FilterField
+		codes   string
+	}{
+		{"eq", []*schema.FilterField{filter("code", schema.FilterEQ, "D003")}, "D003"},
+		{"ne", []*schema.FilterField{filter("code", schema.FilterNE, "D003"), filter("code", schema.FilterLT, "D005")}, "D000,D001,D002,D004"},
+		{"gt", []*schema.FilterField{filter("code", schema.FilterGT, "D020")}, codeRange(21, 25)},
+		{"gte", []*schema.FilterField{filter("code", schema.FilterGTE, "D020")}, codeRange(20, 25)},
+		{"lte", []*schema.FilterField{filter("code", schema.FilterLTE, "D002")}, codeRange(0, 3)},
+		{"like", []*schema.FilterField{filter("code", schema.FilterLike, "D01")}, codeRange(10, 20)},
+		{"in", []*schema.FilterField{filter("code", schema.FilterIn, "D001", "D005", "D999")}, "D001,D005"},
+		{"int", []*schema.FilterField{filter("status", schema.FilterEQ, "1"), filter("code", schema.FilterLT, "D010")}, "D000,D002,D004,D006,D008"},
+		{"empty", []*schema.FilterField{filter("code", schema.FilterEQ)}, codeRange(0, 25)},
+	} {
+		result, err := m.Demo.Query(ctx, schema.DemoQueryParam{Filters: c.filters}, opt)
+		must(t, err)
+		expect(t, c.name, demoCodes(result.Data), c.codes)
+	}
+
+	result, err := m.Demo.Query(ctx, schema.DemoQueryParam{Code: "D007"})
+	must(t, err)
+	expect(t, "code param", demoCodes(result.Data), "D007")
+
+	result, err = m.Demo.Query(ctx, schema.DemoQueryParam{QueryValue: "name2"}, opt)
+	must(t, err)
+	expect(t, "query value", demoCodes(result.Data), "D002,D020,D021,D022,D023,D024")
+
+	// 非法的列名及操作符(绕过白名单直接构造时)被拒绝
+	for _, c := range []struct {
+		name   string
+		filter *schema.FilterField
+	}{
+		{"injected column", filter("code;drop", schema.FilterEQ, "D001")},
+		{"quoted column", filter("`code`", schema.FilterEQ, "D001")},
+		{"qualified column", filter("demo.code", schema.FilterEQ, "D001")},
+		{"upper case column", filter("Code", schema.FilterEQ, "D001")},
+		{"empty column", filter("", schema.FilterEQ, "D001")},
+		{"unknown column", filter("missing", schema.FilterEQ, "D001")},
+		{"unknown operator", filter("code", "regexp", "D001")},
+		{"upper case operator", filter("code", "EQ", "D001")},
+		{"empty operator", filter("code", "", "D001")},
+	} {
+		_, err := m.Demo.Query(ctx, schema.DemoQueryParam{Filters: []*schema.FilterField{c.filter}})
+		if err == nil {
+			t.Fatalf("%s: expected error for invalid filter %+v", c.name, c.filter)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package modeltest 存储接口一致性测试套件，任意存储实现均可接入以验证其行为契约
+package modeltest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/key7men/mag/server/model"
+)
+
+// Models 待验证的存储实现
+type Models struct {
+	Trans              model.ITrans
+	Demo               model.IDemo
+	User               model.IUser
+	UserRole           model.IUserRole
+	Role               model.IRole
+	RoleMenu           model.IRoleMenu
+	Menu               model.IMenu
+	MenuAction         model.IMenuAction
+	MenuActionResource model.IMenuActionResource
+	Tenant             model.ITenant
+	Outbox             model.IOutbox
+}
+
+// Factory 为每个测试用例创建一组基于空存储的实现，返回的清理函数在用例结束后调用
+type Factory func(t *testing.T) (*Models, func())
+
+type testCase struct {
+	name string
+	fn   func(t *testing.T, m *Models)
+}
+
+var testCases = []testCase{
+	{"CRUD", testCRUD},
+	{"SoftDelete", testSoftDelete},
+	{"Pagination", testPagination},
+	{"CursorPagination", testCursorPagination},
+	{"Ordering", testOrdering},
+	{"Filters", testFilters},
+	{"TransCommit", testTransCommit},
+	{"TransRollback", testTransRollback},
+	{"TransNested", testTransNested},
+	{"UserRole", testUserRole},
+	{"RoleMenu", testRoleMenu},
+	{"Menu", testMenu},
+	{"Tenant", testTenant},
+	{"TenantScope", testTenantScope},
+	{"Outbox", testOutbox},
+}
+
+// Run 对存储实现执行一致性测试
+func Run(t *testing.T, factory Factory) {
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			m, cleanFunc := factory(t)
+			if cleanFunc != nil {
+				defer cleanFunc()
+			}
+			tc.fn(t, m)
+		})
+	}
+}
+
+func newID(prefix string, i int) string {
+	return fmt.Sprintf("%s-%03d", prefix, i)
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func expect(t *testing.T, name string, got, want interface{}) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("%s: got %v, want %v", name, got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package modeltest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 投递时计数的投递目标(前failures次投递返回错误)
+type countSink struct {
+	name     string
+	failures int
+	calls    int
+}
+
+func (a *countSink) Name() string {
+	return a.name
+}
+
+func (a *countSink) Send(ctx context.Context, e *schema.Event) error {
+	a.calls++
+	if a.calls <= a.failures {
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+func testOutbox(t *testing.T, m *Models) {
+	ctx := icontext.NewAllTenants(context.Background())
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		must(t, m.Outbox.Create(ctx, schema.Event{
+			ID:          newID("event", i),
+			Type:        schema.EventDemoCreated,
+			AggregateID: newID("demo", i),
+			Payload:     []byte("{}"),
+			Status:      schema.EventPending,
+			NextRetryAt: now.Add(-time.Second),
+		}))
+	}
+
+	// 同一事件在租约到期前只能领取一次
+	claim := func(now time.Time) bool {
+		t.Helper()
+		ok, err := m.Outbox.Claim(ctx, newID("event", 0), now, now.Add(time.Minute))
+		must(t, err)
+		return ok
+	}
+	expect(t, "first claim", claim(now), true)
+	expect(t, "second claim", claim(now), false)
+	expect(t, "claim after lease", claim(now.Add(2*time.Minute)), true)
+
+	// 投递失败时仅重新投递到失败的目标
+	okSink := &countSink{name: "ok"}
+	failSink := &countSink{name: "fail", failures: 1}
+	d := event.NewDispatcher(m.Outbox, event.Options{
+		RetryInterval:    time.Millisecond,
+		MaxRetryInterval: time.Millisecond,
+	}, okSink, failSink)
+
+	for i := 0; i < 2; i++ {
+		_, err := d.Dispatch(ctx)
+		must(t, err)
+		time.Sleep(20 * time.Millisecond)
+	}
+	expect(t, "ok sink calls", okSink.calls, 1)
+	expect(t, "fail sink calls", failSink.calls, 2)
+
+	result, err := m.Outbox.Query(ctx, schema.EventQueryParam{Status: schema.EventDispatched})
+	must(t, err)
+	expect(t, "dispatched", len(result.Data), 1)
+	expect(t, "dispatched event", result.Data[0].ID, newID("event", 1))
+	expect(t, "sent sinks", result.Data[0].SentSinks, []string{"ok", "fail"})
+	expect(t, "attempts", result.Data[0].Attempts, 2)
+}
@@ -0,0 +1,215 @@
+package modeltest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/schema"
+)
+
+func sortedIDs(ids []string) string {
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func testUserRole(t *testing.T, m *Models) {
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		must(t, m.User.Create(ctx, schema.User{
+			ID:       newID("user", i),
+			UserName: newID("name", i),
+			RealName: newID("real", i),
+			Password: "secret",
+			Status:   1,
+		}))
+		must(t, m.Role.Create(ctx, schema.Role{
+			ID:       newID("role", i),
+			Name:     newID("role", i),
+			Sequence: i,
+			Status:   1,
+		}))
+	}
+	for i, pair := range [][2]int{{0, 0}, {0, 1}, {1, 1}} {
+		must(t, m.UserRole.Create(ctx, schema.UserRole{
+			ID:     newID("user-role", i),
+			UserID: newID("user", pair[0]),
+			RoleID: newID("role", pair[1]),
+		}))
+	}
+
+	userIDs := func(roleIDs ...string) string {
+		t.Helper()
+		result, err := m.User.Query(ctx, schema.UserQueryParam{RoleIDs: roleIDs})
+		must(t, err)
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return sortedIDs(ids)
+	}
+	roleIDs := func(userID string) string {
+		t.Helper()
+		result, err := m.Role.Query(ctx, schema.RoleQueryParam{UserID: userID})
+		must(t, err)
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return sortedIDs(ids)
+	}
+
+	expect(t, "users of role 0", userIDs(newID("role", 0)), "user-000")
+	expect(t, "users of role 1", userIDs(newID("role", 1)), "user-000,user-001")
+	expect(t, "roles of user 0", roleIDs(newID("user", 0)), "role-000,role-001")
+
+	result, err := m.UserRole.Query(ctx, schema.UserRoleQueryParam{UserIDs: []string{newID("user", 0), newID("user", 1)}})
+	must(t, err)
+	expect(t, "user roles", len(result.Data), 3)
+
+	// 删除关联后，关联查询不再返回已删除的记录
+	must(t, m.UserRole.DeleteByUserID(ctx, newID("user", 0)))
+	expect(t, "users of role 1 after delete", userIDs(newID("role", 1)), "user-001")
+	expect(t, "roles of user 0 after delete", roleIDs(newID("user", 0)), "")
+
+	must(t, m.User.UpdatePassword(ctx, newID("user", 1), "changed"))
+	user, err := m.User.Get(ctx, newID("user", 1))
+	must(t, err)
+	expect(t, "password", user.Password, "changed")
+
+	users, err := m.User.Query(ctx, schema.UserQueryParam{UserName: newID("name", 1)})
+	must(t, err)
+	expect(t, "user by name", len(users.Data), 1)
+}
+
+func testRoleMenu(t *testing.T, m *Models) {
+	ctx := context.Background()
+	for i, roleID := range []string{"role-000", "role-000", "role-001"} {
+		must(t, m.RoleMenu.Create(ctx, schema.RoleMenu{
+			ID:       newID("role-menu", i),
+			RoleID:   roleID,
+			MenuID:   newID("menu", i),
+			ActionID: newID("action", i),
+		}))
+	}
+
+	countIn := func(ctx context.Context, params schema.RoleMenuQueryParam) int {
+		t.Helper()
+		result, err := m.RoleMenu.Query(ctx, params)
+		must(t, err)
+		return len(result.Data)
+	}
+	count := func(params schema.RoleMenuQueryParam) int {
+		t.Helper()
+		return countIn(ctx, params)
+	}
+
+	expect(t, "menus of role 0", count(schema.RoleMenuQueryParam{RoleID: "role-000"}), 2)
+	expect(t, "menus of roles", count(schema.RoleMenuQueryParam{RoleIDs: []string{"role-000", "role-001"}}), 3)
+
+	must(t, m.RoleMenu.DeleteByRoleID(ctx, "role-000"))
+	expect(t, "menus of role 0 after delete", count(schema.RoleMenuQueryParam{RoleID: "role-000"}), 0)
+	expect(t, "menus of role 1 after delete", count(schema.RoleMenuQueryParam{RoleID: "role-001"}), 1)
+
+	// 按菜单及动作删除授权(跨租户删除需显式指定)
+	ctxA := icontext.NewTenantID(ctx, "tenant-a")
+	ctxAll := icontext.NewAllTenants(ctx)
+	for i, tctx := range []context.Context{ctxA, icontext.NewTenantID(ctx, "tenant-b")} {
+		for j, actionID := range []string{"action-010", "action-011"} {
+			must(t, m.RoleMenu.Create(tctx, schema.RoleMenu{
+				ID:       newID("role-menu", 10+i*2+j),
+				RoleID:   newID("role", 10+i),
+				MenuID:   "menu-010",
+				ActionID: actionID,
+			}))
+		}
+	}
+
+	must(t, m.RoleMenu.DeleteByActionID(ctxA, "action-011"))
+	expect(t, "grants of action 11 after delete in tenant a", countIn(ctxAll, schema.RoleMenuQueryParam{RoleIDs: []string{"role-010", "role-011"}}), 3)
+	must(t, m.RoleMenu.DeleteByActionID(ctxAll, "action-011"))
+	expect(t, "grants of action 11 after delete in all tenants", countIn(ctxAll, schema.RoleMenuQueryParam{RoleIDs: []string{"role-010", "role-011"}}), 2)
+	must(t, m.RoleMenu.DeleteByMenuID(ctxAll, "menu-010"))
+	expect(t, "grants of menu 10 after delete", countIn(ctxAll, schema.RoleMenuQueryParam{RoleIDs: []string{"role-010", "role-011"}}), 0)
+	expect(t, "menus of role 1 after delete by menu", count(schema.RoleMenuQueryParam{RoleID: "role-001"}), 1)
+}
+
+func testMenu(t *testing.T, m *Models) {
+	ctx := context.Background()
+	for _, item := range []schema.Menu{
+		{ID: "menu-000", Name: "root", Sequence: 3, ShowStatus: 1, Status: 1},
+		{ID: "menu-001", Name: "child", Sequence: 2, ParentID: "menu-000", ParentPath: "menu-000", ShowStatus: 1, Status: 1},
+		{ID: "menu-002", Name: "grandchild", Sequence: 1, ParentID: "menu-001", ParentPath: "menu-000/menu-001", ShowStatus: 2, Status: 1},
+	} {
+		must(t, m.Menu.Create(ctx, item))
+	}
+
+	menuIDs := func(params schema.MenuQueryParam) string {
+		t.Helper()
+		result, err := m.Menu.Query(ctx, params)
+		must(t, err)
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return sortedIDs(ids)
+	}
+
+	rootID := "menu-000"
+	emptyID := ""
+	expect(t, "top menus", menuIDs(schema.MenuQueryParam{ParentID: &emptyID}), "menu-000")
+	expect(t, "children", menuIDs(schema.MenuQueryParam{ParentID: &rootID}), "menu-001")
+	expect(t, "descendants", menuIDs(schema.MenuQueryParam{PrefixParentPath: "menu-000"}), "menu-001,menu-002")
+	expect(t, "shown", menuIDs(schema.MenuQueryParam{ShowStatus: 1}), "menu-000,menu-001")
+	expect(t, "ids", menuIDs(schema.MenuQueryParam{IDs: []string{"menu-000", "menu-002"}}), "menu-000,menu-002")
+
+	must(t, m.Menu.UpdateParentPath(ctx, "menu-002", "menu-001"))
+	expect(t, "descendants after move", menuIDs(schema.MenuQueryParam{PrefixParentPath: "menu-000"}), "menu-001")
+
+	must(t, m.Menu.UpdateStatus(ctx, "menu-001", 2))
+	expect(t, "enabled", menuIDs(schema.MenuQueryParam{Status: 1}), "menu-000,menu-002")
+
+	// 动作及资源按菜单关联查询
+	for i, menuID := range []string{"menu-000", "menu-001"} {
+		must(t, m.MenuAction.Create(ctx, schema.MenuAction{
+			ID:     newID("action", i),
+			MenuID: menuID,
+			Code:   "query",
+			Name:   "查询",
+		}))
+		for j, method := range []string{"GET", "POST"} {
+			must(t, m.MenuActionResource.Create(ctx, schema.MenuActionResource{
+				ID:       newID("resource", i*2+j),
+				ActionID: newID("action", i),
+				Method:   method,
+				Path:     "/api/v1/" + menuID,
+			}))
+		}
+	}
+
+	resourceCount := func(params schema.MenuActionResourceQueryParam) int {
+		t.Helper()
+		result, err := m.MenuActionResource.Query(ctx, params)
+		must(t, err)
+		return len(result.Data)
+	}
+
+	actions, err := m.MenuAction.Query(ctx, schema.MenuActionQueryParam{MenuID: "menu-000"})
+	must(t, err)
+	expect(t, "actions of menu 0", len(actions.Data), 1)
+	expect(t, "resources of menu 0", resourceCount(schema.MenuActionResourceQueryParam{MenuID: "menu-000"}), 2)
+	expect(t, "resources of menus", resourceCount(schema.MenuActionResourceQueryParam{MenuIDs: []string{"menu-000", "menu-001"}}), 4)
+
+	must(t, m.MenuActionResource.DeleteByMenuID(ctx, "menu-000"))
+	must(t, m.MenuAction.DeleteByMenuID(ctx, "menu-000"))
+	actions, err = m.MenuAction.Query(ctx, schema.MenuActionQueryParam{MenuID: "menu-000"})
+	must(t, err)
+	expect(t, "actions of menu 0 after delete", len(actions.Data), 0)
+	expect(t, "resources of menu 1 after delete", resourceCount(schema.MenuActionResourceQueryParam{MenuID: "menu-001"}), 2)
+	expect(t, "resources of menus after delete", resourceCount(schema.MenuActionResourceQueryParam{MenuIDs: []string{"menu-000", "menu-001"}}), 2)
+
+	must(t, m.MenuActionResource.DeleteByActionID(ctx, newID("action", 1)))
+	expect(t, "resources of menu 1 after delete by action", resourceCount(schema.MenuActionResourceQueryParam{MenuID: "menu-001"}), 0)
+}
@@ -0,0 +1,149 @@
+package modeltest
+
+import (
+	"context"
+	"testing"
+
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/schema"
+)
+
+func testTenant(t *testing.T, m *Models) {
+	ctx := context.Background()
+	for i, domain := range []string{"a.example.com", ""} {
+		must(t, m.Tenant.Create(ctx, schema.Tenant{
+			ID:      newID("tenant", i),
+			Code:    newID("code", i),
+			Name:    newID("name", i),
+			Domain:  domain,
+			Status:  1,
+			AdminID: newID("admin", i),
+		}))
+	}
+
+	tenantIDs := func(params schema.TenantQueryParam) string {
+		t.Helper()
+		result, err := m.Tenant.Query(ctx, params)
+		must(t, err)
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return sortedIDs(ids)
+	}
+
+	expect(t, "all", tenantIDs(schema.TenantQueryParam{}), "tenant-000,tenant-001")
+	expect(t, "by code", tenantIDs(schema.TenantQueryParam{Code: "code-001"}), "tenant-001")
+	expect(t, "by domain", tenantIDs(schema.TenantQueryParam{Domain: "a.example.com"}), "tenant-000")
+
+	item, err := m.Tenant.Get(ctx, "tenant-000")
+	must(t, err)
+	expect(t, "admin", item.AdminID, "admin-000")
+
+	item.Name = "renamed"
+	item.Domain = ""
+	must(t, m.Tenant.Update(ctx, item.ID, *item))
+	item, err = m.Tenant.Get(ctx, "tenant-000")
+	must(t, err)
+	expect(t, "updated name", item.Name, "renamed")
+	expect(t, "cleared domain", tenantIDs(schema.TenantQueryParam{Domain: "a.example.com"}), "")
+
+	must(t, m.Tenant.UpdateStatus(ctx, "tenant-001", 2))
+	expect(t, "enabled", tenantIDs(schema.TenantQueryParam{Status: 1}), "tenant-000")
+
+	must(t, m.Tenant.Delete(ctx, "tenant-001"))
+	expect(t, "after delete", tenantIDs(schema.TenantQueryParam{}), "tenant-000")
+}
+
+// 按租户隔离的数据仅对所属租户可见(未指定租户时为平台数据)，全局数据(菜单)对所有租户可见，跨租户访问需显式指定
+func testTenantScope(t *testing.T, m *Models) {
+	ctx := context.Background()
+	ctxA := icontext.NewTenantID(ctx, "tenant-a")
+	ctxB := icontext.NewTenantID(ctx, "tenant-b")
+	ctxAll := icontext.NewAllTenants(ctx)
+
+	// 不同租户(及平台)内允许相同的用户名
+	for i, tctx := range []context.Context{ctxA, ctxB, ctx} {
+		must(t, m.User.Create(tctx, schema.User{
+			ID:       newID("user", i),
+			UserName: "admin",
+			RealName: newID("real", i),
+			Password: "secret",
+			Status:   1,
+		}))
+		must(t, m.Role.Create(tctx, schema.Role{
+			ID:     newID("role", i),
+			Name:   "admin",
+			Status: 1,
+		}))
+	}
+	must(t, m.Menu.Create(ctxA, schema.Menu{ID: "menu-000", Name: "shared", ShowStatus: 1, Status: 1}))
+
+	userIDs := func(ctx context.Context) string {
+		t.Helper()
+		result, err := m.User.Query(ctx, schema.UserQueryParam{UserName: "admin"})
+		must(t, err)
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return sortedIDs(ids)
+	}
+	roleIDs := func(ctx context.Context) string {
+		t.Helper()
+		result, err := m.Role.Query(ctx, schema.RoleQueryParam{Name: "admin"})
+		must(t, err)
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		return sortedIDs(ids)
+	}
+
+	expect(t, "users of tenant a", userIDs(ctxA), "user-000")
+	expect(t, "users of tenant b", userIDs(ctxB), "user-001")
+	expect(t, "users of platform", userIDs(ctx), "user-002")
+	expect(t, "users of all tenants", userIDs(ctxAll), "user-000,user-001,user-002")
+	expect(t, "roles of tenant a", roleIDs(ctxA), "role-000")
+	expect(t, "roles of platform", roleIDs(ctx), "role-002")
+
+	for _, tctx := range []context.Context{ctxB, ctx} {
+		item, err := m.User.Get(tctx, "user-000")
+		must(t, err)
+		if item != nil {
+			t.Fatal("user of tenant a visible outside tenant a")
+		}
+	}
+
+	item, err := m.User.Get(ctxAll, "user-000")
+	must(t, err)
+	expect(t, "tenant of user", item.TenantID, "tenant-a")
+
+	// 其他租户(及平台)不允许修改或删除租户的数据
+	for _, tctx := range []context.Context{ctxB, ctx} {
+		must(t, m.User.Update(tctx, "user-000", schema.User{UserName: "changed", RealName: "changed"}))
+		must(t, m.User.UpdateStatus(tctx, "user-000", 2))
+		must(t, m.User.Delete(tctx, "user-000"))
+		must(t, m.Role.Delete(tctx, "role-000"))
+	}
+	item, err = m.User.Get(ctxA, "user-000")
+	must(t, err)
+	if item == nil {
+		t.Fatal("user of tenant a deleted outside tenant a")
+	}
+	expect(t, "user name", item.UserName, "admin")
+	expect(t, "status", item.Status, 1)
+	expect(t, "roles of tenant a after delete", roleIDs(ctxA), "role-000")
+
+	menu, err := m.Menu.Get(ctxB, "menu-000")
+	must(t, err)
+	if menu == nil {
+		t.Fatal("shared menu not visible in tenant b")
+	}
+
+	// 事务内同样按租户隔离
+	must(t, m.Trans.Exec(ctxB, func(ctx context.Context) error {
+		expect(t, "tenant b in transaction", userIDs(ctx), "user-001")
+		return nil
+	}))
+}
@@ -0,0 +1,100 @@
+package modeltest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+var errRollback = errors.New("rollback")
+
+func demoExists(t *testing.T, m *Models, ctx context.Context, id string) bool {
+	t.Helper()
+	item, err := m.Demo.Get(ctx, id)
+	must(t, err)
+	return item != nil
+}
+
+func testTransCommit(t *testing.T, m *Models) {
+	ctx := context.Background()
+	id := newID("demo", 0)
+
+	err := m.Trans.Exec(ctx, func(ctx context.Context) error {
+		must(t, m.Demo.Create(ctx, schema.Demo{ID: id, Code: "D000", Name: "name0", Status: 1}))
+		// 事务内可读取未提交的数据
+		if !demoExists(t, m, ctx, id) {
+			t.Fatal("uncommitted item not visible inside transaction")
+		}
+		return m.Demo.UpdateStatus(ctx, id, 2)
+	})
+	must(t, err)
+
+	item, err := m.Demo.Get(ctx, id)
+	must(t, err)
+	if item == nil {
+		t.Fatal("committed item not found")
+	}
+	expect(t, "status", item.Status, 2)
+}
+
+func testTransRollback(t *testing.T, m *Models) {
+	ctx := context.Background()
+	createDemos(t, m, 1)
+	existID, newItemID := newID("demo", 0), newID("demo", 1)
+
+	err := m.Trans.Exec(ctx, func(ctx context.Context) error {
+		must(t, m.Demo.Create(ctx, schema.Demo{ID: newItemID, Code: "D001", Name: "name1", Status: 1}))
+		must(t, m.Demo.UpdateStatus(ctx, existID, 2))
+		must(t, m.Demo.Delete(ctx, existID))
+		return errRollback
+	})
+	if err == nil {
+		t.Fatal("expected error from rolled back transaction")
+	}
+
+	if demoExists(t, m, ctx, newItemID) {
+		t.Fatal("item created in rolled back transaction is visible")
+	}
+	item, err := m.Demo.Get(ctx, existID)
+	must(t, err)
+	if item == nil {
+		t.Fatal("item deleted in rolled back transaction is missing")
+	}
+	expect(t, "status", item.Status, 1)
+}
+
+func testTransNested(t *testing.T, m *Models) {
+	ctx := context.Background()
+	outerID, innerID := newID("demo", 0), newID("demo", 1)
+
+	// 嵌套事务加入外层事务，外层回滚时内层的变更一并回滚
+	err := m.Trans.Exec(ctx, func(ctx context.Context) error {
+		must(t, m.Demo.Create(ctx, schema.Demo{ID: outerID, Code: "D000", Name: "name0", Status: 1}))
+		must(t, m.Trans.Exec(ctx, func(ctx context.Context) error {
+			return m.Demo.Create(ctx, schema.Demo{ID: innerID, Code: "D001", Name: "name1", Status: 1})
+		}))
+		return errRollback
+	})
+	if err == nil {
+		t.Fatal("expected error from rolled back transaction")
+	}
+	if demoExists(t, m, ctx, outerID) || demoExists(t, m, ctx, innerID) {
+		t.Fatal("nested transaction changes survived outer rollback")
+	}
+
+	// 内层返回错误时，外层可感知并整体回滚
+	err = m.Trans.Exec(ctx, func(ctx context.Context) error {
+		must(t, m.Demo.Create(ctx, schema.Demo{ID: outerID, Code: "D000", Name: "name0", Status: 1}))
+		return m.Trans.Exec(ctx, func(ctx context.Context) error {
+			return errRollback
+		})
+	})
+	if err == nil {
+		t.Fatal("expected error from inner transaction")
+	}
+	if demoExists(t, m, ctx, outerID) {
+		t.Fatal("outer transaction committed after inner error")
+	}
+}
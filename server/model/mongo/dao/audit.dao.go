@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IAudit = (*Audit)(nil)
+
+// AuditSet 注入Audit
+var AuditSet = wire.NewSet(wire.Struct(new(Audit), "*"), wire.Bind(new(model.IAudit), new(*Audit)))
+
+// Audit 审计日志存储
+type Audit struct {
+	Client *mongo.Client
+}
+
+func (a *Audit) getQueryOption(opts ...schema.AuditQueryOptions) schema.AuditQueryOptions {
+	var opt schema.AuditQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Audit) Query(ctx context.Context, params schema.AuditQueryParam, opts ...schema.AuditQueryOptions) (*schema.AuditQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetAuditCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.EntityType; v != "" {
+		filter["entity_type"] = v
+	}
+	if v := params.EntityID; v != "" {
+		filter["entity_id"] = v
+	}
+	if v := params.ActorID; v != "" {
+		filter["actor_id"] = v
+	}
+	if v := params.Action; v != "" {
+		filter["action"] = v
+	}
+	if v := params.StartTime; !v.IsZero() {
+		filter = AndFilter(filter, bson.M{"created_at": bson.M{"$gte": v}})
+	}
+	if v := params.EndTime; !v.IsZero() {
+		filter = AndFilter(filter, bson.M{"created_at": bson.M{"$lte": v}})
+	}
+
+	filter, err := WrapFilterQuery(filter, params.Filters, new(entity.Audits))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Audits
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.AuditQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaAudits(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Audit) Get(ctx context.Context, id string, opts ...schema.AuditQueryOptions) (*schema.Audit, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.Audit
+	ok, err := FindOne(ctx, entity.GetAuditCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaAudit(), nil
+}
+
+// Create 创建数据
+func (a *Audit) Create(ctx context.Context, item schema.Audit) error {
+	eitem := entity.SchemaAudit(item).ToAudit()
+	err := Insert(ctx, entity.GetAuditCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
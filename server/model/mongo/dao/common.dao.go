@@ -0,0 +1,556 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/util"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TransFunc 定义事务执行函数
+type TransFunc func(context.Context) error
+
+// ExecTrans 执行事务
+func ExecTrans(ctx context.Context, cli *mongo.Client, fn TransFunc) error {
+	transModel := &Trans{Client: cli}
+	return transModel.Exec(ctx, fn)
+}
+
+// ExecTransWithLock 执行事务（加锁）
+func ExecTransWithLock(ctx context.Context, cli *mongo.Client, fn TransFunc) error {
+	if !icontext.FromTransLock(ctx) {
+		ctx = icontext.NewTransLock(ctx)
+	}
+	return ExecTrans(ctx, cli, fn)
+}
+
+// DefaultFilter 默认查询条件(排除已删除的数据)
+func DefaultFilter(ctx context.Context) bson.M {
+	return bson.M{"deleted_at": nil}
+}
+
+// TenantFilter 按租户隔离的默认查询条件(排除已删除的数据，仅查询上下文中租户的数据，未指定租户时为平台数据)
+func TenantFilter(ctx context.Context) bson.M {
+	filter := DefaultFilter(ctx)
+	if tenantID, ok := icontext.FromTenantScope(ctx); ok {
+		filter["tenant_id"] = tenantID
+	}
+	return filter
+}
+
+// AndFilter 追加需要组合的查询条件(如$or条件)
+func AndFilter(filter bson.M, cond bson.M) bson.M {
+	list, _ := filter["$and"].(bson.A)
+	filter["$and"] = append(list, cond)
+	return filter
+}
+
+// RegexFilter 模糊匹配(等价于LIKE %v%，不区分大小写)
+func RegexFilter(v string) primitive.Regex {
+	return primitive.Regex{Pattern: regexp.QuoteMeta(v), Options: "i"}
+}
+
+// PrefixFilter 前缀匹配(等价于LIKE v%)
+func PrefixFilter(v string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(v)}
+}
+
+// WrapPageQuery 包装带有排序及分页的查询
+func WrapPageQuery(ctx context.Context, c *mongo.Collection, filter bson.M, pp schema.PaginationParam, orderFields []*schema.OrderField, out interface{}) (*schema.PaginationResult, error) {
+	if pp.OnlyCount {
+		count, err := c.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.PaginationResult{Total: int(count)}, nil
+	} else if !pp.Pagination {
+		err := Find(ctx, c, filter, options.Find().SetSort(ParseOrder(orderFields)), out)
+		return nil, err
+	} else if pp.IsCursor() {
+		return FindCursorPage(ctx, c, filter, pp, orderFields, out)
+	}
+
+	total, err := FindPage(ctx, c, filter, pp, options.Find().SetSort(ParseOrder(orderFields)), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.PaginationResult{
+		Total:    total,
+		Current:  pp.GetCurrent(),
+		PageSize: pp.GetPageSize(),
+	}, nil
+}
+
+// Find 查询数据列表
+func Find(ctx context.Context, c *mongo.Collection, filter bson.M, opts *options.FindOptions, out interface{}) error {
+	cursor, err := c.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, out)
+}
+
+// FindPage 查询分页数据
+func FindPage(ctx context.Context, c *mongo.Collection, filter bson.M, pp schema.PaginationParam, opts *options.FindOptions, out interface{}) (int, error) {
+	var count int64
+	if !pp.NoCount {
+		var err error
+		count, err = c.CountDocuments(ctx, filter)
+		if err != nil {
+			return 0, err
+		} else if count == 0 {
+			return 0, nil
+		}
+	}
+
+	current, pageSize := int64(pp.GetCurrent()), int64(pp.GetPageSize())
+	if current > 0 && pageSize > 0 {
+		opts.SetSkip((current - 1) * pageSize).SetLimit(pageSize)
+	} else if pageSize > 0 {
+		opts.SetLimit(pageSize)
+	}
+
+	err := Find(ctx, c, filter, opts, out)
+	return int(count), err
+}
+
+// FindCursorPage 基于游标(排序字段+ID)查询分页数据，避免大集合的skip扫描
+func FindCursorPage(ctx context.Context, c *mongo.Collection, filter bson.M, pp schema.PaginationParam, orderFields []*schema.OrderField, out interface{}) (*schema.PaginationResult, error) {
+	orderFields = withIDOrderField(orderFields)
+	fields, err := getCursorFields(orderFields, out)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &schema.PaginationResult{PageSize: pp.GetPageSize()}
+	if !pp.NoCount {
+		count, err := c.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		pr.Total = int(count)
+	}
+
+	var cursor *schema.PageCursor
+	if v := pp.Cursor; v != "" {
+		cursor, err = schema.DecodeCursor(v)
+		if err != nil || !cursor.Match(orderFields) {
+			return nil, errs.ErrInvalidCursor
+		}
+
+		cond, err := cursorFilter(orderFields, fields, cursor)
+		if err != nil {
+			return nil, errs.ErrInvalidCursor
+		}
+		filter = AndFilter(copyFilter(filter), cond)
+	}
+
+	backward := cursor != nil && cursor.Prev
+	queryFields := orderFields
+	if backward {
+		queryFields = reverseOrderFields(orderFields)
+	}
+
+	pageSize := pp.GetPageSize()
+	opts := options.Find().SetSort(ParseOrder(queryFields)).SetLimit(int64(pageSize) + 1)
+	err = Find(ctx, c, filter, opts, out)
+	if err != nil {
+		return nil, err
+	}
+
+	list := reflect.ValueOf(out).Elem()
+	hasMore := uint(list.Len()) > pageSize
+	if hasMore {
+		list.Set(list.Slice(0, int(pageSize)))
+	}
+	if backward {
+		reverseSlice(list)
+	}
+
+	if n := list.Len(); n > 0 {
+		// 向后翻页时，只要存在更多数据或来自上一页就有下一页；向前翻页时同理
+		order := schema.FormatCursorOrder(orderFields)
+		if hasMore || backward {
+			pr.NextCursor = encodeCursor(list.Index(n-1), fields, order, false)
+		}
+		if cursor != nil && (!backward || hasMore) {
+			pr.PrevCursor = encodeCursor(list.Index(0), fields, order, true)
+		}
+	}
+
+	return pr, nil
+}
+
+func copyFilter(filter bson.M) bson.M {
+	m := make(bson.M, len(filter)+1)
+	for k, v := range filter {
+		m[k] = v
+	}
+	return m
+}
+
+// 确保排序字段以ID结尾，保证排序的唯一性
+func withIDOrderField(items []*schema.OrderField) []*schema.OrderField {
+	for _, item := range items {
+		if item.Key == "id" {
+			return items
+		}
+	}
+	return append(items[:len(items):len(items)], schema.NewOrderField("id", schema.OrderByASC))
+}
+
+func reverseOrderFields(items []*schema.OrderField) []*schema.OrderField {
+	list := make([]*schema.OrderField, len(items))
+	for i, item := range items {
+		d := schema.OrderByDESC
+		if item.Direction == schema.OrderByDESC {
+			d = schema.OrderByASC
+		}
+		list[i] = schema.NewOrderField(item.Key, d)
+	}
+	return list
+}
+
+func reverseSlice(v reflect.Value) {
+	for i, j := 0, v.Len()-1; i < j; i, j = i+1, j-1 {
+		vi, vj := v.Index(i).Interface(), v.Index(j).Interface()
+		v.Index(i).Set(reflect.ValueOf(vj))
+		v.Index(j).Set(reflect.ValueOf(vi))
+	}
+}
+
+// 获取列表元素对应的实体类型
+func elemType(out interface{}) reflect.Type {
+	t := reflect.TypeOf(out)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// 根据bson字段名查找实体字段(支持内嵌结构)
+func lookupField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := strings.Split(sf.Tag.Get("bson"), ",")
+		if sf.Anonymous && len(tag) > 1 && tag[1] == "inline" {
+			if f, ok := lookupField(sf.Type, key); ok {
+				f.Index = append([]int{i}, f.Index...)
+				return f, true
+			}
+			continue
+		}
+		if tag[0] == key {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// 获取排序字段对应的实体字段
+func getCursorFields(orderFields []*schema.OrderField, out interface{}) ([]reflect.StructField, error) {
+	t := elemType(out)
+	fields := make([]reflect.StructField, len(orderFields))
+	for i, item := range orderFields {
+		sf, ok := lookupField(t, fieldName(item.Key))
+		if !ok {
+			return nil, errs.Errorf("unknown cursor order field: %s", item.Key)
+		}
+		fields[i] = sf
+	}
+	return fields, nil
+}
+
+// 构建游标的定位条件，形如：{$or: [{a: {$gt: ?}}, {a: ?, b: {$gt: ?}}]}
+// NULL值(含字段不存在)视为最小值，与mongo的排序一致：a为NULL时，大于条件为a不为NULL，不存在小于的记录；
+// a不为NULL时，可为NULL的字段(指针类型)的小于条件包含a为NULL
+func cursorFilter(orderFields []*schema.OrderField, fields []reflect.StructField, cursor *schema.PageCursor) (bson.M, error) {
+	values := make([]interface{}, len(fields))
+	for i, sf := range fields {
+		if cursor.IsNull(i) {
+			continue
+		}
+		t := sf.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		v := reflect.New(t)
+		if err := util.JSONUnmarshal(cursor.Values[i], v.Interface()); err != nil {
+			return nil, err
+		}
+		values[i] = v.Elem().Interface()
+	}
+
+	ors := make(bson.A, 0, len(orderFields))
+	for i, item := range orderFields {
+		key := fieldName(item.Key)
+		less := (item.Direction == schema.OrderByDESC) != cursor.Prev
+		if values[i] == nil && less {
+			continue
+		}
+
+		cond := bson.M{}
+		for j := 0; j < i; j++ {
+			cond[fieldName(orderFields[j].Key)] = values[j]
+		}
+
+		switch {
+		case values[i] == nil:
+			cond[key] = bson.M{"$ne": nil}
+		case less && fields[i].Type.Kind() == reflect.Ptr:
+			cond["$or"] = bson.A{bson.M{key: bson.M{"$lt": values[i]}}, bson.M{key: nil}}
+		case less:
+			cond[key] = bson.M{"$lt": values[i]}
+		default:
+			cond[key] = bson.M{"$gt": values[i]}
+		}
+		ors = append(ors, cond)
+	}
+
+	if len(ors) == 0 {
+		return bson.M{"_id": bson.M{"$exists": false}}, nil
+	}
+	return bson.M{"$or": ors}, nil
+}
+
+func encodeCursor(item reflect.Value, fields []reflect.StructField, order string, prev bool) string {
+	item = reflect.Indirect(item)
+	values := make([]json.RawMessage, len(fields))
+	for i, sf := range fields {
+		buf, err := util.JSONMarshal(item.FieldByIndex(sf.Index).Interface())
+		if err != nil {
+			return ""
+		}
+		values[i] = buf
+	}
+	return schema.EncodeCursor(&schema.PageCursor{Prev: prev, Order: order, Values: values})
+}
+
+// FindOne 查询单条数据
+func FindOne(ctx context.Context, c *mongo.Collection, filter bson.M, out interface{}) (bool, error) {
+	err := c.FindOne(ctx, filter).Decode(out)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Check 检查数据是否存在
+func Check(ctx context.Context, c *mongo.Collection, filter bson.M) (bool, error) {
+	count, err := c.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Insert 插入数据(自动填充创建及更新时间)
+func Insert(ctx context.Context, c *mongo.Collection, doc interface{ SetTimestamps(time.Time) }) error {
+	doc.SetTimestamps(time.Now())
+	if v, ok := doc.(interface{ SetTenantID(string) }); ok && entity.IsTenantScoped(doc) {
+		if tenantID, ok := icontext.FromTenantID(ctx); ok {
+			v.SetTenantID(tenantID)
+		}
+	}
+	_, err := c.InsertOne(ctx, doc)
+	return err
+}
+
+// Update 更新数据(与gorm的Updates一致，仅更新非零值字段)
+func Update(ctx context.Context, c *mongo.Collection, filter bson.M, doc interface{}) error {
+	fields := bson.M{}
+	nonZeroFields(reflect.Indirect(reflect.ValueOf(doc)), fields)
+	return UpdateFields(ctx, c, filter, fields)
+}
+
+// 不允许通过更新修改的字段
+var readonlyFields = map[string]struct{}{
+	"_id":        {},
+	"created_at": {},
+	"updated_at": {},
+	"deleted_at": {},
+	"tenant_id":  {},
+}
+
+func nonZeroFields(v reflect.Value, fields bson.M) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := strings.Split(sf.Tag.Get("bson"), ",")
+		if sf.Anonymous && len(tag) > 1 && tag[1] == "inline" {
+			nonZeroFields(v.Field(i), fields)
+			continue
+		}
+
+		if _, ok := readonlyFields[tag[0]]; ok || tag[0] == "" || tag[0] == "-" {
+			continue
+		}
+		if fv := v.Field(i); !fv.IsZero() {
+			fields[tag[0]] = fv.Interface()
+		}
+	}
+}
+
+// UpdateFields 更新指定字段(零值同样写入)
+func UpdateFields(ctx context.Context, c *mongo.Collection, filter bson.M, fields bson.M) error {
+	set := bson.M{"updated_at": time.Now()}
+	for k, v := range fields {
+		set[k] = v
+	}
+	_, err := c.UpdateMany(ctx, filter, bson.M{"$set": set})
+	return err
+}
+
+// Delete 删除数据(与gorm一致采用软删除)
+func Delete(ctx context.Context, c *mongo.Collection, filter bson.M) error {
+	_, err := c.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+	return err
+}
+
+var columnRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var filterOperators = map[schema.FilterOperator]string{
+	schema.FilterEQ:   "$eq",
+	schema.FilterNE:   "$ne",
+	schema.FilterGT:   "$gt",
+	schema.FilterGTE:  "$gte",
+	schema.FilterLT:   "$lt",
+	schema.FilterLTE:  "$lte",
+	schema.FilterLike: "$regex",
+	schema.FilterIn:   "$in",
+}
+
+// WrapFilterQuery 包装通用过滤条件(字段需存在于实体中，值按字段类型转换)
+func WrapFilterQuery(filter bson.M, filters []*schema.FilterField, out interface{}) (bson.M, error) {
+	t := elemType(out)
+	for _, item := range filters {
+		op, ok := filterOperators[item.Operator]
+		if !ok {
+			return nil, errs.Errorf("invalid filter operator: %s", item.Operator)
+		} else if !columnRegexp.MatchString(item.Key) {
+			return nil, errs.Errorf("invalid filter column: %s", item.Key)
+		} else if len(item.Values) == 0 {
+			continue
+		}
+
+		key := fieldName(item.Key)
+		sf, ok := lookupField(t, key)
+		if !ok {
+			return nil, errs.Errorf("invalid filter column: %s", item.Key)
+		}
+
+		var value interface{}
+		switch item.Operator {
+		case schema.FilterLike:
+			value = RegexFilter(item.Values[0])
+		case schema.FilterIn:
+			values := make(bson.A, len(item.Values))
+			for i, v := range item.Values {
+				fv, err := parseFilterValue(sf.Type, v)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = fv
+			}
+			value = values
+		default:
+			fv, err := parseFilterValue(sf.Type, item.Values[0])
+			if err != nil {
+				return nil, err
+			}
+			value = fv
+		}
+
+		if op == "$regex" {
+			filter = AndFilter(filter, bson.M{key: value})
+		} else {
+			filter = AndFilter(filter, bson.M{key: bson.M{op: value}})
+		}
+	}
+	return filter, nil
+}
+
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// 将过滤条件的值按字段类型转换(关系型数据库会隐式转换，mongo需要类型一致)
+func parseFilterValue(t reflect.Type, v string) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		for _, layout := range timeLayouts {
+			if tv, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+				return tv, nil
+			}
+		}
+		return nil, errs.New400I18nResponse("error.invalid_time_filter", v)
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iv, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, errs.New400I18nResponse("error.invalid_number_filter", v)
+		}
+		return reflect.ValueOf(iv).Convert(t).Interface(), nil
+	case reflect.Float32, reflect.Float64:
+		fv, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, errs.New400I18nResponse("error.invalid_number_filter", v)
+		}
+		return fv, nil
+	case reflect.Bool:
+		bv, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errs.New400I18nResponse("error.invalid_bool_filter", v)
+		}
+		return bv, nil
+	}
+	return v, nil
+}
+
+// 将字段名转换为文档字段名(主键为_id)
+func fieldName(key string) string {
+	if key == "id" {
+		return "_id"
+	}
+	return key
+}
+
+// ParseOrder 解析排序字段(重复的字段以首次出现的为准)
+func ParseOrder(items []*schema.OrderField) bson.D {
+	d := make(bson.D, 0, len(items))
+	keys := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		key := fieldName(item.Key)
+		if _, ok := keys[key]; ok {
+			continue
+		}
+		keys[key] = struct{}{}
+
+		direction := 1
+		if item.Direction == schema.OrderByDESC {
+			direction = -1
+		}
+		d = append(d, bson.E{Key: key, Value: direction})
+	}
+	return d
+}
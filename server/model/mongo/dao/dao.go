@@ -0,0 +1,22 @@
+package dao
+
+import "github.com/google/wire"
+
+// ModelSet model注入
+var ModelSet = wire.NewSet(
+	AuditSet,
+	DemoSet,
+	LogSet,
+	MenuActionResourceSet,
+	MenuActionSet,
+	MenuSet,
+	OutboxSet,
+	RoleMenuSet,
+	RoleSet,
+	TenantSet,
+	TransSet,
+	UserRoleSet,
+	UserSet,
+	WebhookDeliverySet,
+	WebhookSet,
+)
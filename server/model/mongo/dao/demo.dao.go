@@ -0,0 +1,131 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IDemo = (*Demo)(nil)
+
+// DemoSet 注入Demo
+var DemoSet = wire.NewSet(wire.Struct(new(Demo), "*"), wire.Bind(new(model.IDemo), new(*Demo)))
+
+// Demo 示例存储
+type Demo struct {
+	Client *mongo.Client
+}
+
+func (a *Demo) getQueryOption(opts ...schema.DemoQueryOptions) schema.DemoQueryOptions {
+	var opt schema.DemoQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...schema.DemoQueryOptions) (*schema.DemoQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetDemoCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.Code; v != "" {
+		filter["code"] = v
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"code": RegexFilter(v)},
+			bson.M{"name": RegexFilter(v)},
+			bson.M{"memo": RegexFilter(v)},
+		}})
+	}
+
+	filter, err := WrapFilterQuery(filter, params.Filters, new(entity.Demos))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Demos
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.DemoQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaDemos(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Demo) Get(ctx context.Context, id string, opts ...schema.DemoQueryOptions) (*schema.Demo, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.Demo
+	ok, err := FindOne(ctx, entity.GetDemoCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaDemo(), nil
+}
+
+// Create 创建数据
+func (a *Demo) Create(ctx context.Context, item schema.Demo) error {
+	eitem := entity.SchemaDemo(item).ToDemo()
+	err := Insert(ctx, entity.GetDemoCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *Demo) Update(ctx context.Context, id string, item schema.Demo) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaDemo(item).ToDemo()
+	err := Update(ctx, entity.GetDemoCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Demo) Delete(ctx context.Context, id string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetDemoCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Demo) UpdateStatus(ctx context.Context, id string, status int) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetDemoCollection(ctx, a.Client), filter, bson.M{"status": status})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.ILog = (*Log)(nil)
+
+// LogSet 注入Log
+var LogSet = wire.NewSet(wire.Struct(new(Log), "*"), wire.Bind(new(model.ILog), new(*Log)))
+
+// Log 系统日志存储
+type Log struct {
+	Client *mongo.Client
+}
+
+func (a *Log) getQueryOption(opts ...schema.LogQueryOptions) schema.LogQueryOptions {
+	var opt schema.LogQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Log) Query(ctx context.Context, params schema.LogQueryParam, opts ...schema.LogQueryOptions) (*schema.LogQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetLogCollection(ctx, a.Client)
+	// 日志文档没有软删除标记，不使用默认过滤条件
+	filter := bson.M{}
+	if v := params.IDs; len(v) > 0 {
+		filter["_id"] = bson.M{"$in": objectIDs(v)}
+	}
+	if v := params.Levels; len(v) > 0 {
+		filter["level"] = bson.M{"$in": v}
+	}
+	if v := params.TraceID; v != "" {
+		filter["trace_id"] = v
+	}
+	if v := params.UserID; v != "" {
+		filter["user_id"] = v
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"message": RegexFilter(v)},
+			bson.M{"data": RegexFilter(v)},
+		}})
+	}
+	if v := params.StartTime; !v.IsZero() {
+		filter = AndFilter(filter, bson.M{"created_at": bson.M{"$gte": v}})
+	}
+	if v := params.EndTime; !v.IsZero() {
+		filter = AndFilter(filter, bson.M{"created_at": bson.M{"$lte": v}})
+	}
+	if v := params.Before; !v.IsZero() {
+		filter = AndFilter(filter, bson.M{"created_at": bson.M{"$lt": v}})
+	}
+
+	filter, err := WrapFilterQuery(filter, params.Filters, new(entity.Logs))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Logs
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.LogQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaLogs(),
+	}
+	return qr, nil
+}
+
+// DeleteByIDs 删除指定数据
+func (a *Log) DeleteByIDs(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := entity.GetLogCollection(ctx, a.Client).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs(ids)}})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// 转换为ObjectID列表(忽略无效的ID)
+func objectIDs(ids []string) []primitive.ObjectID {
+	list := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			list = append(list, oid)
+		}
+	}
+	return list
+}
@@ -0,0 +1,122 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IMenuAction = (*MenuAction)(nil)
+
+// MenuActionSet 注入MenuAction
+var MenuActionSet = wire.NewSet(wire.Struct(new(MenuAction), "*"), wire.Bind(new(model.IMenuAction), new(*MenuAction)))
+
+// MenuAction 菜单动作存储
+type MenuAction struct {
+	Client *mongo.Client
+}
+
+func (a *MenuAction) getQueryOption(opts ...schema.MenuActionQueryOptions) schema.MenuActionQueryOptions {
+	var opt schema.MenuActionQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *MenuAction) Query(ctx context.Context, params schema.MenuActionQueryParam, opts ...schema.MenuActionQueryOptions) (*schema.MenuActionQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetMenuActionCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx)
+	if v := params.MenuID; v != "" {
+		filter["menu_id"] = v
+	}
+	if v := params.IDs; len(v) > 0 {
+		filter["_id"] = bson.M{"$in": v}
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
+
+	var list entity.MenuActions
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.MenuActionQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaMenuActions(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *MenuAction) Get(ctx context.Context, id string, opts ...schema.MenuActionQueryOptions) (*schema.MenuAction, error) {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.MenuAction
+	ok, err := FindOne(ctx, entity.GetMenuActionCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaMenuAction(), nil
+}
+
+// Create 创建数据
+func (a *MenuAction) Create(ctx context.Context, item schema.MenuAction) error {
+	eitem := entity.SchemaMenuAction(item).ToMenuAction()
+	err := Insert(ctx, entity.GetMenuActionCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *MenuAction) Update(ctx context.Context, id string, item schema.MenuAction) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaMenuAction(item).ToMenuAction()
+	err := Update(ctx, entity.GetMenuActionCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *MenuAction) Delete(ctx context.Context, id string) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetMenuActionCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByMenuID 根据菜单ID删除数据
+func (a *MenuAction) DeleteByMenuID(ctx context.Context, menuID string) error {
+	filter := DefaultFilter(ctx)
+	filter["menu_id"] = menuID
+
+	err := Delete(ctx, entity.GetMenuActionCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IMenuActionResource = (*MenuActionResource)(nil)
+
+// MenuActionResourceSet 注入MenuActionResource
+var MenuActionResourceSet = wire.NewSet(wire.Struct(new(MenuActionResource), "*"), wire.Bind(new(model.IMenuActionResource), new(*MenuActionResource)))
+
+// MenuActionResource 菜单动作关联资源存储
+type MenuActionResource struct {
+	Client *mongo.Client
+}
+
+func (a *MenuActionResource) getQueryOption(opts ...schema.MenuActionResourceQueryOptions) schema.MenuActionResourceQueryOptions {
+	var opt schema.MenuActionResourceQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *MenuActionResource) Query(ctx context.Context, params schema.MenuActionResourceQueryParam, opts ...schema.MenuActionResourceQueryOptions) (*schema.MenuActionResourceQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx)
+	if v := params.MenuID; v != "" {
+		actionIDs, err := a.queryActionIDs(ctx, v)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		filter = AndFilter(filter, bson.M{"action_id": bson.M{"$in": actionIDs}})
+	}
+	if v := params.MenuIDs; len(v) > 0 {
+		actionIDs, err := a.queryActionIDs(ctx, v...)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		filter = AndFilter(filter, bson.M{"action_id": bson.M{"$in": actionIDs}})
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
+
+	var list entity.MenuActionResources
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.MenuActionResourceQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaMenuActionResources(),
+	}
+	return qr, nil
+}
+
+// 查询菜单下的动作ID列表
+func (a *MenuActionResource) queryActionIDs(ctx context.Context, menuIDs ...string) ([]string, error) {
+	filter := DefaultFilter(ctx)
+	filter["menu_id"] = bson.M{"$in": menuIDs}
+
+	var list entity.MenuActions
+	err := Find(ctx, entity.GetMenuActionCollection(ctx, a.Client), filter, options.Find().SetProjection(bson.M{"_id": 1}), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	actionIDs := make([]string, len(list))
+	for i, item := range list {
+		actionIDs[i] = item.ID
+	}
+	return actionIDs, nil
+}
+
+// Get 查询指定数据
+func (a *MenuActionResource) Get(ctx context.Context, id string, opts ...schema.MenuActionResourceQueryOptions) (*schema.MenuActionResource, error) {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.MenuActionResource
+	ok, err := FindOne(ctx, entity.GetMenuActionResourceCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaMenuActionResource(), nil
+}
+
+// Create 创建数据
+func (a *MenuActionResource) Create(ctx context.Context, item schema.MenuActionResource) error {
+	eitem := entity.SchemaMenuActionResource(item).ToMenuActionResource()
+	err := Insert(ctx, entity.GetMenuActionResourceCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *MenuActionResource) Update(ctx context.Context, id string, item schema.MenuActionResource) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaMenuActionResource(item).ToMenuActionResource()
+	err := Update(ctx, entity.GetMenuActionResourceCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *MenuActionResource) Delete(ctx context.Context, id string) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetMenuActionResourceCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByActionID 根据动作ID删除数据
+func (a *MenuActionResource) DeleteByActionID(ctx context.Context, actionID string) error {
+	filter := DefaultFilter(ctx)
+	filter["action_id"] = actionID
+
+	err := Delete(ctx, entity.GetMenuActionResourceCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByMenuID 根据菜单ID删除数据
+func (a *MenuActionResource) DeleteByMenuID(ctx context.Context, menuID string) error {
+	actionIDs, err := a.queryActionIDs(ctx, menuID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+
+	filter := DefaultFilter(ctx)
+	filter["action_id"] = bson.M{"$in": actionIDs}
+
+	err = Delete(ctx, entity.GetMenuActionResourceCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
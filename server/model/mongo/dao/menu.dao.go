@@ -0,0 +1,157 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IMenu = (*Menu)(nil)
+
+// MenuSet 注入Menu
+var MenuSet = wire.NewSet(wire.Struct(new(Menu), "*"), wire.Bind(new(model.IMenu), new(*Menu)))
+
+// Menu 菜单存储
+type Menu struct {
+	Client *mongo.Client
+}
+
+func (a *Menu) getQueryOption(opts ...schema.MenuQueryOptions) schema.MenuQueryOptions {
+	var opt schema.MenuQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...schema.MenuQueryOptions) (*schema.MenuQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetMenuCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx)
+	if v := params.IDs; len(v) > 0 {
+		filter["_id"] = bson.M{"$in": v}
+	}
+	if v := params.Name; v != "" {
+		filter["name"] = v
+	}
+	if v := params.ParentID; v != nil {
+		filter["parent_id"] = *v
+	}
+	if v := params.PrefixParentPath; v != "" {
+		filter["parent_path"] = PrefixFilter(v)
+	}
+	if v := params.ShowStatus; v != 0 {
+		filter["show_status"] = v
+	}
+	if v := params.Status; v != 0 {
+		filter["status"] = v
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"name": RegexFilter(v)},
+			bson.M{"memo": RegexFilter(v)},
+		}})
+	}
+
+	filter, err := WrapFilterQuery(filter, params.Filters, new(entity.Menus))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Menus
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.MenuQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaMenus(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Menu) Get(ctx context.Context, id string, opts ...schema.MenuQueryOptions) (*schema.Menu, error) {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.Menu
+	ok, err := FindOne(ctx, entity.GetMenuCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaMenu(), nil
+}
+
+// Create 创建数据
+func (a *Menu) Create(ctx context.Context, item schema.Menu) error {
+	eitem := entity.SchemaMenu(item).ToMenu()
+	err := Insert(ctx, entity.GetMenuCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *Menu) Update(ctx context.Context, id string, item schema.Menu) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaMenu(item).ToMenu()
+	err := Update(ctx, entity.GetMenuCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateParentPath 更新父级路径
+func (a *Menu) UpdateParentPath(ctx context.Context, id, parentPath string) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetMenuCollection(ctx, a.Client), filter, bson.M{"parent_path": parentPath})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Menu) Delete(ctx context.Context, id string) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetMenuCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Menu) UpdateStatus(ctx context.Context, id string, status int) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetMenuCollection(ctx, a.Client), filter, bson.M{"status": status})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
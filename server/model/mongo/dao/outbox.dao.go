@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IOutbox = (*Outbox)(nil)
+
+// OutboxSet 注入Outbox
+var OutboxSet = wire.NewSet(wire.Struct(new(Outbox), "*"), wire.Bind(new(model.IOutbox), new(*Outbox)))
+
+// Outbox 事件发件箱存储
+type Outbox struct {
+	Client *mongo.Client
+}
+
+func (a *Outbox) getQueryOption(opts ...schema.EventQueryOptions) schema.EventQueryOptions {
+	var opt schema.EventQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Outbox) Query(ctx context.Context, params schema.EventQueryParam, opts ...schema.EventQueryOptions) (*schema.EventQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetOutboxCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.Status; v > 0 {
+		filter["status"] = v
+	}
+	if v := params.RetryBefore; !v.IsZero() {
+		filter["next_retry_at"] = bson.M{"$lte": v}
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
+
+	var list entity.Outboxes
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.EventQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaEvents(),
+	}
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *Outbox) Create(ctx context.Context, item schema.Event) error {
+	eitem := entity.SchemaEvent(item).ToOutbox()
+	err := Insert(ctx, entity.GetOutboxCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(仅更新投递状态，零值同样写入)
+func (a *Outbox) Update(ctx context.Context, id string, item schema.Event) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaEvent(item).ToOutbox()
+	err := UpdateFields(ctx, entity.GetOutboxCollection(ctx, a.Client), filter, bson.M{
+		"status":        eitem.Status,
+		"attempts":      eitem.Attempts,
+		"last_error":    eitem.LastError,
+		"next_retry_at": eitem.NextRetryAt,
+		"sent_sinks":    eitem.SentSinks,
+	})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Claim 领取到期的待投递事件(条件更新，同一事件只有一个实例领取成功)
+func (a *Outbox) Claim(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+	filter["status"] = schema.EventPending
+	filter["next_retry_at"] = bson.M{"$lte": now}
+
+	result, err := entity.GetOutboxCollection(ctx, a.Client).UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{"next_retry_at": leaseUntil, "updated_at": time.Now()},
+	})
+	if err != nil {
+		return false, errs.WithStack(err)
+	}
+	return result.ModifiedCount > 0, nil
+}
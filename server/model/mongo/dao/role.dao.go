@@ -0,0 +1,159 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IRole = (*Role)(nil)
+
+// RoleSet 注入Role
+var RoleSet = wire.NewSet(wire.Struct(new(Role), "*"), wire.Bind(new(model.IRole), new(*Role)))
+
+// Role 角色存储
+type Role struct {
+	Client *mongo.Client
+}
+
+func (a *Role) getQueryOption(opts ...schema.RoleQueryOptions) schema.RoleQueryOptions {
+	var opt schema.RoleQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...schema.RoleQueryOptions) (*schema.RoleQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetRoleCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.IDs; len(v) > 0 {
+		filter["_id"] = bson.M{"$in": v}
+	}
+	if v := params.Name; v != "" {
+		filter["name"] = v
+	}
+	if v := params.UserID; v != "" {
+		roleIDs, err := a.queryRoleIDs(ctx, v)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		filter = AndFilter(filter, bson.M{"_id": bson.M{"$in": roleIDs}})
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"name": RegexFilter(v)},
+			bson.M{"memo": RegexFilter(v)},
+		}})
+	}
+
+	filter, err := WrapFilterQuery(filter, params.Filters, new(entity.Roles))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Roles
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.RoleQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaRoles(),
+	}
+	return qr, nil
+}
+
+// 查询用户关联的角色ID列表
+func (a *Role) queryRoleIDs(ctx context.Context, userID string) ([]string, error) {
+	filter := TenantFilter(ctx)
+	filter["user_id"] = userID
+
+	var list entity.UserRoles
+	err := Find(ctx, entity.GetUserRoleCollection(ctx, a.Client), filter, options.Find().SetProjection(bson.M{"role_id": 1}), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := make([]string, len(list))
+	for i, item := range list {
+		roleIDs[i] = item.RoleID
+	}
+	return roleIDs, nil
+}
+
+// Get 查询指定数据
+func (a *Role) Get(ctx context.Context, id string, opts ...schema.RoleQueryOptions) (*schema.Role, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.Role
+	ok, err := FindOne(ctx, entity.GetRoleCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaRole(), nil
+}
+
+// Create 创建数据
+func (a *Role) Create(ctx context.Context, item schema.Role) error {
+	eitem := entity.SchemaRole(item).ToRole()
+	err := Insert(ctx, entity.GetRoleCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *Role) Update(ctx context.Context, id string, item schema.Role) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaRole(item).ToRole()
+	err := Update(ctx, entity.GetRoleCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Role) Delete(ctx context.Context, id string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetRoleCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Role) UpdateStatus(ctx context.Context, id string, status int) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetRoleCollection(ctx, a.Client), filter, bson.M{"status": status})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IRoleMenu = (*RoleMenu)(nil)
+
+// RoleMenuSet 注入RoleMenu
+var RoleMenuSet = wire.NewSet(wire.Struct(new(RoleMenu), "*"), wire.Bind(new(model.IRoleMenu), new(*RoleMenu)))
+
+// RoleMenu 角色菜单存储
+type RoleMenu struct {
+	Client *mongo.Client
+}
+
+func (a *RoleMenu) getQueryOption(opts ...schema.RoleMenuQueryOptions) schema.RoleMenuQueryOptions {
+	var opt schema.RoleMenuQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *RoleMenu) Query(ctx context.Context, params schema.RoleMenuQueryParam, opts ...schema.RoleMenuQueryOptions) (*schema.RoleMenuQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetRoleMenuCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.RoleID; v != "" {
+		filter["role_id"] = v
+	}
+	if v := params.RoleIDs; len(v) > 0 {
+		filter = AndFilter(filter, bson.M{"role_id": bson.M{"$in": v}})
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.RoleMenus
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.RoleMenuQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaRoleMenus(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *RoleMenu) Get(ctx context.Context, id string, opts ...schema.RoleMenuQueryOptions) (*schema.RoleMenu, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.RoleMenu
+	ok, err := FindOne(ctx, entity.GetRoleMenuCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaRoleMenu(), nil
+}
+
+// Create 创建数据
+func (a *RoleMenu) Create(ctx context.Context, item schema.RoleMenu) error {
+	eitem := entity.SchemaRoleMenu(item).ToRoleMenu()
+	err := Insert(ctx, entity.GetRoleMenuCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *RoleMenu) Update(ctx context.Context, id string, item schema.RoleMenu) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaRoleMenu(item).ToRoleMenu()
+	err := Update(ctx, entity.GetRoleMenuCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *RoleMenu) Delete(ctx context.Context, id string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetRoleMenuCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByRoleID 根据角色ID删除数据
+func (a *RoleMenu) DeleteByRoleID(ctx context.Context, roleID string) error {
+	filter := TenantFilter(ctx)
+	filter["role_id"] = roleID
+
+	err := Delete(ctx, entity.GetRoleMenuCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByMenuID 根据菜单ID删除数据
+func (a *RoleMenu) DeleteByMenuID(ctx context.Context, menuID string) error {
+	filter := TenantFilter(ctx)
+	filter["menu_id"] = menuID
+
+	err := Delete(ctx, entity.GetRoleMenuCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByActionID 根据动作ID删除数据
+func (a *RoleMenu) DeleteByActionID(ctx context.Context, actionID string) error {
+	filter := TenantFilter(ctx)
+	filter["action_id"] = actionID
+
+	err := Delete(ctx, entity.GetRoleMenuCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.ITenant = (*Tenant)(nil)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"), wire.Bind(new(model.ITenant), new(*Tenant)))
+
+// Tenant 租户存储
+type Tenant struct {
+	Client *mongo.Client
+}
+
+func (a *Tenant) getQueryOption(opts ...schema.TenantQueryOptions) schema.TenantQueryOptions {
+	var opt schema.TenantQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetTenantCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx)
+	if v := params.Code; v != "" {
+		filter["code"] = v
+	}
+	if v := params.Domain; v != "" {
+		filter["domain"] = v
+	}
+	if v := params.Status; v > 0 {
+		filter["status"] = v
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"code": RegexFilter(v)},
+			bson.M{"name": RegexFilter(v)},
+			bson.M{"domain": RegexFilter(v)},
+			bson.M{"memo": RegexFilter(v)},
+		}})
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Tenants
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.TenantQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaTenants(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, id string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.Tenant
+	ok, err := FindOne(ctx, entity.GetTenantCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaTenant(), nil
+}
+
+// Create 创建数据
+func (a *Tenant) Create(ctx context.Context, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	err := Insert(ctx, entity.GetTenantCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(绑定的域名允许清空，因此显式更新)
+func (a *Tenant) Update(ctx context.Context, id string, item schema.Tenant) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaTenant(item).ToTenant()
+	err := UpdateFields(ctx, entity.GetTenantCollection(ctx, a.Client), filter, bson.M{
+		"code":     eitem.Code,
+		"name":     eitem.Name,
+		"domain":   eitem.Domain,
+		"status":   eitem.Status,
+		"memo":     eitem.Memo,
+		"admin_id": eitem.AdminID,
+	})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(ctx context.Context, id string) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetTenantCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Tenant) UpdateStatus(ctx context.Context, id string, status int) error {
+	filter := DefaultFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetTenantCollection(ctx, a.Client), filter, bson.M{"status": status})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
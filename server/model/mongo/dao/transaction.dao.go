@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.ITrans = new(Trans)
+
+// TransSet 注入Trans
+var TransSet = wire.NewSet(wire.Struct(new(Trans), "*"), wire.Bind(new(model.ITrans), new(*Trans)))
+
+// Trans 事务管理(基于会话，要求mongo以副本集或分片集群方式部署)
+type Trans struct {
+	Client *mongo.Client
+}
+
+// Exec 执行事务
+func (a *Trans) Exec(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := icontext.FromTrans(ctx); ok {
+		return fn(ctx)
+	}
+
+	session, err := a.Client.StartSession()
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(icontext.NewTrans(sessCtx, session))
+	})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Savepoint 在当前事务中执行(mongo不支持保存点，fn返回错误前的写入不会单独回滚)
+func (a *Trans) Savepoint(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := icontext.FromTrans(ctx); !ok {
+		return a.Exec(ctx, fn)
+	}
+	return fn(ctx)
+}
@@ -0,0 +1,173 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IUser = (*User)(nil)
+
+// UserSet 注入User
+var UserSet = wire.NewSet(wire.Struct(new(User), "*"), wire.Bind(new(model.IUser), new(*User)))
+
+// User 用户存储
+type User struct {
+	Client *mongo.Client
+}
+
+func (a *User) getQueryOption(opts ...schema.UserQueryOptions) schema.UserQueryOptions {
+	var opt schema.UserQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetUserCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.UserName; v != "" {
+		filter["user_name"] = v
+	}
+	if v := params.Status; v > 0 {
+		filter["status"] = v
+	}
+	if v := params.RoleIDs; len(v) > 0 {
+		userIDs, err := a.queryUserIDs(ctx, v)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		filter["_id"] = bson.M{"$in": userIDs}
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"user_name": RegexFilter(v)},
+			bson.M{"real_name": RegexFilter(v)},
+			bson.M{"phone": RegexFilter(v)},
+			bson.M{"email": RegexFilter(v)},
+		}})
+	}
+
+	filter, err := WrapFilterQuery(filter, params.Filters, new(entity.Users))
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Users
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.UserQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaUsers(),
+	}
+	return qr, nil
+}
+
+// 查询关联了指定角色的用户ID列表
+func (a *User) queryUserIDs(ctx context.Context, roleIDs []string) ([]string, error) {
+	filter := TenantFilter(ctx)
+	filter["role_id"] = bson.M{"$in": roleIDs}
+
+	var list entity.UserRoles
+	err := Find(ctx, entity.GetUserRoleCollection(ctx, a.Client), filter, options.Find().SetProjection(bson.M{"user_id": 1}), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, len(list))
+	for i, item := range list {
+		userIDs[i] = item.UserID
+	}
+	return userIDs, nil
+}
+
+// Get 查询指定数据
+func (a *User) Get(ctx context.Context, id string, opts ...schema.UserQueryOptions) (*schema.User, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.User
+	ok, err := FindOne(ctx, entity.GetUserCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaUser(), nil
+}
+
+// Create 创建数据
+func (a *User) Create(ctx context.Context, item schema.User) error {
+	eitem := entity.SchemaUser(item).ToUser()
+	err := Insert(ctx, entity.GetUserCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *User) Update(ctx context.Context, id string, item schema.User) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaUser(item).ToUser()
+	err := Update(ctx, entity.GetUserCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *User) Delete(ctx context.Context, id string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetUserCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *User) UpdateStatus(ctx context.Context, id string, status int) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetUserCollection(ctx, a.Client), filter, bson.M{"status": status})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdatePassword 更新密码
+func (a *User) UpdatePassword(ctx context.Context, id, password string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetUserCollection(ctx, a.Client), filter, bson.M{"password": password})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
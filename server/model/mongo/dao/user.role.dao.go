@@ -0,0 +1,122 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IUserRole = (*UserRole)(nil)
+
+// UserRoleSet 注入UserRole
+var UserRoleSet = wire.NewSet(wire.Struct(new(UserRole), "*"), wire.Bind(new(model.IUserRole), new(*UserRole)))
+
+// UserRole 用户角色存储
+type UserRole struct {
+	Client *mongo.Client
+}
+
+func (a *UserRole) getQueryOption(opts ...schema.UserRoleQueryOptions) schema.UserRoleQueryOptions {
+	var opt schema.UserRoleQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *UserRole) Query(ctx context.Context, params schema.UserRoleQueryParam, opts ...schema.UserRoleQueryOptions) (*schema.UserRoleQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetUserRoleCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.UserID; v != "" {
+		filter["user_id"] = v
+	}
+	if v := params.UserIDs; len(v) > 0 {
+		filter = AndFilter(filter, bson.M{"user_id": bson.M{"$in": v}})
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.UserRoles
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.UserRoleQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaUserRoles(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *UserRole) Get(ctx context.Context, id string, opts ...schema.UserRoleQueryOptions) (*schema.UserRole, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.UserRole
+	ok, err := FindOne(ctx, entity.GetUserRoleCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaUserRole(), nil
+}
+
+// Create 创建数据
+func (a *UserRole) Create(ctx context.Context, item schema.UserRole) error {
+	eitem := entity.SchemaUserRole(item).ToUserRole()
+	err := Insert(ctx, entity.GetUserRoleCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *UserRole) Update(ctx context.Context, id string, item schema.UserRole) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaUserRole(item).ToUserRole()
+	err := Update(ctx, entity.GetUserRoleCollection(ctx, a.Client), filter, eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *UserRole) Delete(ctx context.Context, id string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetUserRoleCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *UserRole) DeleteByUserID(ctx context.Context, userID string) error {
+	filter := TenantFilter(ctx)
+	filter["user_id"] = userID
+
+	err := Delete(ctx, entity.GetUserRoleCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
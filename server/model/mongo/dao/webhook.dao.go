@@ -0,0 +1,136 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IWebhook = (*Webhook)(nil)
+
+// WebhookSet 注入Webhook
+var WebhookSet = wire.NewSet(wire.Struct(new(Webhook), "*"), wire.Bind(new(model.IWebhook), new(*Webhook)))
+
+// Webhook webhook订阅存储
+type Webhook struct {
+	Client *mongo.Client
+}
+
+func (a *Webhook) getQueryOption(opts ...schema.WebhookQueryOptions) schema.WebhookQueryOptions {
+	var opt schema.WebhookQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Webhook) Query(ctx context.Context, params schema.WebhookQueryParam, opts ...schema.WebhookQueryOptions) (*schema.WebhookQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetWebhookCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.Status; v > 0 {
+		filter["status"] = v
+	}
+	if v := params.URL; v != "" {
+		filter["url"] = v
+	}
+	if v := params.QueryValue; v != "" {
+		filter = AndFilter(filter, bson.M{"$or": bson.A{
+			bson.M{"name": RegexFilter(v)},
+			bson.M{"url": RegexFilter(v)},
+			bson.M{"memo": RegexFilter(v)},
+		}})
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.Webhooks
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.WebhookQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaWebhooks(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Webhook) Get(ctx context.Context, id string, opts ...schema.WebhookQueryOptions) (*schema.Webhook, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.Webhook
+	ok, err := FindOne(ctx, entity.GetWebhookCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaWebhook(), nil
+}
+
+// Create 创建数据
+func (a *Webhook) Create(ctx context.Context, item schema.Webhook) error {
+	eitem := entity.SchemaWebhook(item).ToWebhook()
+	err := Insert(ctx, entity.GetWebhookCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(订阅的事件类型允许清空，因此显式更新)
+func (a *Webhook) Update(ctx context.Context, id string, item schema.Webhook) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaWebhook(item).ToWebhook()
+	err := UpdateFields(ctx, entity.GetWebhookCollection(ctx, a.Client), filter, bson.M{
+		"name":   eitem.Name,
+		"url":    eitem.URL,
+		"secret": eitem.Secret,
+		"events": eitem.Events,
+		"status": eitem.Status,
+		"memo":   eitem.Memo,
+	})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Webhook) Delete(ctx context.Context, id string) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := Delete(ctx, entity.GetWebhookCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Webhook) UpdateStatus(ctx context.Context, id string, status int) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	err := UpdateFields(ctx, entity.GetWebhookCollection(ctx, a.Client), filter, bson.M{"status": status})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ model.IWebhookDelivery = (*WebhookDelivery)(nil)
+
+// WebhookDeliverySet 注入WebhookDelivery
+var WebhookDeliverySet = wire.NewSet(wire.Struct(new(WebhookDelivery), "*"), wire.Bind(new(model.IWebhookDelivery), new(*WebhookDelivery)))
+
+// WebhookDelivery webhook投递记录存储
+type WebhookDelivery struct {
+	Client *mongo.Client
+}
+
+func (a *WebhookDelivery) getQueryOption(opts ...schema.WebhookDeliveryQueryOptions) schema.WebhookDeliveryQueryOptions {
+	var opt schema.WebhookDeliveryQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *WebhookDelivery) Query(ctx context.Context, params schema.WebhookDeliveryQueryParam, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDeliveryQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetWebhookDeliveryCollection(ctx, a.Client)
+	filter := TenantFilter(ctx)
+	if v := params.WebhookID; v != "" {
+		filter["webhook_id"] = v
+	}
+	if v := params.EventID; v != "" {
+		filter["event_id"] = v
+	}
+	if v := params.EventType; v != "" {
+		filter["event_type"] = v
+	}
+	if v := params.Status; v > 0 {
+		filter["status"] = v
+	}
+	if v := params.RetryBefore; !v.IsZero() {
+		filter["next_retry_at"] = bson.M{"$lte": v}
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+
+	var list entity.WebhookDeliveries
+	pr, err := WrapPageQuery(ctx, c, filter, params.PaginationParam, opt.OrderFields, &list)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	qr := &schema.WebhookDeliveryQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaWebhookDeliveries(),
+	}
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *WebhookDelivery) Get(ctx context.Context, id string, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDelivery, error) {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	var item entity.WebhookDelivery
+	ok, err := FindOne(ctx, entity.GetWebhookDeliveryCollection(ctx, a.Client), filter, &item)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaWebhookDelivery(), nil
+}
+
+// Create 创建数据
+func (a *WebhookDelivery) Create(ctx context.Context, item schema.WebhookDelivery) error {
+	eitem := entity.SchemaWebhookDelivery(item).ToWebhookDelivery()
+	err := Insert(ctx, entity.GetWebhookDeliveryCollection(ctx, a.Client), eitem)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据(仅更新投递状态，零值同样写入)
+func (a *WebhookDelivery) Update(ctx context.Context, id string, item schema.WebhookDelivery) error {
+	filter := TenantFilter(ctx)
+	filter["_id"] = id
+
+	eitem := entity.SchemaWebhookDelivery(item).ToWebhookDelivery()
+	err := UpdateFields(ctx, entity.GetWebhookDeliveryCollection(ctx, a.Client), filter, bson.M{
+		"status":          eitem.Status,
+		"attempts":        eitem.Attempts,
+		"response_status": eitem.ResponseStatus,
+		"response_body":   eitem.ResponseBody,
+		"last_error":      eitem.LastError,
+		"next_retry_at":   eitem.NextRetryAt,
+	})
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByWebhookID 根据webhook订阅ID删除数据
+func (a *WebhookDelivery) DeleteByWebhookID(ctx context.Context, webhookID string) error {
+	filter := TenantFilter(ctx)
+	filter["webhook_id"] = webhookID
+
+	err := Delete(ctx, entity.GetWebhookDeliveryCollection(ctx, a.Client), filter)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	return nil
+}
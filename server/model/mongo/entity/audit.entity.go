@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetAuditCollection 获取审计日志存储
+func GetAuditCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, Audit{}.CollectionName())
+}
+
+// SchemaAudit 审计日志对象
+type SchemaAudit schema.Audit
+
+// ToAudit 转换为审计日志实体
+func (a SchemaAudit) ToAudit() *Audit {
+	item := new(Audit)
+	util.StructMapToStruct(a, item)
+	item.Changes = util.JSONMarshalToString(a.Changes)
+	return item
+}
+
+// Audit 审计日志实体
+type Audit struct {
+	Model      `bson:",inline"`
+	EntityType string `bson:"entity_type"` // 实体类型
+	EntityID   string `bson:"entity_id"`   // 实体ID
+	Action     string `bson:"action"`      // 审计动作
+	ActorID    string `bson:"actor_id"`    // 操作人
+	TraceID    string `bson:"trace_id"`    // 追踪ID
+	Changes    string `bson:"changes"`     // 变更字段列表(JSON)
+}
+
+// CollectionName 集合名
+func (a Audit) CollectionName() string {
+	return a.Model.CollectionName("audit")
+}
+
+// CreateIndexes 创建索引
+func (a Audit) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "entity_type", "entity_id", "action", "actor_id", "trace_id")
+}
+
+// ToSchemaAudit 转换为审计日志对象
+func (a Audit) ToSchemaAudit() *schema.Audit {
+	item := new(schema.Audit)
+	util.StructMapToStruct(a, item)
+	item.Changes = nil
+	if a.Changes != "" {
+		_ = util.JSONUnmarshal([]byte(a.Changes), &item.Changes)
+	}
+	return item
+}
+
+// Audits 审计日志实体列表
+type Audits []*Audit
+
+// ToSchemaAudits 转换为审计日志对象列表
+func (a Audits) ToSchemaAudits() []*schema.Audit {
+	list := make([]*schema.Audit, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaAudit()
+	}
+	return list
+}
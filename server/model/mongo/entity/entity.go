@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/key7men/mag/server/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Model base model
+type Model struct {
+	ID        string     `bson:"_id"`
+	CreatedAt time.Time  `bson:"created_at"`
+	UpdatedAt time.Time  `bson:"updated_at"`
+	DeletedAt *time.Time `bson:"deleted_at"`
+	TenantID  string     `bson:"tenant_id"`
+}
+
+// TenantShared 不按租户隔离的实体(全局数据，如菜单、动作、资源及租户)
+type TenantShared interface {
+	TenantShared() bool
+}
+
+// IsTenantScoped 检查实体是否按租户隔离
+func IsTenantScoped(m interface{}) bool {
+	if v, ok := m.(TenantShared); ok {
+		return !v.TenantShared()
+	}
+	return true
+}
+
+// CollectionName collection name
+func (Model) CollectionName(name string) string {
+	return fmt.Sprintf("%s%s", config.C.Mongo.CollectionPrefix, name)
+}
+
+// SetTimestamps 创建时填充创建及更新时间
+func (a *Model) SetTimestamps(now time.Time) {
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = now
+	}
+	a.UpdatedAt = now
+}
+
+// SetTenantID 创建时填充租户ID
+func (a *Model) SetTenantID(tenantID string) {
+	if a.TenantID == "" {
+		a.TenantID = tenantID
+	}
+}
+
+// CreateIndexes 创建索引(已存在的索引会被忽略)
+func (Model) CreateIndexes(ctx context.Context, cli *mongo.Client, name string, keys ...string) error {
+	models := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "updated_at", Value: 1}}},
+		{Keys: bson.D{{Key: "deleted_at", Value: 1}}},
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}}},
+	}
+	for _, key := range keys {
+		models = append(models, mongo.IndexModel{Keys: bson.D{{Key: key, Value: 1}}})
+	}
+
+	_, err := GetCollection(ctx, cli, name).Indexes().CreateMany(ctx, models)
+	return err
+}
+
+// GetCollection 获取集合(事务会话由上下文传递)
+func GetCollection(ctx context.Context, cli *mongo.Client, name string) *mongo.Collection {
+	return cli.Database(config.C.Mongo.Database).Collection(name)
+}
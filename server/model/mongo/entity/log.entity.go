@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetLogCollection 获取系统日志存储
+func GetLogCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, Log{}.CollectionName())
+}
+
+// Log 系统日志实体(与mongo日志钩子的文档结构保持一致，索引由钩子创建)
+type Log struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"` // id
+	Level        string             `bson:"level"`         // 日志级别
+	Message      string             `bson:"message"`       // 消息
+	TraceID      string             `bson:"trace_id"`      // 跟踪ID
+	UserID       string             `bson:"user_id"`       // 用户ID
+	SpanTitle    string             `bson:"span_title"`    // 跟踪单元标题
+	SpanFunction string             `bson:"span_function"` // 跟踪单元函数名
+	Data         string             `bson:"data"`          // 日志数据(json)
+	Version      string             `bson:"version"`       // 服务版本号
+	CreatedAt    time.Time          `bson:"created_at"`    // 创建时间
+}
+
+// CollectionName 集合名(与日志钩子配置的集合名一致，未配置时使用默认集合名)
+func (a Log) CollectionName() string {
+	if v := config.C.LogMongoHook.Collection; v != "" {
+		return v
+	}
+	return Model{}.CollectionName("logger")
+}
+
+// ToSchemaLog 转换为系统日志对象
+func (a Log) ToSchemaLog() *schema.Log {
+	return &schema.Log{
+		ID:           a.ID.Hex(),
+		Level:        a.Level,
+		Message:      a.Message,
+		TraceID:      a.TraceID,
+		UserID:       a.UserID,
+		SpanTitle:    a.SpanTitle,
+		SpanFunction: a.SpanFunction,
+		Data:         a.Data,
+		Version:      a.Version,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// Logs 系统日志实体列表
+type Logs []*Log
+
+// ToSchemaLogs 转换为系统日志对象列表
+func (a Logs) ToSchemaLogs() []*schema.Log {
+	list := make([]*schema.Log, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaLog()
+	}
+	return list
+}
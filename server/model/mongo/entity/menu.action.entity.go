@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetMenuActionCollection 菜单动作
+func GetMenuActionCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, MenuAction{}.CollectionName())
+}
+
+// SchemaMenuAction 菜单动作
+type SchemaMenuAction schema.MenuAction
+
+// ToMenuAction 转换为菜单动作实体
+func (a SchemaMenuAction) ToMenuAction() *MenuAction {
+	item := new(MenuAction)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// MenuAction 菜单动作实体
+type MenuAction struct {
+	Model  `bson:",inline"`
+	MenuID string `bson:"menu_id"` // 菜单ID
+	Code   string `bson:"code"`    // 动作编号
+	Name   string `bson:"name"`    // 动作名称
+}
+
+// TenantShared 菜单动作为全局数据(不按租户隔离)
+func (a MenuAction) TenantShared() bool {
+	return true
+}
+
+// CollectionName 集合名
+func (a MenuAction) CollectionName() string {
+	return a.Model.CollectionName("menu_action")
+}
+
+// CreateIndexes 创建索引
+func (a MenuAction) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "menu_id")
+}
+
+// ToSchemaMenuAction 转换为菜单动作对象
+func (a MenuAction) ToSchemaMenuAction() *schema.MenuAction {
+	item := new(schema.MenuAction)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// MenuActions 菜单动作列表
+type MenuActions []*MenuAction
+
+// ToSchemaMenuActions 转换为菜单动作对象列表
+func (a MenuActions) ToSchemaMenuActions() []*schema.MenuAction {
+	list := make([]*schema.MenuAction, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaMenuAction()
+	}
+	return list
+}
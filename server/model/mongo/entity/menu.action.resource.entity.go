@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetMenuActionResourceCollection 菜单动作关联资源
+func GetMenuActionResourceCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, MenuActionResource{}.CollectionName())
+}
+
+// SchemaMenuActionResource 菜单动作关联资源
+type SchemaMenuActionResource schema.MenuActionResource
+
+// ToMenuActionResource 转换为菜单动作关联资源实体
+func (a SchemaMenuActionResource) ToMenuActionResource() *MenuActionResource {
+	item := new(MenuActionResource)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// MenuActionResource 菜单动作关联资源实体
+type MenuActionResource struct {
+	Model    `bson:",inline"`
+	ActionID string `bson:"action_id"` // 菜单动作ID
+	Method   string `bson:"method"`    // 资源请求方式(支持正则)
+	Path     string `bson:"path"`      // 资源请求路径（支持/:id匹配）
+}
+
+// TenantShared 菜单资源为全局数据(不按租户隔离)
+func (a MenuActionResource) TenantShared() bool {
+	return true
+}
+
+// CollectionName 集合名
+func (a MenuActionResource) CollectionName() string {
+	return a.Model.CollectionName("menu_action_resource")
+}
+
+// CreateIndexes 创建索引
+func (a MenuActionResource) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "action_id")
+}
+
+// ToSchemaMenuActionResource 转换为菜单动作关联资源对象
+func (a MenuActionResource) ToSchemaMenuActionResource() *schema.MenuActionResource {
+	item := new(schema.MenuActionResource)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// MenuActionResources 菜单动作关联资源列表
+type MenuActionResources []*MenuActionResource
+
+// ToSchemaMenuActionResources 转换为菜单动作关联资源对象列表
+func (a MenuActionResources) ToSchemaMenuActionResources() []*schema.MenuActionResource {
+	list := make([]*schema.MenuActionResource, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaMenuActionResource()
+	}
+	return list
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetMenuCollection 获取菜单存储
+func GetMenuCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, Menu{}.CollectionName())
+}
+
+// SchemaMenu 菜单对象
+type SchemaMenu schema.Menu
+
+// ToMenu 转换为菜单实体
+func (a SchemaMenu) ToMenu() *Menu {
+	item := new(Menu)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Menu 菜单实体
+type Menu struct {
+	Model      `bson:",inline"`
+	Name       string  `bson:"name"`        // 菜单名称
+	Sequence   int     `bson:"sequence"`    // 排序值
+	Icon       *string `bson:"icon"`        // 菜单图标
+	Router     *string `bson:"router"`      // 访问路由
+	ParentID   *string `bson:"parent_id"`   // 父级内码
+	ParentPath *string `bson:"parent_path"` // 父级路径
+	ShowStatus int     `bson:"show_status"` // 状态(1:显示 2:隐藏)
+	Status     int     `bson:"status"`      // 状态(1:启用 2:禁用)
+	Memo       *string `bson:"memo"`        // 备注
+	Creator    string  `bson:"creator"`     // 创建人
+}
+
+// TenantShared 菜单为全局数据(不按租户隔离)
+func (a Menu) TenantShared() bool {
+	return true
+}
+
+// CollectionName 集合名
+func (a Menu) CollectionName() string {
+	return a.Model.CollectionName("menu")
+}
+
+// CreateIndexes 创建索引
+func (a Menu) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "name", "sequence", "parent_id", "parent_path", "show_status", "status")
+}
+
+// ToSchemaMenu 转换为菜单对象
+func (a Menu) ToSchemaMenu() *schema.Menu {
+	item := new(schema.Menu)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Menus 菜单实体列表
+type Menus []*Menu
+
+// ToSchemaMenus 转换为菜单对象列表
+func (a Menus) ToSchemaMenus() []*schema.Menu {
+	list := make([]*schema.Menu, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaMenu()
+	}
+	return list
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetOutboxCollection 获取事件发件箱存储
+func GetOutboxCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, Outbox{}.CollectionName())
+}
+
+// SchemaEvent 领域事件对象
+type SchemaEvent schema.Event
+
+// ToOutbox 转换为事件发件箱实体
+func (a SchemaEvent) ToOutbox() *Outbox {
+	item := new(Outbox)
+	util.StructMapToStruct(a, item)
+	item.Status = int(a.Status)
+	item.Payload = string(a.Payload)
+	return item
+}
+
+// Outbox 事件发件箱实体
+type Outbox struct {
+	Model       `bson:",inline"`
+	Type        string    `bson:"type"`          // 事件类型
+	AggregateID string    `bson:"aggregate_id"`  // 聚合(实体)ID
+	Payload     string    `bson:"payload"`       // 事件数据(JSON)
+	ActorID     string    `bson:"actor_id"`      // 操作人
+	TraceID     string    `bson:"trace_id"`      // 追踪ID
+	Status      int       `bson:"status"`        // 投递状态(1:待投递 2:已投递 3:超过最大重试次数)
+	Attempts    int       `bson:"attempts"`      // 投递次数
+	LastError   string    `bson:"last_error"`    // 最后一次投递错误
+	SentSinks   []string  `bson:"sent_sinks"`    // 已投递成功的目标
+	NextRetryAt time.Time `bson:"next_retry_at"` // 下次投递时间
+}
+
+// CollectionName 集合名
+func (a Outbox) CollectionName() string {
+	return a.Model.CollectionName("outbox")
+}
+
+// CreateIndexes 创建索引
+func (a Outbox) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "type", "aggregate_id", "status", "next_retry_at")
+}
+
+// ToSchemaEvent 转换为领域事件对象
+func (a Outbox) ToSchemaEvent() *schema.Event {
+	item := new(schema.Event)
+	util.StructMapToStruct(a, item)
+	item.Status = schema.EventStatus(a.Status)
+	item.Payload = []byte(a.Payload)
+	return item
+}
+
+// Outboxes 事件发件箱实体列表
+type Outboxes []*Outbox
+
+// ToSchemaEvents 转换为领域事件对象列表
+func (a Outboxes) ToSchemaEvents() []*schema.Event {
+	list := make([]*schema.Event, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaEvent()
+	}
+	return list
+}
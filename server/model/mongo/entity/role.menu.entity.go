@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetRoleMenuCollection 角色菜单
+func GetRoleMenuCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, RoleMenu{}.CollectionName())
+}
+
+// SchemaRoleMenu 角色菜单
+type SchemaRoleMenu schema.RoleMenu
+
+// ToRoleMenu 转换为角色菜单实体
+func (a SchemaRoleMenu) ToRoleMenu() *RoleMenu {
+	item := new(RoleMenu)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// RoleMenu 角色菜单实体
+type RoleMenu struct {
+	Model    `bson:",inline"`
+	RoleID   string `bson:"role_id"`   // 角色ID
+	MenuID   string `bson:"menu_id"`   // 菜单ID
+	ActionID string `bson:"action_id"` // 动作ID
+}
+
+// CollectionName 集合名
+func (a RoleMenu) CollectionName() string {
+	return a.Model.CollectionName("role_menu")
+}
+
+// CreateIndexes 创建索引
+func (a RoleMenu) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "role_id", "menu_id", "action_id")
+}
+
+// ToSchemaRoleMenu 转换为角色菜单对象
+func (a RoleMenu) ToSchemaRoleMenu() *schema.RoleMenu {
+	item := new(schema.RoleMenu)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// RoleMenus 角色菜单列表
+type RoleMenus []*RoleMenu
+
+// ToSchemaRoleMenus 转换为角色菜单对象列表
+func (a RoleMenus) ToSchemaRoleMenus() []*schema.RoleMenu {
+	list := make([]*schema.RoleMenu, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaRoleMenu()
+	}
+	return list
+}
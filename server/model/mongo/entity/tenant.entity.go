@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetTenantCollection 获取租户存储
+func GetTenantCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, Tenant{}.CollectionName())
+}
+
+// SchemaTenant 租户对象
+type SchemaTenant schema.Tenant
+
+// ToTenant 转换为租户实体
+func (a SchemaTenant) ToTenant() *Tenant {
+	item := new(Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenant 租户实体
+type Tenant struct {
+	Model   `bson:",inline"`
+	Code    string  `bson:"code"`     // 租户编号
+	Name    string  `bson:"name"`     // 租户名称
+	Domain  string  `bson:"domain"`   // 绑定的域名
+	Status  int     `bson:"status"`   // 状态(1:启用 2:禁用)
+	Memo    *string `bson:"memo"`     // 备注
+	AdminID string  `bson:"admin_id"` // 租户管理员
+	Creator string  `bson:"creator"`  // 创建者
+}
+
+// TenantShared 租户为全局数据(不按租户隔离)
+func (a Tenant) TenantShared() bool {
+	return true
+}
+
+// CollectionName 集合名
+func (a Tenant) CollectionName() string {
+	return a.Model.CollectionName("tenant")
+}
+
+// CreateIndexes 创建索引
+func (a Tenant) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "code", "name", "domain", "status")
+}
+
+// ToSchemaTenant 转换为租户对象
+func (a Tenant) ToSchemaTenant() *schema.Tenant {
+	item := new(schema.Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenants 租户实体列表
+type Tenants []*Tenant
+
+// ToSchemaTenants 转换为租户对象列表
+func (a Tenants) ToSchemaTenants() []*schema.Tenant {
+	list := make([]*schema.Tenant, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaTenant()
+	}
+	return list
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetUserCollection 获取用户存储
+func GetUserCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, User{}.CollectionName())
+}
+
+// SchemaUser 用户对象
+type SchemaUser schema.User
+
+// ToUser 转换为用户实体
+func (a SchemaUser) ToUser() *User {
+	item := new(User)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// User 用户实体
+type User struct {
+	Model    `bson:",inline"`
+	UserName string  `bson:"user_name"` // 用户名
+	RealName string  `bson:"real_name"` // 真实姓名
+	Password string  `bson:"password"`  // 密码(sha1(md5(明文))加密)
+	Email    *string `bson:"email"`     // 邮箱
+	Phone    *string `bson:"phone"`     // 手机号
+	Status   int     `bson:"status"`    // 状态(1:启用 2:停用)
+	Creator  string  `bson:"creator"`   // 创建者
+}
+
+// CollectionName 集合名
+func (a User) CollectionName() string {
+	return a.Model.CollectionName("user")
+}
+
+// CreateIndexes 创建索引
+func (a User) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "user_name", "real_name", "email", "phone", "status")
+}
+
+// ToSchemaUser 转换为用户对象
+func (a User) ToSchemaUser() *schema.User {
+	item := new(schema.User)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Users 用户实体列表
+type Users []*User
+
+// ToSchemaUsers 转换为用户对象列表
+func (a Users) ToSchemaUsers() []*schema.User {
+	list := make([]*schema.User, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaUser()
+	}
+	return list
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetWebhookDeliveryCollection 获取webhook投递记录存储
+func GetWebhookDeliveryCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, WebhookDelivery{}.CollectionName())
+}
+
+// SchemaWebhookDelivery webhook投递记录对象
+type SchemaWebhookDelivery schema.WebhookDelivery
+
+// ToWebhookDelivery 转换为webhook投递记录实体
+func (a SchemaWebhookDelivery) ToWebhookDelivery() *WebhookDelivery {
+	item := new(WebhookDelivery)
+	util.StructMapToStruct(a, item)
+	item.Status = int(a.Status)
+	item.Payload = string(a.Payload)
+	return item
+}
+
+// WebhookDelivery webhook投递记录实体
+type WebhookDelivery struct {
+	Model          `bson:",inline"`
+	WebhookID      string    `bson:"webhook_id"`      // webhook订阅ID
+	EventID        string    `bson:"event_id"`        // 事件ID
+	EventType      string    `bson:"event_type"`      // 事件类型
+	Payload        string    `bson:"payload"`         // 投递内容(JSON)
+	Status         int       `bson:"status"`          // 投递状态(1:待投递 2:投递成功 3:死信)
+	Attempts       int       `bson:"attempts"`        // 投递次数
+	ResponseStatus int       `bson:"response_status"` // 最后一次响应状态码
+	ResponseBody   string    `bson:"response_body"`   // 最后一次响应内容
+	LastError      string    `bson:"last_error"`      // 最后一次投递错误
+	NextRetryAt    time.Time `bson:"next_retry_at"`   // 下次投递时间
+}
+
+// CollectionName 集合名
+func (a WebhookDelivery) CollectionName() string {
+	return a.Model.CollectionName("webhook_delivery")
+}
+
+// CreateIndexes 创建索引
+func (a WebhookDelivery) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "webhook_id", "event_id", "event_type", "status", "next_retry_at")
+}
+
+// ToSchemaWebhookDelivery 转换为webhook投递记录对象
+func (a WebhookDelivery) ToSchemaWebhookDelivery() *schema.WebhookDelivery {
+	item := new(schema.WebhookDelivery)
+	util.StructMapToStruct(a, item)
+	item.Status = schema.WebhookDeliveryStatus(a.Status)
+	item.Payload = []byte(a.Payload)
+	return item
+}
+
+// WebhookDeliveries webhook投递记录实体列表
+type WebhookDeliveries []*WebhookDelivery
+
+// ToSchemaWebhookDeliveries 转换为webhook投递记录对象列表
+func (a WebhookDeliveries) ToSchemaWebhookDeliveries() []*schema.WebhookDelivery {
+	list := make([]*schema.WebhookDelivery, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaWebhookDelivery()
+	}
+	return list
+}
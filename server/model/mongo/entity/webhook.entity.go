@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"context"
+	"strings"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetWebhookCollection 获取webhook订阅存储
+func GetWebhookCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return GetCollection(ctx, cli, Webhook{}.CollectionName())
+}
+
+// SchemaWebhook webhook订阅对象
+type SchemaWebhook schema.Webhook
+
+// ToWebhook 转换为webhook订阅实体
+func (a SchemaWebhook) ToWebhook() *Webhook {
+	item := new(Webhook)
+	util.StructMapToStruct(a, item)
+	item.Events = strings.Join(a.Events, ",")
+	return item
+}
+
+// Webhook webhook订阅实体
+type Webhook struct {
+	Model   `bson:",inline"`
+	Name    string  `bson:"name"`    // 名称
+	URL     string  `bson:"url"`     // 投递地址
+	Secret  string  `bson:"secret"`  // 签名密钥
+	Events  string  `bson:"events"`  // 订阅的事件类型(逗号分隔)
+	Status  int     `bson:"status"`  // 状态(1:启用 2:禁用)
+	Memo    *string `bson:"memo"`    // 备注
+	Creator string  `bson:"creator"` // 创建者
+}
+
+// CollectionName 集合名
+func (a Webhook) CollectionName() string {
+	return a.Model.CollectionName("webhook")
+}
+
+// CreateIndexes 创建索引
+func (a Webhook) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a.CollectionName(), "name", "status")
+}
+
+// ToSchemaWebhook 转换为webhook订阅对象
+func (a Webhook) ToSchemaWebhook() *schema.Webhook {
+	item := new(schema.Webhook)
+	util.StructMapToStruct(a, item)
+	item.Events = nil
+	if a.Events != "" {
+		item.Events = strings.Split(a.Events, ",")
+	}
+	return item
+}
+
+// Webhooks webhook订阅实体列表
+type Webhooks []*Webhook
+
+// ToSchemaWebhooks 转换为webhook订阅对象列表
+func (a Webhooks) ToSchemaWebhooks() []*schema.Webhook {
+	list := make([]*schema.Webhook, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaWebhook()
+	}
+	return list
+}
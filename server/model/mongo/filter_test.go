@@ -0,0 +1,62 @@
+package mongo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/key7men/mag/server/model/mongo/dao"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"github.com/key7men/mag/server/schema"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// 过滤条件按实体字段类型转换，非法的字段及操作符被拒绝(无需连接mongo)
+func TestWrapFilterQuery(t *testing.T) {
+	filter := func(key string, op schema.FilterOperator, values ...string) *schema.FilterField {
+		return &schema.FilterField{Key: key, Operator: op, Values: values}
+	}
+
+	for _, c := range []struct {
+		name   string
+		filter *schema.FilterField
+		want   bson.M // 为空时期望返回错误
+	}{
+		{"eq", filter("code", schema.FilterEQ, "D001"), bson.M{"code": bson.M{"$eq": "D001"}}},
+		{"ne int", filter("status", schema.FilterNE, "1"), bson.M{"status": bson.M{"$ne": 1}}},
+		{"in int", filter("status", schema.FilterIn, "1", "2"), bson.M{"status": bson.M{"$in": bson.A{1, 2}}}},
+		{"like", filter("name", schema.FilterLike, "a.b"), bson.M{"name": dao.RegexFilter("a.b")}},
+		{"id", filter("id", schema.FilterEQ, "x"), bson.M{"_id": bson.M{"$eq": "x"}}},
+		{"pointer", filter("memo", schema.FilterEQ, "m"), bson.M{"memo": bson.M{"$eq": "m"}}},
+		{"invalid int", filter("status", schema.FilterEQ, "abc"), nil},
+		{"invalid time", filter("created_at", schema.FilterGT, "yesterday"), nil},
+		{"injected column", filter("code;drop", schema.FilterEQ, "D001"), nil},
+		{"operator column", filter("$where", schema.FilterEQ, "1"), nil},
+		{"nested column", filter("code.x", schema.FilterEQ, "D001"), nil},
+		{"upper case column", filter("Code", schema.FilterEQ, "D001"), nil},
+		{"unknown column", filter("missing", schema.FilterEQ, "D001"), nil},
+		{"unknown operator", filter("code", "regexp", "D001"), nil},
+		{"empty operator", filter("code", "", "D001"), nil},
+	} {
+		got, err := dao.WrapFilterQuery(bson.M{}, []*schema.FilterField{c.filter}, new(entity.Demos))
+		if c.want == nil {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", c.name, got)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+
+		want := bson.M{"$and": bson.A{c.want}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, want)
+		}
+	}
+
+	// 无过滤值时忽略
+	got, err := dao.WrapFilterQuery(bson.M{}, []*schema.FilterField{filter("code", schema.FilterEQ)}, new(entity.Demos))
+	if err != nil || len(got) != 0 {
+		t.Errorf("empty values: got %v, %v", got, err)
+	}
+}
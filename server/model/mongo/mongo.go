@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/server/model/mongo/entity"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Config 配置参数
+type Config struct {
+	URI     string
+	Timeout int
+}
+
+// NewClient 创建mongo客户端实例
+func NewClient(c *Config) (*mongo.Client, func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+
+	cli, err := mongo.Connect(ctx, options.Client().ApplyURI(c.URI))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanFunc := func() {
+		err := cli.Disconnect(context.Background())
+		if err != nil {
+			logger.Errorf(context.Background(), "Mongo client disconnect error: %s", err.Error())
+		}
+	}
+
+	err = cli.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return nil, cleanFunc, err
+	}
+
+	return cli, cleanFunc, nil
+}
+
+type indexer interface {
+	CreateIndexes(ctx context.Context, cli *mongo.Client) error
+}
+
+// CreateIndexes 创建集合索引
+func CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return createIndexes(ctx, cli,
+		new(entity.Audit),
+		new(entity.Demo),
+		new(entity.MenuAction),
+		new(entity.MenuActionResource),
+		new(entity.Menu),
+		new(entity.Outbox),
+		new(entity.RoleMenu),
+		new(entity.Role),
+		new(entity.Tenant),
+		new(entity.UserRole),
+		new(entity.User),
+		new(entity.WebhookDelivery),
+		new(entity.Webhook),
+	)
+}
+
+func createIndexes(ctx context.Context, cli *mongo.Client, items ...indexer) error {
+	for _, item := range items {
+		err := item.CreateIndexes(ctx, cli)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/model/modeltest"
+	imongo "github.com/key7men/mag/server/model/mongo"
+	"github.com/key7men/mag/server/model/mongo/dao"
+)
+
+// 设置环境变量 MAG_TEST_MONGO_URI 后运行(事务依赖副本集，如：mongodb://127.0.0.1:27017/?replicaSet=rs0)
+func TestMongo(t *testing.T) {
+	uri := os.Getenv("MAG_TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("MAG_TEST_MONGO_URI not set")
+	}
+
+	cli, cleanFunc, err := imongo.NewClient(&imongo.Config{URI: uri, Timeout: 10})
+	if err != nil {
+		if cleanFunc != nil {
+			cleanFunc()
+		}
+		t.Fatal(err)
+	}
+	defer cleanFunc()
+
+	var seq int
+	modeltest.Run(t, func(t *testing.T) (*modeltest.Models, func()) {
+		// 每个用例使用独立的数据库，用例结束后删除
+		seq++
+		config.C.Mongo.Database = fmt.Sprintf("mag_modeltest_%d_%d", time.Now().Unix(), seq)
+		config.C.Mongo.CollectionPrefix = ""
+
+		ctx := context.Background()
+		dropFunc := func() {
+			_ = cli.Database(config.C.Mongo.Database).Drop(ctx)
+		}
+		err := imongo.CreateIndexes(ctx, cli)
+		if err != nil {
+			dropFunc()
+			t.Fatal(err)
+		}
+
+		return &modeltest.Models{
+			Trans:              &dao.Trans{Client: cli},
+			Demo:               &dao.Demo{Client: cli},
+			User:               &dao.User{Client: cli},
+			UserRole:           &dao.UserRole{Client: cli},
+			Role:               &dao.Role{Client: cli},
+			RoleMenu:           &dao.RoleMenu{Client: cli},
+			Menu:               &dao.Menu{Client: cli},
+			MenuAction:         &dao.MenuAction{Client: cli},
+			MenuActionResource: &dao.MenuActionResource{Client: cli},
+			Tenant:             &dao.Tenant{Client: cli},
+			Outbox:             &dao.Outbox{Client: cli},
+		}, dropFunc
+	})
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IOutbox 事件发件箱存储
+type IOutbox interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.EventQueryParam, opts ...schema.EventQueryOptions) (*schema.EventQueryResult, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.Event) error
+	// 更新数据
+	Update(ctx context.Context, id string, item schema.Event) error
+	// 领取到期的待投递事件(将下次投递时间设为租约到期时间)，返回是否领取成功(已被其他实例领取时返回false)
+	Claim(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error)
+}
@@ -20,4 +20,8 @@ type IRoleMenu interface {
 	Delete(ctx context.Context, id string) error
 	// 根据角色ID删除数据
 	DeleteByRoleID(ctx context.Context, roleID string) error
+	// 根据菜单ID删除数据
+	DeleteByMenuID(ctx context.Context, menuID string) error
+	// 根据动作ID删除数据
+	DeleteByActionID(ctx context.Context, actionID string) error
 }
@@ -8,4 +8,6 @@ import (
 type ITrans interface {
 	// 执行事务
 	Exec(ctx context.Context, fn func(context.Context) error) error
+	// 在当前事务中以保存点执行(返回错误时仅回滚保存点之后的操作)
+	Savepoint(ctx context.Context, fn func(context.Context) error) error
 }
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IWebhookDelivery webhook投递记录存储
+type IWebhookDelivery interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.WebhookDeliveryQueryParam, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDeliveryQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, id string, opts ...schema.WebhookDeliveryQueryOptions) (*schema.WebhookDelivery, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.WebhookDelivery) error
+	// 更新数据(投递状态)
+	Update(ctx context.Context, id string, item schema.WebhookDelivery) error
+	// 根据webhook订阅ID删除数据
+	DeleteByWebhookID(ctx context.Context, webhookID string) error
+}
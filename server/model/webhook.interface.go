@@ -0,0 +1,23 @@
+package model
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// IWebhook webhook订阅存储
+type IWebhook interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.WebhookQueryParam, opts ...schema.WebhookQueryOptions) (*schema.WebhookQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, id string, opts ...schema.WebhookQueryOptions) (*schema.Webhook, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.Webhook) error
+	// 更新数据
+	Update(ctx context.Context, id string, item schema.Webhook) error
+	// 删除数据
+	Delete(ctx context.Context, id string) error
+	// 更新状态
+	UpdateStatus(ctx context.Context, id string, status int) error
+}
@@ -0,0 +1,237 @@
+package codegen
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/key7men/mag/server/module/openapi"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 生成文件的头部注释
+const generatedHeader = "Code generated by mag gen client. DO NOT EDIT."
+
+// 响应数据的类型
+type resultKind int
+
+const (
+	resultStatus resultKind = iota // 响应状态(OK)
+	resultObject                   // 响应对象
+	resultList                     // 列表数据
+	resultPage                     // 分页数据
+	resultBinary                   // 非JSON数据
+)
+
+// 查询参数
+type queryParam struct {
+	Key    string       // 参数名称
+	Name   string       // 字段名称(Go)
+	Type   reflect.Type // 参数类型
+	Filter bool         // 是否为通用过滤条件(filter[key][op]=value)
+}
+
+// 接口(由路由及接口文档转换，供各语言的生成器使用)
+type operation struct {
+	*openapi.Endpoint
+	Query  []*queryParam
+	Body   reflect.Type
+	Result reflect.Type
+	Kind   resultKind
+}
+
+// QueryName 查询参数结构体的名称
+func (a *operation) QueryName() string {
+	return a.Route.Name + "Query"
+}
+
+// 过滤条件的结构体名称
+const queryFilterName = "QueryFilter"
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	rawMessageType   = reflect.TypeOf(json.RawMessage{})
+	statusResultType = reflect.TypeOf(schema.StatusResult{})
+	stringType       = reflect.TypeOf("")
+	intType          = reflect.TypeOf(0)
+	boolType         = reflect.TypeOf(false)
+)
+
+func newOperations(endpoints []*openapi.Endpoint) []*operation {
+	ops := make([]*operation, len(endpoints))
+	for i, e := range endpoints {
+		op := &operation{Endpoint: e}
+		route := e.Route
+
+		if route.Query != nil {
+			for _, qf := range openapi.QueryFields(reflect.TypeOf(route.Query)) {
+				op.Query = append(op.Query, &queryParam{Key: qf.Name, Name: qf.Field.Name, Type: qf.Field.Type})
+			}
+		}
+		if wl := route.Whitelist; wl != nil {
+			if len(wl.Filters) > 0 {
+				op.Query = append(op.Query, &queryParam{Key: "filter", Name: "Filters", Filter: true})
+			}
+			if len(wl.Sorts) > 0 {
+				op.Query = append(op.Query, &queryParam{Key: "sort", Name: "Sort", Type: stringType})
+			}
+			if len(wl.Fields) > 0 {
+				op.Query = append(op.Query, &queryParam{Key: "fields", Name: "Fields", Type: stringType})
+			}
+		}
+		for _, p := range route.Params {
+			if p.In != "query" {
+				continue
+			}
+			t := stringType
+			if p.Schema != nil {
+				switch p.Schema.Type {
+				case "integer":
+					t = intType
+				case "boolean":
+					t = boolType
+				}
+			}
+			op.Query = append(op.Query, &queryParam{Key: p.Name, Name: exportName(p.Name), Type: t})
+		}
+
+		if route.Body != nil {
+			op.Body = indirect(reflect.TypeOf(route.Body))
+		}
+
+		switch v := route.Response.(type) {
+		case nil:
+			op.Kind = resultBinary
+		case openapi.ListOf:
+			op.Kind = resultList
+			if v.Paged {
+				op.Kind = resultPage
+			}
+			op.Result = indirect(reflect.TypeOf(v.Item))
+		default:
+			op.Result = indirect(reflect.TypeOf(v))
+			op.Kind = resultObject
+			if op.Result == statusResultType {
+				op.Kind = resultStatus
+			}
+		}
+		if route.ContentType != "" {
+			op.Kind = resultBinary
+		}
+		ops[i] = op
+	}
+	return ops
+}
+
+// 收集接口中使用的具名结构体(按名称排序，extra为额外包含的结构体)
+func collectTypes(ops []*operation, extra ...reflect.Type) []reflect.Type {
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		t = indirect(t)
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			walk(t.Elem())
+		case reflect.Struct:
+			if isBuiltinType(t) || seen[t] {
+				return
+			}
+			if t.Name() != "" {
+				seen[t] = true
+			}
+			for _, f := range structFields(t) {
+				walk(f.Type)
+			}
+		}
+	}
+
+	for _, op := range ops {
+		if op.Body != nil {
+			walk(op.Body)
+		}
+		if op.Result != nil && op.Kind != resultStatus {
+			walk(op.Result)
+		}
+	}
+	walk(reflect.TypeOf(schema.PaginationResult{}))
+	for _, t := range extra {
+		walk(t)
+	}
+
+	types := make([]reflect.Type, 0, len(seen))
+	for t := range seen {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name() < types[j].Name()
+	})
+	return types
+}
+
+// 结构体字段(按json标签，匿名嵌入的结构体展开)
+type structField struct {
+	Name      string       // 字段名称(Go)
+	JSONName  string       // json名称
+	OmitEmpty bool         // 是否为空时忽略
+	Type      reflect.Type // 字段类型
+}
+
+func structFields(t reflect.Type) []*structField {
+	var fields []*structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		if field.Anonymous && parts[0] == "" {
+			if ft := indirect(field.Type); ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		sf := &structField{Name: field.Name, JSONName: parts[0], Type: field.Type}
+		if sf.JSONName == "" {
+			sf.JSONName = field.Name
+		}
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				sf.OmitEmpty = true
+			}
+		}
+		fields = append(fields, sf)
+	}
+	return fields
+}
+
+func isBuiltinType(t reflect.Type) bool {
+	return t == timeType || t == rawMessageType
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// 导出名称(如roleIDs为RoleIDs，id为ID)
+func exportName(name string) string {
+	if strings.EqualFold(name, "id") {
+		return "ID"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// 首字母小写的名称(如QueryDemo为queryDemo)
+func lowerName(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,304 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"strings"
+
+	"github.com/key7men/mag/server/module/openapi"
+)
+
+// GoFile 生成的Go源文件
+type GoFile struct {
+	Name string // 文件名称
+	Data []byte // 文件内容
+}
+
+// Go 根据接口生成Go客户端包(client.go为运行时，api.go为数据结构及接口方法)
+func Go(endpoints []*openapi.Endpoint, pkg string) ([]*GoFile, error) {
+	runtime, err := format.Source([]byte(goRuntime(pkg)))
+	if err != nil {
+		return nil, err
+	}
+
+	g := &goGenerator{pkg: pkg, imports: make(map[string]bool)}
+	api, err := g.generate(newOperations(endpoints))
+	if err != nil {
+		return nil, err
+	}
+
+	return []*GoFile{
+		{Name: "client.go", Data: runtime},
+		{Name: "api.go", Data: api},
+	}, nil
+}
+
+type goGenerator struct {
+	pkg     string
+	imports map[string]bool
+	names   map[string]bool
+	buf     bytes.Buffer
+}
+
+func (g *goGenerator) generate(ops []*operation) ([]byte, error) {
+	var types []reflect.Type
+	for _, t := range collectTypes(ops) {
+		if !goRuntimeTypes[t] {
+			types = append(types, t)
+		}
+	}
+	g.names = make(map[string]bool, len(types))
+	for _, t := range types {
+		if g.names[t.Name()] || goRuntimeNames[t.Name()] {
+			return nil, fmt.Errorf("codegen: duplicate type name %s (%s)", t.Name(), t.PkgPath())
+		}
+		g.names[t.Name()] = true
+	}
+
+	for _, t := range types {
+		g.printf("// %s 对应服务端的%s\n", t.Name(), t.String())
+		g.printf("type %s %s\n\n", t.Name(), g.structType(t))
+	}
+
+	g.printf("// %s 通用过滤条件(filter[key][op]=value，未指定op时为eq)\n", queryFilterName)
+	g.printf("type %s struct {\nKey string\nOp string\nValue string\n}\n\n", queryFilterName)
+
+	pages := make(map[string]bool)
+	for _, op := range ops {
+		if op.Kind == resultPage && !pages[op.Result.Name()] {
+			pages[op.Result.Name()] = true
+			name := op.Result.Name() + "Page"
+			if err := g.reserve(name); err != nil {
+				return nil, err
+			}
+			g.printf("// %s %s的分页数据\n", name, op.Result.Name())
+			g.printf("type %s struct {\nList []*%s `json:\"list\"`\nPagination *PaginationResult `json:\"pagination,omitempty\"`\n}\n\n", name, op.Result.Name())
+		}
+	}
+
+	for _, op := range ops {
+		if len(op.Query) == 0 {
+			continue
+		}
+		if err := g.reserve(op.QueryName()); err != nil {
+			return nil, err
+		}
+		g.queryType(op)
+	}
+
+	for _, op := range ops {
+		if err := g.method(op); err != nil {
+			return nil, err
+		}
+	}
+
+	var head bytes.Buffer
+	fmt.Fprintf(&head, "// %s\n\npackage %s\n\nimport (\n\"context\"\n", generatedHeader, g.pkg)
+	for _, imp := range []string{"encoding/json", "io", "net/url", "time"} {
+		if g.imports[imp] {
+			fmt.Fprintf(&head, "%q\n", imp)
+		}
+	}
+	head.WriteString(")\n\n")
+	head.Write(g.buf.Bytes())
+
+	return format.Source(head.Bytes())
+}
+
+func (g *goGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// 生成的类型名称不能与数据结构重名
+func (g *goGenerator) reserve(name string) error {
+	if g.names[name] || goRuntimeNames[name] {
+		return fmt.Errorf("codegen: duplicate type name %s", name)
+	}
+	g.names[name] = true
+	return nil
+}
+
+func (g *goGenerator) structType(t reflect.Type) string {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, f := range structFields(t) {
+		tag := f.JSONName
+		if f.OmitEmpty {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:%q`\n", f.Name, g.typeOf(f.Type), tag)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// Go类型表达式(具名的基础类型及切片类型展开为底层类型)
+func (g *goGenerator) typeOf(t reflect.Type) string {
+	switch t {
+	case timeType:
+		g.imports["time"] = true
+		return "time.Time"
+	case rawMessageType:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeOf(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeOf(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeOf(t.Elem()))
+	case reflect.Map:
+		return "map[" + g.typeOf(t.Key()) + "]" + g.typeOf(t.Elem())
+	case reflect.Interface:
+		return "interface{}"
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structType(t)
+		}
+		return t.Name()
+	}
+	return t.Kind().String()
+}
+
+func (g *goGenerator) queryType(op *operation) {
+	g.printf("// %s %s的查询参数\n", op.QueryName(), op.Route.Name)
+	g.printf("type %s struct {\n", op.QueryName())
+	for _, p := range op.Query {
+		if p.Filter {
+			g.printf("%s []*%s\n", p.Name, queryFilterName)
+			continue
+		}
+		g.printf("%s %s\n", p.Name, g.typeOf(p.Type))
+	}
+	g.printf("}\n\n")
+
+	g.imports["net/url"] = true
+	g.printf("func (a *%s) values() url.Values {\nq := make(url.Values)\nif a == nil {\nreturn q\n}\n", op.QueryName())
+	for _, p := range op.Query {
+		if p.Filter {
+			g.printf("addFilters(q, a.%s)\n", p.Name)
+			continue
+		}
+		g.printf("addQuery(q, %q, a.%s)\n", p.Key, p.Name)
+	}
+	g.printf("return q\n}\n\n")
+}
+
+func (g *goGenerator) method(op *operation) error {
+	route := op.Route
+	params := []string{"ctx context.Context"}
+	for _, name := range op.PathParams {
+		params = append(params, lowerName(name)+" string")
+	}
+	query, body := "nil", "nil"
+	if len(op.Query) > 0 {
+		params = append(params, "query *"+op.QueryName())
+		query = "query.values()"
+	}
+	if op.Body != nil {
+		params = append(params, "body *"+g.typeOf(op.Body))
+		body = "body"
+	}
+	if field := route.Upload; field != "" {
+		g.imports["io"] = true
+		params = append(params, "filename string", "file io.Reader")
+		body = fmt.Sprintf("&fileUpload{field: %q, filename: filename, reader: file}", field)
+	}
+
+	var result, zero string
+	switch op.Kind {
+	case resultStatus:
+	case resultObject:
+		result, zero = "*"+g.typeOf(op.Result), "nil"
+	case resultList:
+		result, zero = "[]*"+g.typeOf(op.Result), "nil"
+	case resultPage:
+		result, zero = "*"+op.Result.Name()+"Page", "nil"
+	case resultBinary:
+		result, zero = "[]byte", "nil"
+	}
+
+	g.printf("// %s %s(%s %s)\n", route.Name, route.Summary, op.Method, op.Path)
+	if result == "" {
+		g.printf("func (c *Client) %s(%s) error {\n", route.Name, strings.Join(params, ", "))
+	} else {
+		g.printf("func (c *Client) %s(%s) (%s, error) {\n", route.Name, strings.Join(params, ", "), result)
+	}
+
+	call := fmt.Sprintf("c.do(ctx, %q, %s, %s, %s, %%s)", op.Method, g.pathExpr(op), query, body)
+	switch op.Kind {
+	case resultStatus:
+		if route.Token == openapi.TokenClear {
+			g.printf("if err := %s; err != nil {\nreturn err\n}\nc.SetToken(\"\")\nreturn nil\n}\n\n", fmt.Sprintf(call, "nil"))
+			return nil
+		}
+		g.printf("return %s\n}\n\n", fmt.Sprintf(call, "nil"))
+		return nil
+	case resultList:
+		g.printf("var result struct {\nList %s `json:\"list\"`\n}\n", result)
+		g.printf("if err := %s; err != nil {\nreturn nil, err\n}\nreturn result.List, nil\n}\n\n", fmt.Sprintf(call, "&result"))
+		return nil
+	case resultBinary:
+		g.printf("var result []byte\n")
+	default:
+		g.printf("result := new(%s)\n", strings.TrimPrefix(result, "*"))
+	}
+
+	target := "result"
+	if op.Kind == resultBinary {
+		target = "&result"
+	}
+	g.printf("if err := %s; err != nil {\nreturn %s, err\n}\n", fmt.Sprintf(call, target), zero)
+
+	switch route.Token {
+	case openapi.TokenSet:
+		if op.Kind != resultObject || !hasField(op.Result, "AccessToken") {
+			return fmt.Errorf("codegen: %s: token response must have an AccessToken field", route.Name)
+		}
+		g.printf("c.SetToken(result.AccessToken)\n")
+	case openapi.TokenClear:
+		g.printf("c.SetToken(\"\")\n")
+	}
+	g.printf("return result, nil\n}\n\n")
+	return nil
+}
+
+// 请求路径表达式(路由参数使用url.PathEscape转义)
+func (g *goGenerator) pathExpr(op *operation) string {
+	if len(op.PathParams) == 0 {
+		return fmt.Sprintf("%q", op.Path)
+	}
+
+	g.imports["net/url"] = true
+	var parts []string
+	var lit strings.Builder
+	for i, seg := range strings.Split(op.Path, "/") {
+		if i > 0 {
+			lit.WriteByte('/')
+		}
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			parts = append(parts, fmt.Sprintf("%q", lit.String()), "url.PathEscape("+lowerName(seg[1:])+")")
+			lit.Reset()
+			continue
+		}
+		lit.WriteString(seg)
+	}
+	if lit.Len() > 0 {
+		parts = append(parts, fmt.Sprintf("%q", lit.String()))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func hasField(t reflect.Type, name string) bool {
+	for _, f := range structFields(t) {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
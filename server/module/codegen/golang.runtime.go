@@ -0,0 +1,281 @@
+package codegen
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// 运行时中定义的名称(数据结构不能与之重名)
+var goRuntimeNames = map[string]bool{
+	"Client":         true,
+	"Option":         true,
+	"ResponseError":  true,
+	"FieldError":     true,
+	"New":            true,
+	"WithHTTPClient": true,
+	"WithToken":      true,
+	"QueryFilter":    true,
+}
+
+// 运行时中已定义的服务端数据结构(结构相同，直接使用运行时中的定义)
+var goRuntimeTypes = map[reflect.Type]bool{
+	reflect.TypeOf(schema.FieldError{}): true,
+}
+
+// 客户端运行时(结构体标签中的反引号以‵代替)
+func goRuntime(pkg string) string {
+	s := strings.Replace(goRuntimeSource, "{{package}}", pkg, 1)
+	return strings.Replace(s, "‵", "`", -1)
+}
+
+const goRuntimeSource = `// ` + generatedHeader + `
+
+package {{package}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client 接口客户端(并发安全，登录及刷新令牌后自动保存访问令牌)
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	mu         sync.RWMutex
+	token      string
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithHTTPClient 设定HTTP客户端
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken 设定访问令牌
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// New 创建客户端(baseURL如http://127.0.0.1:10088)
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token 获取访问令牌
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// SetToken 设定访问令牌(为空时不发送认证信息)
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// ResponseError 响应错误(兼容{"error":{...}}及application/problem+json两种格式)
+type ResponseError struct {
+	StatusCode int           // 响应状态码
+	Type       string        // 错误类型(URI)
+	Title      string        // 错误类型的简要说明
+	Code       int           // 错误码
+	ID         string        // 错误标识(多语言消息目录中的键)
+	Message    string        // 错误信息
+	TraceID    string        // 跟踪ID
+	Errors     []*FieldError // 字段校验错误
+}
+
+// FieldError 字段校验错误
+type FieldError struct {
+	Field   string ‵json:"field"‵   // 字段名称
+	Rule    string ‵json:"rule"‵    // 校验规则
+	Message string ‵json:"message"‵ // 错误信息
+}
+
+func (e *ResponseError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
+func decodeError(resp *http.Response) error {
+	e := &ResponseError{StatusCode: resp.StatusCode}
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil || len(buf) == 0 {
+		return e
+	}
+
+	var body struct {
+		Type    string        ‵json:"type"‵
+		Title   string        ‵json:"title"‵
+		Detail  string        ‵json:"detail"‵
+		Code    int           ‵json:"code"‵
+		TraceID string        ‵json:"trace_id"‵
+		Errors  []*FieldError ‵json:"errors"‵
+		Error   *struct {
+			Code    int    ‵json:"code"‵
+			ID      string ‵json:"id"‵
+			Message string ‵json:"message"‵
+		} ‵json:"error"‵
+	}
+	if err := json.Unmarshal(buf, &body); err != nil {
+		e.Message = strings.TrimSpace(string(buf))
+		return e
+	}
+
+	if body.Error != nil {
+		e.Code, e.ID, e.Message = body.Error.Code, body.Error.ID, body.Error.Message
+		return e
+	}
+	e.Type, e.Title, e.Code, e.Message = body.Type, body.Title, body.Code, body.Detail
+	e.TraceID, e.Errors = body.TraceID, body.Errors
+	if strings.HasPrefix(body.Type, "urn:mag:") {
+		e.ID = strings.TrimPrefix(body.Type, "urn:mag:")
+	}
+	return e
+}
+
+// 发送请求并解析响应数据(body为*fileUpload时上传文件，result为*[]byte时返回原始数据，为nil时忽略响应数据)
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var (
+		reader      io.Reader
+		contentType string
+	)
+	switch v := body.(type) {
+	case nil:
+	case *fileUpload:
+		buf, ct, err := v.encode()
+		if err != nil {
+			return err
+		}
+		reader, contentType = buf, ct
+	default:
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader, contentType = bytes.NewReader(buf), "application/json"
+	}
+
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+
+	switch v := result.(type) {
+	case nil:
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+		return err
+	case *[]byte:
+		*v, err = ioutil.ReadAll(resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// 上传文件的请求数据(multipart/form-data)
+type fileUpload struct {
+	field    string
+	filename string
+	reader   io.Reader
+}
+
+func (a *fileUpload) encode() (*bytes.Buffer, string, error) {
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+	fw, err := mw.CreateFormFile(a.field, a.filename)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(fw, a.reader); err != nil {
+		return nil, "", err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf, mw.FormDataContentType(), nil
+}
+
+// 添加查询参数(零值忽略，切片按多个同名参数添加)
+func addQuery(q url.Values, key string, value interface{}) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			addQuery(q, key, rv.Index(i).Interface())
+		}
+		return
+	}
+	if rv.IsZero() {
+		return
+	}
+
+	if t, ok := value.(time.Time); ok {
+		q.Add(key, t.Format(time.RFC3339))
+		return
+	}
+	q.Add(key, fmt.Sprint(value))
+}
+
+// 添加通用过滤条件(filter[key][op]=value)
+func addFilters(q url.Values, filters []*QueryFilter) {
+	for _, f := range filters {
+		key := "filter[" + f.Key + "]"
+		if f.Op != "" {
+			key += "[" + f.Op + "]"
+		}
+		q.Add(key, f.Value)
+	}
+}
+`
@@ -0,0 +1,283 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/key7men/mag/server/module/openapi"
+	"github.com/key7men/mag/server/schema"
+)
+
+// TSFiles 生成的TypeScript源文件
+type TSFiles struct {
+	Models  []byte // 数据结构(models.ts)
+	Service []byte // Angular接口服务(api.service.ts)
+}
+
+// TypeScript 根据接口生成TypeScript数据结构及Angular接口服务
+func TypeScript(endpoints []*openapi.Endpoint) (*TSFiles, error) {
+	ops := newOperations(endpoints)
+	types := collectTypes(ops,
+		reflect.TypeOf(schema.StatusResult{}),
+		reflect.TypeOf(schema.ErrorResult{}),
+		reflect.TypeOf(schema.ProblemResult{}),
+	)
+
+	names := make(map[string]bool, len(types))
+	for _, t := range types {
+		if names[t.Name()] {
+			return nil, fmt.Errorf("codegen: duplicate type name %s (%s)", t.Name(), t.PkgPath())
+		}
+		names[t.Name()] = true
+	}
+
+	models := tsModels(ops, types)
+	service, err := tsService(ops)
+	if err != nil {
+		return nil, err
+	}
+	return &TSFiles{Models: models, Service: service}, nil
+}
+
+func tsModels(ops []*operation, types []reflect.Type) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n", generatedHeader)
+
+	for _, t := range types {
+		fmt.Fprintf(&b, "\n/** 对应服务端的%s */\nexport interface %s {\n", t.String(), t.Name())
+		for _, f := range structFields(t) {
+			optional := ""
+			if f.OmitEmpty || f.Type.Kind() == reflect.Ptr {
+				optional = "?"
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", tsKey(f.JSONName), optional, tsType(f.Type))
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString("\n/** 列表数据(分页查询时包含分页信息) */\nexport interface ListResult<T> {\n  list: T[];\n  pagination?: PaginationResult;\n}\n")
+	fmt.Fprintf(&b, "\n/** 通用过滤条件(filter[key][op]=value，未指定op时为eq) */\nexport interface %s {\n  key: string;\n  op?: string;\n  value: string;\n}\n", queryFilterName)
+
+	for _, op := range ops {
+		if len(op.Query) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n/** %s的查询参数 */\nexport interface %s {\n", op.Route.Name, op.QueryName())
+		for _, p := range op.Query {
+			if p.Filter {
+				fmt.Fprintf(&b, "  %s?: %s[];\n", p.Key, queryFilterName)
+				continue
+			}
+			fmt.Fprintf(&b, "  %s?: %s;\n", tsKey(p.Key), tsType(p.Type))
+		}
+		b.WriteString("}\n")
+	}
+	return b.Bytes()
+}
+
+// TypeScript类型表达式
+func tsType(t reflect.Type) string {
+	switch t {
+	case timeType:
+		return "string"
+	case rawMessageType:
+		return "unknown"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return tsType(t.Elem())
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return tsType(t.Elem()) + "[]"
+	case reflect.Map:
+		return "{ [key: string]: " + tsType(t.Elem()) + " }"
+	case reflect.Struct:
+		if t.Name() != "" {
+			return t.Name()
+		}
+		var parts []string
+		for _, f := range structFields(t) {
+			parts = append(parts, fmt.Sprintf("%s: %s", tsKey(f.JSONName), tsType(f.Type)))
+		}
+		return "{ " + strings.Join(parts, "; ") + " }"
+	}
+	return "unknown"
+}
+
+// 非标识符的键使用引号
+func tsKey(key string) string {
+	for i, r := range key {
+		if r == '_' || r == '$' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || (i > 0 && '0' <= r && r <= '9') {
+			continue
+		}
+		return "'" + key + "'"
+	}
+	return key
+}
+
+func tsService(ops []*operation) ([]byte, error) {
+	imports := map[string]bool{"ListResult": true, queryFilterName: true}
+	var methods bytes.Buffer
+
+	for _, op := range ops {
+		route := op.Route
+		var params []string
+		for _, name := range op.PathParams {
+			params = append(params, lowerName(name)+": string")
+		}
+		if op.Body != nil {
+			params = append(params, "body: "+tsType(op.Body))
+			imports[tsType(op.Body)] = true
+		}
+		var pre string
+		if field := route.Upload; field != "" {
+			params = append(params, "file: File")
+			pre = fmt.Sprintf("    const form = new FormData();\n    form.append('%s', file);\n", field)
+		}
+		if len(op.Query) > 0 {
+			params = append(params, "query?: "+op.QueryName())
+			imports[op.QueryName()] = true
+		}
+
+		var result string
+		switch op.Kind {
+		case resultStatus:
+			result = "StatusResult"
+			imports[result] = true
+		case resultObject:
+			result = tsType(op.Result)
+			imports[result] = true
+		case resultList, resultPage:
+			result = "ListResult<" + tsType(op.Result) + ">"
+			imports[tsType(op.Result)] = true
+		case resultBinary:
+			result = "Blob"
+		}
+
+		var opts []string
+		if len(op.Query) > 0 {
+			opts = append(opts, "params: toParams(query)")
+		}
+		if op.Kind == resultBinary {
+			opts = append(opts, "responseType: 'blob'")
+		}
+
+		method := strings.ToLower(op.Method)
+		var args []string
+		args = append(args, tsPath(op))
+		switch op.Method {
+		case "POST", "PUT", "PATCH":
+			if op.Body != nil {
+				args = append(args, "body")
+			} else if pre != "" {
+				args = append(args, "form")
+			} else {
+				args = append(args, "null")
+			}
+		}
+		if len(opts) > 0 {
+			args = append(args, "{ "+strings.Join(opts, ", ")+" }")
+		}
+
+		generic := "<" + result + ">"
+		if op.Kind == resultBinary {
+			generic = ""
+		}
+		call := fmt.Sprintf("this.http.%s%s(%s)", method, generic, strings.Join(args, ", "))
+
+		switch route.Token {
+		case openapi.TokenSet:
+			if op.Kind != resultObject || !hasField(op.Result, "AccessToken") {
+				return nil, fmt.Errorf("codegen: %s: token response must have an AccessToken field", route.Name)
+			}
+			call = fmt.Sprintf("this.http\n      .%s%s(%s)\n      .pipe(tap((res) => this.tokenService.set({ token: res.access_token })))",
+				method, generic, strings.Join(args, ", "))
+		case openapi.TokenClear:
+			call += ".pipe(tap(() => this.tokenService.clear()))"
+		}
+
+		fmt.Fprintf(&methods, "\n  /** %s(%s %s) */\n", route.Summary, op.Method, op.Path)
+		fmt.Fprintf(&methods, "  %s(%s): Observable<%s> {\n%s    return %s;\n  }\n", lowerName(route.Name), strings.Join(params, ", "), result, pre, call)
+	}
+
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n", generatedHeader)
+	b.WriteString(`import { HttpClient, HttpParams } from '@angular/common/http';
+import { Inject, Injectable } from '@angular/core';
+import { DA_SERVICE_TOKEN, ITokenService } from '@delon/auth';
+import { Observable } from 'rxjs';
+import { tap } from 'rxjs/operators';
+`)
+	fmt.Fprintf(&b, "import {\n  %s,\n} from './models';\n", strings.Join(names, ",\n  "))
+	b.WriteString(`
+/** 转换查询参数(空值忽略，数组按多个同名参数添加，filter转换为filter[key][op]=value) */
+function toParams(query?: object): HttpParams {
+  let params = new HttpParams();
+  if (!query) {
+    return params;
+  }
+
+  Object.keys(query).forEach((key) => {
+    const value = (query as any)[key];
+    if (value === undefined || value === null || value === '') {
+      return;
+    }
+    if (key === 'filter') {
+      (value as QueryFilter[]).forEach((f) => {
+        params = params.append(f.op ? ` + "`filter[${f.key}][${f.op}]`" + ` : ` + "`filter[${f.key}]`" + `, f.value);
+      });
+      return;
+    }
+    (Array.isArray(value) ? value : [value]).forEach((v) => {
+      params = params.append(key, String(v));
+    });
+  });
+  return params;
+}
+
+/**
+ * 接口服务(登录及刷新令牌后自动保存访问令牌，请求时由JWTInterceptor添加认证信息)
+ */
+@Injectable({ providedIn: 'root' })
+export class ApiService {
+  constructor(private http: HttpClient, @Inject(DA_SERVICE_TOKEN) private tokenService: ITokenService) {}
+`)
+	b.Write(methods.Bytes())
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+// 请求路径表达式(路由参数使用encodeURIComponent转义)
+func tsPath(op *operation) string {
+	if len(op.PathParams) == 0 {
+		return "'" + op.Path + "'"
+	}
+
+	segs := strings.Split(op.Path, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segs[i] = "${encodeURIComponent(" + lowerName(seg[1:]) + ")}"
+		}
+	}
+	return "`" + strings.Join(segs, "/") + "`"
+}
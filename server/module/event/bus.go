@@ -0,0 +1,74 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// Sink 事件投递目标
+type Sink interface {
+	// 投递目标名称
+	Name() string
+	// 投递事件(返回错误时事件会被重新投递到该目标，需保证幂等)
+	Send(ctx context.Context, e *schema.Event) error
+}
+
+// HandlerFunc 进程内事件处理函数
+type HandlerFunc func(ctx context.Context, e *schema.Event) error
+
+var _ Sink = (*Bus)(nil)
+
+// NewBus 创建进程内事件总线
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]HandlerFunc),
+	}
+}
+
+// Bus 进程内事件总线
+type Bus struct {
+	lock     sync.RWMutex
+	handlers map[string][]HandlerFunc
+}
+
+// Subscribe 订阅事件(未指定事件类型时订阅全部事件)
+func (a *Bus) Subscribe(handler HandlerFunc, eventTypes ...string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if len(eventTypes) == 0 {
+		eventTypes = []string{"*"}
+	}
+	for _, t := range eventTypes {
+		a.handlers[t] = append(a.handlers[t], handler)
+	}
+}
+
+// Name 投递目标名称
+func (a *Bus) Name() string {
+	return "bus"
+}
+
+// Send 将事件分发给订阅者
+func (a *Bus) Send(ctx context.Context, e *schema.Event) error {
+	a.lock.RLock()
+	handlers := make([]HandlerFunc, 0, len(a.handlers[e.Type])+len(a.handlers["*"]))
+	handlers = append(handlers, a.handlers[e.Type]...)
+	handlers = append(handlers, a.handlers["*"]...)
+	a.lock.RUnlock()
+
+	var errs []string
+	for _, handler := range handlers {
+		if err := handler(ctx, e); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
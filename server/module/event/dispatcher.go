@@ -0,0 +1,214 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/key7men/mag/pkg/logger"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+)
+
+// Options 分发器参数
+type Options struct {
+	Interval         time.Duration // 发件箱轮询间隔
+	BatchSize        int           // 每次轮询投递的最大事件数量
+	MaxRetries       int           // 最大投递次数
+	RetryInterval    time.Duration // 重试间隔(按投递次数指数退避)
+	MaxRetryInterval time.Duration // 最大重试间隔
+	LeaseTimeout     time.Duration // 领取事件的租约时长(超过后未完成投递的事件可被重新领取)
+}
+
+func (o *Options) fill() {
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 10
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 5 * time.Second
+	}
+	if o.MaxRetryInterval < o.RetryInterval {
+		o.MaxRetryInterval = o.RetryInterval
+	}
+	if o.LeaseTimeout <= 0 {
+		o.LeaseTimeout = time.Minute
+	}
+}
+
+// NewDispatcher 创建事件分发器
+func NewDispatcher(outboxModel model.IOutbox, opts Options, sinks ...Sink) *Dispatcher {
+	opts.fill()
+	return &Dispatcher{
+		outboxModel: outboxModel,
+		opts:        opts,
+		sinks:       sinks,
+		notify:      make(chan struct{}, 1),
+	}
+}
+
+// Dispatcher 事件分发器(轮询发件箱表并投递到各个目标，投递失败时按退避策略仅重试失败的目标)
+// 投递前先领取事件，多个实例同时分发时同一事件只由一个实例投递；事件至少投递一次(租约到期后重新投递)，投递目标需以事件ID保证幂等
+type Dispatcher struct {
+	outboxModel model.IOutbox
+	opts        Options
+	sinks       []Sink
+	notify      chan struct{}
+	stop        chan struct{}
+	wg          sync.WaitGroup
+}
+
+// Start 启动分发
+func (a *Dispatcher) Start() {
+	a.stop = make(chan struct{})
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.run()
+	}()
+}
+
+// Stop 停止分发(等待当前批次投递完成)
+func (a *Dispatcher) Stop() {
+	if a.stop == nil {
+		return
+	}
+	close(a.stop)
+	a.wg.Wait()
+	a.stop = nil
+}
+
+// Notify 通知分发器立即轮询(在写入事件的事务提交后调用)
+func (a *Dispatcher) Notify() {
+	if a == nil {
+		return
+	}
+
+	select {
+	case a.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (a *Dispatcher) run() {
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := a.Dispatch(icontext.NewAllTenants(context.Background()))
+			if err != nil {
+				logger.Errorf(context.Background(), "Dispatch outbox events error: %s", err.Error())
+			}
+			if err != nil || n < a.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+		case <-a.notify:
+		}
+	}
+}
+
+// Dispatch 投递一批到期的待投递事件，返回本批次的事件数量
+func (a *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	result, err := a.outboxModel.Query(ctx, schema.EventQueryParam{
+		PaginationParam: schema.PaginationParam{
+			Pagination: true,
+			NoCount:    true,
+			Current:    1,
+			PageSize:   uint(a.opts.BatchSize),
+		},
+		Status:      schema.EventPending,
+		RetryBefore: time.Now(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range result.Data {
+		now := time.Now()
+		ok, err := a.outboxModel.Claim(ctx, item.ID, now, now.Add(a.opts.LeaseTimeout))
+		if err != nil {
+			return 0, err
+		} else if !ok {
+			continue
+		}
+
+		if err := a.dispatchEvent(item); err != nil {
+			return 0, err
+		}
+	}
+	return len(result.Data), nil
+}
+
+func (a *Dispatcher) dispatchEvent(item *schema.Event) error {
+	ctx := icontext.NewTraceID(context.Background(), item.TraceID)
+	ctx = icontext.NewUserID(ctx, item.ActorID)
+	ctx = logger.NewTraceIDContext(ctx, item.TraceID)
+	ctx = logger.NewUserIDContext(ctx, item.ActorID)
+	// 事件处理(如查询订阅的webhook)限定在事件所属的租户内(平台事件的租户ID为空)
+	ctx = icontext.NewTenantID(ctx, item.TenantID)
+
+	sent := make(map[string]bool, len(item.SentSinks))
+	for _, name := range item.SentSinks {
+		sent[name] = true
+	}
+
+	var errs []string
+	for _, sink := range a.sinks {
+		if sent[sink.Name()] {
+			continue
+		}
+		if err := sink.Send(ctx, item); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", sink.Name(), err.Error()))
+			continue
+		}
+		item.SentSinks = append(item.SentSinks, sink.Name())
+	}
+
+	item.Attempts++
+	if len(errs) == 0 {
+		item.Status = schema.EventDispatched
+		item.LastError = ""
+	} else {
+		item.LastError = strings.Join(errs, "; ")
+		if len(item.LastError) > 1024 {
+			item.LastError = item.LastError[:1024]
+		}
+
+		if item.Attempts >= a.opts.MaxRetries {
+			item.Status = schema.EventDead
+			logger.Errorf(ctx, "Event %s(%s) dispatch failed after %d attempts: %s", item.ID, item.Type, item.Attempts, item.LastError)
+		} else {
+			item.NextRetryAt = time.Now().Add(Backoff(item.Attempts, a.opts.RetryInterval, a.opts.MaxRetryInterval))
+			logger.Warnf(ctx, "Event %s(%s) dispatch failed, will retry at %s: %s", item.ID, item.Type, item.NextRetryAt.Format(time.RFC3339), item.LastError)
+		}
+	}
+
+	return a.outboxModel.Update(ctx, item.ID, *item)
+}
+
+// Backoff 按投递次数计算指数退避的重试间隔(不超过最大重试间隔)
+func Backoff(attempts int, interval, maxInterval time.Duration) time.Duration {
+	d := interval
+	for i := 1; i < attempts && d < maxInterval; i++ {
+		d *= 2
+	}
+	if d > maxInterval {
+		d = maxInterval
+	}
+	return d
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/key7men/mag/pkg/trace"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ Sink = (*RedisStreamSink)(nil)
+
+// NewRedisStreamSink 创建redis stream投递目标
+func NewRedisStreamSink(cli *redis.Client, stream string, maxLen int64) *RedisStreamSink {
+	return &RedisStreamSink{
+		cli:    cli,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+// RedisStreamSink 将事件追加到redis stream
+type RedisStreamSink struct {
+	cli    *redis.Client
+	stream string
+	maxLen int64
+}
+
+// Name 投递目标名称
+func (a *RedisStreamSink) Name() string {
+	return "redis_stream"
+}
+
+// Send 投递事件
+func (a *RedisStreamSink) Send(ctx context.Context, e *schema.Event) error {
+	return trace.RedisWithContext(ctx, a.cli).XAdd(&redis.XAddArgs{
+		Stream:       a.stream,
+		MaxLenApprox: a.maxLen,
+		Values: map[string]interface{}{
+			"id":           e.ID,
+			"type":         e.Type,
+			"aggregate_id": e.AggregateID,
+			"payload":      string(e.Payload),
+			"actor_id":     e.ActorID,
+			"trace_id":     e.TraceID,
+			"created_at":   e.CreatedAt.Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
+
+// Close 关闭redis连接
+func (a *RedisStreamSink) Close() error {
+	return a.cli.Close()
+}
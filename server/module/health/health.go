@@ -0,0 +1,155 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/key7men/mag/server/schema"
+)
+
+// 定义错误
+var (
+	ErrShuttingDown = errors.New("server is shutting down")
+	ErrNotDone      = errors.New("not completed yet")
+)
+
+// CheckFunc 依赖检查函数
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// New 创建健康检查(timeout为单个依赖检查的超时时间)
+func New(timeout time.Duration) *Health {
+	if timeout <= 0 {
+		timeout = 3 * time.Second
+	}
+	return &Health{
+		timeout: timeout,
+		tasks:   make(map[string]*int32),
+	}
+}
+
+// Health 存活及就绪检查
+type Health struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	checks       []check
+	tasks        map[string]*int32
+	shuttingDown int32
+}
+
+// Register 注册就绪检查的依赖
+func (a *Health) Register(name string, fn CheckFunc) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.checks = append(a.checks, check{name: name, fn: fn})
+}
+
+// Wait 注册需要等待完成的启动任务(完成前就绪检查失败)
+func (a *Health) Wait(name string) {
+	done := new(int32)
+
+	a.mu.Lock()
+	a.tasks[name] = done
+	a.mu.Unlock()
+
+	a.Register(name, func(context.Context) error {
+		if atomic.LoadInt32(done) == 0 {
+			return ErrNotDone
+		}
+		return nil
+	})
+}
+
+// Done 标记启动任务已完成
+func (a *Health) Done(name string) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if done, ok := a.tasks[name]; ok {
+		atomic.StoreInt32(done, 1)
+	}
+}
+
+// Shutdown 标记服务正在关闭(之后就绪检查均失败)
+func (a *Health) Shutdown() {
+	atomic.StoreInt32(&a.shuttingDown, 1)
+}
+
+// Live 存活检查(仅检查进程可以响应)
+func (a *Health) Live() *schema.HealthResult {
+	return &schema.HealthResult{Status: schema.OKStatus}
+}
+
+// Ready 就绪检查(并发执行各依赖检查，任一失败则整体失败)
+func (a *Health) Ready(ctx context.Context) *schema.HealthResult {
+	a.mu.RLock()
+	checks := make([]check, len(a.checks))
+	copy(checks, a.checks)
+	a.mu.RUnlock()
+
+	result := &schema.HealthResult{
+		Status: schema.OKStatus,
+		Checks: make([]*schema.HealthCheck, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			result.Checks[i] = a.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&a.shuttingDown) == 1 {
+		result.Checks = append(result.Checks, &schema.HealthCheck{
+			Name:   "shutdown",
+			Status: schema.FailStatus,
+			Error:  ErrShuttingDown.Error(),
+		})
+	}
+
+	for _, item := range result.Checks {
+		if item.Status != schema.OKStatus {
+			result.Status = schema.FailStatus
+			break
+		}
+	}
+	return result
+}
+
+func (a *Health) run(ctx context.Context, c check) *schema.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	item := &schema.HealthCheck{
+		Name:     c.name,
+		Status:   schema.OKStatus,
+		Duration: time.Since(start).Nanoseconds() / 1e6,
+	}
+	if err != nil {
+		item.Status = schema.FailStatus
+		item.Error = err.Error()
+	}
+	return item
+}
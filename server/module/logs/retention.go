@@ -0,0 +1,209 @@
+package logs
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/key7men/mag/pkg/errs"
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+)
+
+// Options 日志保留策略参数
+type Options struct {
+	Interval   time.Duration // 清理间隔
+	MaxAge     time.Duration // 日志保留时长
+	BatchSize  int           // 每批清理的最大条数
+	ArchiveDir string        // 归档目录(为空时直接删除，否则先写入归档文件再删除)
+}
+
+func (o *Options) fill() {
+	if o.Interval <= 0 {
+		o.Interval = time.Hour
+	}
+	if o.MaxAge <= 0 {
+		o.MaxAge = 30 * 24 * time.Hour
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+}
+
+// NewRetention 创建日志清理任务
+func NewRetention(logModel model.ILog, opts Options) *Retention {
+	opts.fill()
+	return &Retention{
+		logModel: logModel,
+		opts:     opts,
+	}
+}
+
+// Retention 日志清理任务(定期删除或归档超过保留时长的日志)
+type Retention struct {
+	logModel model.ILog
+	opts     Options
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// Start 启动清理
+func (a *Retention) Start() {
+	a.stop = make(chan struct{})
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.run()
+	}()
+}
+
+// Stop 停止清理(等待当前批次完成)
+func (a *Retention) Stop() {
+	if a.stop == nil {
+		return
+	}
+	close(a.stop)
+	a.wg.Wait()
+	a.stop = nil
+}
+
+func (a *Retention) run() {
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := a.Run(context.Background())
+		if err != nil {
+			logger.Errorf(context.Background(), "Clean expired logs error: %s", err.Error())
+		} else if n > 0 {
+			logger.Infof(context.Background(), "Cleaned %d expired logs", n)
+		}
+
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run 执行一次清理，返回清理的日志数量
+func (a *Retention) Run(ctx context.Context) (int, error) {
+	before := time.Now().Add(-a.opts.MaxAge)
+
+	var archive *archiveWriter
+	defer func() {
+		if archive != nil {
+			if err := archive.Close(); err != nil {
+				logger.Errorf(ctx, "Close log archive error: %s", err.Error())
+			}
+		}
+	}()
+
+	var total int
+	for {
+		select {
+		case <-a.stop:
+			return total, nil
+		default:
+		}
+
+		result, err := a.logModel.Query(ctx, schema.LogQueryParam{
+			PaginationParam: schema.PaginationParam{
+				Pagination: true,
+				NoCount:    true,
+				Current:    1,
+				PageSize:   uint(a.opts.BatchSize),
+			},
+			Before: before,
+		}, schema.LogQueryOptions{
+			OrderFields: schema.NewOrderFields(
+				schema.NewOrderField("created_at", schema.OrderByASC),
+				schema.NewOrderField("id", schema.OrderByASC),
+			),
+		})
+		if err != nil {
+			return total, err
+		} else if len(result.Data) == 0 {
+			return total, nil
+		}
+
+		if dir := a.opts.ArchiveDir; dir != "" {
+			if archive == nil {
+				archive, err = newArchiveWriter(dir, before)
+				if err != nil {
+					return total, err
+				}
+			}
+			// 归档写入成功后才删除，保证日志不丢失
+			if err := archive.Write(result.Data); err != nil {
+				return total, err
+			}
+		}
+
+		ids := make([]string, len(result.Data))
+		for i, item := range result.Data {
+			ids[i] = item.ID
+		}
+		if err := a.logModel.DeleteByIDs(ctx, ids); err != nil {
+			return total, err
+		}
+
+		total += len(ids)
+		if len(ids) < a.opts.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// archiveWriter 日志归档文件(gzip压缩，每行一条json格式的日志)
+type archiveWriter struct {
+	f  *os.File
+	gw *gzip.Writer
+}
+
+func newArchiveWriter(dir string, before time.Time) (*archiveWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	name := filepath.Join(dir, fmt.Sprintf("logs-%s-%d.jsonl.gz", before.Format("20060102150405"), time.Now().UnixNano()))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+
+	return &archiveWriter{f: f, gw: gzip.NewWriter(f)}, nil
+}
+
+func (a *archiveWriter) Write(items schema.Logs) error {
+	for _, item := range items {
+		buf, err := util.JSONMarshal(item)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		if _, err := a.gw.Write(append(buf, '\n')); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+
+	// 刷新至文件，保证删除前已写入的日志可被恢复
+	if err := a.gw.Flush(); err != nil {
+		return errs.WithStack(err)
+	}
+	return errs.WithStack(a.f.Sync())
+}
+
+func (a *archiveWriter) Close() error {
+	if err := a.gw.Close(); err != nil {
+		_ = a.f.Close()
+		return err
+	}
+	return a.f.Close()
+}
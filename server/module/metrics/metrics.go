@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mag"
+
+// 指标注册表(包含go运行时及进程指标)
+var registry = prometheus.NewRegistry()
+
+// 定义指标
+var (
+	// HTTPRequests HTTP请求数(按路由模板及状态码)
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration HTTP请求耗时(按路由模板)
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// AuthFailures 认证失败数(按原因)
+	AuthFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "failures_total",
+		Help:      "Number of failed authentications by reason.",
+	}, []string{"reason"})
+
+	// CasbinDenials casbin拒绝访问数(按路由模板)
+	CasbinDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "casbin",
+		Name:      "denials_total",
+		Help:      "Number of requests denied by casbin by method and route template.",
+	}, []string{"method", "route"})
+
+	// CasbinLoadDuration casbin策略加载耗时
+	CasbinLoadDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "casbin",
+		Name:      "policy_load_duration_seconds",
+		Help:      "Duration of casbin policy loads.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// RateLimitRejections 限流拒绝数(按策略)
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ratelimit",
+		Name:      "rejections_total",
+		Help:      "Number of requests rejected by the rate limiter by policy.",
+	}, []string{"policy"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		AuthFailures,
+		CasbinDenials,
+		CasbinLoadDuration,
+		RateLimitRejections,
+	)
+}
+
+// Handler 指标输出
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Register 注册指标收集器(重复注册时忽略)
+func Register(c prometheus.Collector) error {
+	if err := registry.Register(c); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterDB 注册数据库连接池指标
+func RegisterDB(name string, db *sql.DB) error {
+	return Register(newDBStatsCollector(name, db))
+}
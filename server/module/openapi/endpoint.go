@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	pathParamRegexp = regexp.MustCompile(`[:*](\w+)`)
+	nameRegexp      = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// Endpoint 已注册且有文档的接口
+type Endpoint struct {
+	Method     string   // 请求方式
+	Path       string   // 路由模板
+	PathParams []string // 路径参数
+	Route      *Route   // 接口文档
+}
+
+// Collect 匹配已注册的路由及接口文档，按路由模板排序返回(prefixes下的路由缺少文档、文档不完整或缺少对应路由时返回错误)
+func Collect(routes gin.RoutesInfo, docs Routes, prefixes ...string) ([]*Endpoint, error) {
+	var (
+		endpoints []*Endpoint
+		problems  []string
+	)
+	registered := make(map[string]bool)
+	names := make(map[string]string)
+	for _, ri := range routes {
+		key := ri.Method + " " + ri.Path
+		registered[key] = true
+
+		route, ok := docs[key]
+		if !ok {
+			if hasPrefix(ri.Path, prefixes) {
+				problems = append(problems, fmt.Sprintf("%s: missing route doc", key))
+			}
+			continue
+		}
+
+		switch {
+		case !nameRegexp.MatchString(route.Name):
+			problems = append(problems, fmt.Sprintf("%s: missing or invalid name", key))
+			continue
+		case names[route.Name] != "":
+			problems = append(problems, fmt.Sprintf("%s: duplicate name %s (%s)", key, route.Name, names[route.Name]))
+			continue
+		case route.Response == nil && route.ContentType == "":
+			problems = append(problems, fmt.Sprintf("%s: missing response type", key))
+			continue
+		case route.Body == nil && route.Upload == "" && (ri.Method == http.MethodPost || ri.Method == http.MethodPut) && !route.NoBody:
+			problems = append(problems, fmt.Sprintf("%s: missing request type", key))
+			continue
+		}
+		names[route.Name] = key
+
+		e := &Endpoint{
+			Method: ri.Method,
+			Path:   ri.Path,
+			Route:  route,
+		}
+		for _, m := range pathParamRegexp.FindAllStringSubmatch(ri.Path, -1) {
+			e.PathParams = append(e.PathParams, m[1])
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	for key := range docs {
+		if !registered[key] {
+			problems = append(problems, fmt.Sprintf("%s: route doc without registered route", key))
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path == endpoints[j].Path {
+			return endpoints[i].Method < endpoints[j].Method
+		}
+		return endpoints[i].Path < endpoints[j].Path
+	})
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return endpoints, fmt.Errorf("openapi: %d problem(s):\n  %s", len(problems), strings.Join(problems, "\n  "))
+	}
+	return endpoints, nil
+}
+
+func hasPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
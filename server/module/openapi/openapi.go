@@ -0,0 +1,299 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/server/schema"
+)
+
+// Route 接口文档(请求及响应数据使用schema中的结构体，根据json、form及binding标签生成)
+type Route struct {
+	Name        string                 // 接口名称(用于operationId及生成的客户端方法名，如QueryDemo)
+	Summary     string                 // 接口说明
+	Tags        []string               // 分组
+	Public      bool                   // 是否无需认证
+	Query       interface{}            // 查询参数(结构体，按form标签展开)
+	Whitelist   *schema.QueryWhitelist // 通用查询条件白名单(生成filter、sort、fields参数)
+	Params      []*Parameter           // 其他查询参数
+	Body        interface{}            // 请求数据(JSON)
+	Upload      string                 // 上传文件的表单字段(请求数据为multipart/form-data，如file)
+	NoBody      bool                   // POST/PUT接口无请求数据(如退出登录)
+	Response    interface{}            // 响应数据(JSON，列表及分页数据使用List、Page包装)
+	ContentType string                 // 非JSON响应的内容类型(如image/png，多种类型以逗号分隔)
+	Token       TokenAction            // 生成的客户端对访问令牌的处理
+}
+
+// TokenAction 客户端对访问令牌的处理
+type TokenAction int
+
+// 定义访问令牌的处理
+const (
+	TokenNone  TokenAction = iota
+	TokenSet               // 保存响应中的访问令牌(登录、刷新令牌)
+	TokenClear             // 清除访问令牌(登出)
+)
+
+// Routes 接口文档列表(键为"方法 路由模板"，如"GET /api/v1/users/:id")
+type Routes map[string]*Route
+
+// OK 响应状态(egin.ResOK)
+var OK = schema.StatusResult{}
+
+// ListOf 列表响应数据(schema.ListResult)
+type ListOf struct {
+	Item  interface{} // 列表项
+	Paged bool        // 是否包含分页信息
+}
+
+// List 列表响应数据(egin.ResList)
+func List(item interface{}) interface{} {
+	return ListOf{Item: item}
+}
+
+// Page 分页响应数据(egin.ResPage)
+func Page(item interface{}) interface{} {
+	return ListOf{Item: item, Paged: true}
+}
+
+// Info 文档信息
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document OpenAPI 3文档
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security,omitempty"`
+}
+
+// Components 可复用的对象
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme 认证方式
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Operation 接口
+type Operation struct {
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	OperationID string                 `json:"operationId"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*Response   `json:"responses"`
+	Security    *[]map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter 请求参数
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody 请求数据
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response 响应数据
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType 数据格式
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Build 根据已注册的路由及接口文档生成OpenAPI文档(prefixes下的路由缺少文档或文档缺少对应路由时返回错误)
+func Build(info Info, routes gin.RoutesInfo, docs Routes, prefixes ...string) (*Document, error) {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+	}
+	g := newGenerator()
+
+	endpoints, err := Collect(routes, docs, prefixes...)
+	for _, e := range endpoints {
+		path, op := g.operation(e)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*Operation)
+		}
+		doc.Paths[path][strings.ToLower(e.Method)] = op
+	}
+	doc.Components.Schemas = g.schemas
+	return doc, err
+}
+
+func (g *generator) operation(e *Endpoint) (string, *Operation) {
+	route := e.Route
+	op := &Operation{
+		Summary:     route.Summary,
+		Tags:        route.Tags,
+		OperationID: strings.ToLower(route.Name[:1]) + route.Name[1:],
+		Responses:   make(map[string]*Response),
+	}
+	if route.Public {
+		op.Security = &[]map[string][]string{}
+	}
+
+	for _, name := range e.PathParams {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	if route.Query != nil {
+		op.Parameters = append(op.Parameters, g.queryParameters(reflect.TypeOf(route.Query))...)
+	}
+	if wl := route.Whitelist; wl != nil {
+		op.Parameters = append(op.Parameters, whitelistParameters(wl)...)
+	}
+	op.Parameters = append(op.Parameters, route.Params...)
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: g.schemaOf(reflect.TypeOf(route.Body))},
+			},
+		}
+	}
+
+	if field := route.Upload; field != "" {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"multipart/form-data": {Schema: &Schema{
+					Type:       "object",
+					Required:   []string{field},
+					Properties: map[string]*Schema{field: {Type: "string", Format: "binary"}},
+				}},
+			},
+		}
+	}
+
+	if route.ContentType != "" {
+		content := make(map[string]*MediaType)
+		for _, ct := range strings.Split(route.ContentType, ",") {
+			content[strings.TrimSpace(ct)] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+		op.Responses["200"] = &Response{
+			Description: http.StatusText(http.StatusOK),
+			Content:     content,
+		}
+	} else {
+		op.Responses["200"] = &Response{
+			Description: http.StatusText(http.StatusOK),
+			Content: map[string]*MediaType{
+				"application/json": {Schema: g.responseSchema(route.Response)},
+			},
+		}
+	}
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content: map[string]*MediaType{
+			"application/json":         {Schema: g.schemaOf(reflect.TypeOf(schema.ErrorResult{}))},
+			"application/problem+json": {Schema: g.schemaOf(reflect.TypeOf(schema.ProblemResult{}))},
+		},
+	}
+
+	return pathParamRegexp.ReplaceAllString(e.Path, "{$1}"), op
+}
+
+// 列表及分页响应数据展开为schema.ListResult的结构
+func (g *generator) responseSchema(v interface{}) *Schema {
+	l, ok := v.(ListOf)
+	if !ok {
+		return g.schemaOf(reflect.TypeOf(v))
+	}
+
+	s := &Schema{
+		Type:     "object",
+		Required: []string{"list"},
+		Properties: map[string]*Schema{
+			"list": {Type: "array", Items: g.schemaOf(reflect.TypeOf(l.Item))},
+		},
+	}
+	if l.Paged {
+		s.Properties["pagination"] = g.schemaOf(reflect.TypeOf(schema.PaginationResult{}))
+	}
+	return s
+}
+
+// 通用查询条件参数(filter[key][op]、sort、fields)
+func whitelistParameters(wl *schema.QueryWhitelist) []*Parameter {
+	var params []*Parameter
+	if len(wl.Filters) > 0 {
+		keys := make([]string, 0, len(wl.Filters))
+		for key := range wl.Filters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		explode := true
+		params = append(params, &Parameter{
+			Name:        "filter",
+			In:          "query",
+			Description: fmt.Sprintf("filter[key][op]=value, key: %s", strings.Join(keys, ",")),
+			Style:       "deepObject",
+			Explode:     &explode,
+			Schema:      &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		})
+	}
+	if len(wl.Sorts) > 0 {
+		keys := make([]string, 0, len(wl.Sorts))
+		for key := range wl.Sorts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		params = append(params, &Parameter{
+			Name:        "sort",
+			In:          "query",
+			Description: fmt.Sprintf("sort=-a,b, key: %s", strings.Join(keys, ",")),
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+	if len(wl.Fields) > 0 {
+		params = append(params, &Parameter{
+			Name:        "fields",
+			In:          "query",
+			Description: fmt.Sprintf("fields=a,b, field: %s", strings.Join(wl.Fields, ",")),
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+	return params
+}
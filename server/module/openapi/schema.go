@@ -0,0 +1,311 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema 数据结构(JSON Schema的子集)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// 根据Go类型生成数据结构(具名结构体放入components并引用)
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func (g *generator) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.register(t)}
+	}
+	return &Schema{}
+}
+
+// 注册具名结构体(先占位以支持递归引用，如菜单树)
+func (g *generator) register(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, ok := g.schemas[name]; ok {
+		name = strings.Replace(t.PkgPath(), "/", ".", -1) + "." + name
+	}
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.fields(t, s)
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+func (g *generator) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.SplitN(tag, ",", 2)[0]
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, s)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fs := g.schemaOf(field.Type)
+		if applyBinding(fs, field.Tag.Get("binding")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fs
+	}
+}
+
+// QueryField 查询参数字段
+type QueryField struct {
+	Name    string              // 参数名称(form标签)
+	Default string              // 默认值
+	Field   reflect.StructField // 结构体字段
+}
+
+// QueryFields 按form标签展开查询参数结构体(form:"-"及非基础类型的字段忽略，匿名嵌入的结构体展开)
+func QueryFields(t reflect.Type) []*QueryField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var fields []*QueryField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, QueryFields(field.Type)...)
+			continue
+		}
+		if field.PkgPath != "" || !isQueryType(field.Type) {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		qf := &QueryField{Name: parts[0], Field: field}
+		if qf.Name == "" {
+			qf.Name = field.Name
+		}
+		for _, opt := range parts[1:] {
+			if strings.HasPrefix(opt, "default=") {
+				qf.Default = opt[len("default="):]
+			}
+		}
+		fields = append(fields, qf)
+	}
+	return fields
+}
+
+// 查询参数支持基础类型、时间及其切片
+func isQueryType(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return t == timeType
+}
+
+func (g *generator) queryParameters(t reflect.Type) []*Parameter {
+	var params []*Parameter
+	for _, qf := range QueryFields(t) {
+		ps := g.schemaOf(qf.Field.Type)
+		if qf.Default != "" {
+			ps.Default = parseValue(ps.Type, qf.Default)
+		}
+		params = append(params, &Parameter{
+			Name:     qf.Name,
+			In:       "query",
+			Required: applyBinding(ps, qf.Field.Tag.Get("binding")),
+			Schema:   ps,
+		})
+	}
+	return params
+}
+
+// 根据binding标签设定约束，返回是否必填
+func applyBinding(s *Schema, binding string) bool {
+	var required bool
+	for _, rule := range strings.Split(binding, ",") {
+		key, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, param = rule[:i], rule[i+1:]
+		}
+
+		switch key {
+		case "required":
+			required = true
+		case "min", "gte":
+			setBound(s, param, false, false)
+		case "max", "lte":
+			setBound(s, param, true, false)
+		case "gt":
+			setBound(s, param, false, true)
+		case "lt":
+			setBound(s, param, true, true)
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, parseValue(s.Type, v))
+			}
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		}
+	}
+	return required
+}
+
+// 数值类型设定取值范围，字符串及数组设定长度范围
+func setBound(s *Schema, param string, upper, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch s.Type {
+	case "integer", "number":
+		if upper {
+			s.Maximum, s.ExclusiveMaximum = &n, exclusive
+		} else {
+			s.Minimum, s.ExclusiveMinimum = &n, exclusive
+		}
+	case "string", "array":
+		v := int(n)
+		if exclusive {
+			if upper {
+				v--
+			} else {
+				v++
+			}
+		}
+		switch {
+		case s.Type == "string" && upper:
+			s.MaxLength = &v
+		case s.Type == "string":
+			s.MinLength = &v
+		case upper:
+			s.MaxItems = &v
+		default:
+			s.MinItems = &v
+		}
+	}
+}
+
+func parseValue(typ, v string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NewMemoryStore 创建基于内存的限流存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		items: make(map[string]time.Time),
+		swept: time.Now(),
+	}
+}
+
+// MemoryStore 基于内存的限流存储(仅在当前进程内生效)
+type MemoryStore struct {
+	mu    sync.Mutex
+	items map[string]time.Time
+	swept time.Time
+}
+
+// Allow 判断请求是否允许通过
+func (a *MemoryStore) Allow(ctx context.Context, key string, p Policy) (*Result, error) {
+	key = p.Name + ":" + key
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tat, result := gcra(a.items[key], now, p)
+	a.items[key] = tat
+	a.sweep(now)
+	return result, nil
+}
+
+// 每分钟清理一次令牌已补满的记录
+func (a *MemoryStore) sweep(now time.Time) {
+	if now.Sub(a.swept) < time.Minute {
+		return
+	}
+	a.swept = now
+
+	for key, tat := range a.items {
+		if tat.Before(now) {
+			delete(a.items, key)
+		}
+	}
+}
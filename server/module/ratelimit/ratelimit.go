@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// 主存储异常后，在该时长内直接使用备用存储(避免每个请求都等待主存储超时)
+const primaryRetryInterval = 5 * time.Second
+
+// Policy 限流策略(令牌桶：每Period补充Count个令牌，桶容量为Burst)
+type Policy struct {
+	Name   string
+	Count  int64
+	Period time.Duration
+	Burst  int64
+}
+
+// 每个令牌的补充间隔
+func (p Policy) emission() time.Duration {
+	return p.Period / time.Duration(p.Count)
+}
+
+func (p Policy) burst() int64 {
+	if p.Burst <= 0 {
+		return p.Count
+	}
+	return p.Burst
+}
+
+// Result 限流结果
+type Result struct {
+	Allowed    bool
+	Limit      int64         // 桶容量
+	Remaining  int64         // 剩余令牌数
+	RetryAfter time.Duration // 被拒绝时，可重试的等待时长
+	ResetAfter time.Duration // 令牌补满的等待时长
+}
+
+// Store 限流存储
+type Store interface {
+	Allow(ctx context.Context, key string, p Policy) (*Result, error)
+}
+
+// gcra 基于GCRA算法计算限流结果(tat为理论到达时间，返回新的理论到达时间)
+func gcra(tat, now time.Time, p Policy) (time.Time, *Result) {
+	emission := p.emission()
+	burst := p.burst()
+	tolerance := emission * time.Duration(burst)
+
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(emission)
+	diff := now.Sub(newTat.Add(-tolerance))
+
+	if diff < 0 {
+		return tat, &Result{
+			Allowed:    false,
+			Limit:      burst,
+			Remaining:  0,
+			RetryAfter: -diff,
+			ResetAfter: tat.Sub(now),
+		}
+	}
+
+	return newTat, &Result{
+		Allowed:    true,
+		Limit:      burst,
+		Remaining:  int64(diff / emission),
+		ResetAfter: newTat.Sub(now),
+	}
+}
+
+// NewLimiter 创建限流器(主存储不可用时使用备用存储，并在一段时间后重试主存储)
+func NewLimiter(primary, fallback Store, onError func(error)) *Limiter {
+	return &Limiter{
+		primary:  primary,
+		fallback: fallback,
+		onError:  onError,
+	}
+}
+
+// Limiter 限流器
+type Limiter struct {
+	primary  Store
+	fallback Store
+	onError  func(error)
+	retryAt  int64 // 重试主存储的时间(UnixNano)
+}
+
+// Allow 判断请求是否允许通过
+func (a *Limiter) Allow(ctx context.Context, key string, p Policy) *Result {
+	if p.Count <= 0 || p.Period <= 0 {
+		return &Result{Allowed: true}
+	}
+
+	if a.primary != nil && time.Now().UnixNano() >= atomic.LoadInt64(&a.retryAt) {
+		result, err := a.primary.Allow(ctx, key, p)
+		if err == nil {
+			return result
+		}
+		atomic.StoreInt64(&a.retryAt, time.Now().Add(primaryRetryInterval).UnixNano())
+		if a.onError != nil {
+			a.onError(err)
+		}
+	}
+
+	result, _ := a.fallback.Allow(ctx, key, p)
+	return result
+}
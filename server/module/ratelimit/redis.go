@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/key7men/mag/pkg/trace"
+)
+
+// GCRA算法(时间单位为微秒)，返回[是否允许, 剩余令牌数, 重试等待时长, 补满等待时长]
+var gcraScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local emission = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local tolerance = emission * burst
+
+local tat = tonumber(redis.call("GET", key) or now)
+if tat < now then
+  tat = now
+end
+
+local new_tat = tat + emission
+local diff = now - (new_tat - tolerance)
+if diff < 0 then
+  return {0, 0, -diff, tat - now}
+end
+
+redis.call("SET", key, new_tat, "PX", math.ceil((new_tat - now) / 1000))
+return {1, math.floor(diff / emission), 0, new_tat - now}
+`)
+
+// NewRedisStore 创建基于redis的限流存储(多实例共享)
+func NewRedisStore(cli *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{
+		cli:    cli,
+		prefix: prefix,
+	}
+}
+
+// RedisStore 基于redis的限流存储
+type RedisStore struct {
+	cli    *redis.Client
+	prefix string
+}
+
+// Allow 判断请求是否允许通过
+func (a *RedisStore) Allow(ctx context.Context, key string, p Policy) (*Result, error) {
+	now := time.Now().UnixNano() / 1e3
+	emission := int64(p.emission() / time.Microsecond)
+	burst := p.burst()
+
+	v, err := gcraScript.Run(trace.RedisWithContext(ctx, a.cli), []string{a.prefix + p.Name + ":" + key}, now, emission, burst).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := v.([]interface{})
+	if !ok || len(values) != 4 {
+		return nil, errors.New("unexpected rate limit script result")
+	}
+	ints := make([]int64, len(values))
+	for i, item := range values {
+		ints[i], _ = item.(int64)
+	}
+
+	return &Result{
+		Allowed:    ints[0] == 1,
+		Limit:      burst,
+		Remaining:  ints[1],
+		RetryAfter: time.Duration(ints[2]) * time.Microsecond,
+		ResetAfter: time.Duration(ints[3]) * time.Microsecond,
+	}, nil
+}
+
+// Close 关闭redis连接
+func (a *RedisStore) Close() error {
+	return a.cli.Close()
+}
@@ -3,12 +3,16 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	casbinModel "github.com/casbin/casbin/v2/model"
 	"github.com/casbin/casbin/v2/persist"
 	"github.com/google/wire"
 	"github.com/key7men/mag/pkg/logger"
+	icontext "github.com/key7men/mag/server/enhance/context"
 	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/metrics"
 	"github.com/key7men/mag/server/schema"
 )
 
@@ -24,27 +28,68 @@ type CasbinAdapter struct {
 	MenuResourceModel model.IMenuActionResource
 	UserModel         model.IUser
 	UserRoleModel     model.IUserRole
+	TenantModel       model.ITenant
+	mu                sync.RWMutex `wire:"-"`
+	loaded            bool         `wire:"-"`
+	loadErr           error        `wire:"-"`
 }
 
 // TODO: LoadPolicy loads all policy rules from the storage.
 func (a *CasbinAdapter) LoadPolicy(model casbinModel.Model) error {
-	ctx := context.Background()
+	// 加载全部租户的策略
+	ctx := icontext.NewAllTenants(context.Background())
+	start := time.Now()
+	defer func() {
+		metrics.CasbinLoadDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	err := a.loadPolicy(ctx, model)
+	a.mu.Lock()
+	a.loaded = true
+	a.loadErr = err
+	a.mu.Unlock()
+	return err
+}
+
+// LoadState 最近一次加载策略的结果(未加载时loaded为false)
+func (a *CasbinAdapter) LoadState() (loaded bool, err error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.loaded, a.loadErr
+}
+
+func (a *CasbinAdapter) loadPolicy(ctx context.Context, model casbinModel.Model) error {
 	err := a.loadRolePolicy(ctx, model)
 	if err != nil {
 		logger.Errorf(ctx, "Load casbin role policy error: %s", err.Error())
 		return err
 	}
 
-	err = a.loadUserPolicy(ctx, model)
+	// 仅加载启用的用户的策略
+	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Load casbin user policy error: %s", err.Error())
+		return err
+	}
+
+	err = a.loadUserPolicy(ctx, model, userResult.Data)
 	if err != nil {
 		logger.Errorf(ctx, "Load casbin user policy error: %s", err.Error())
 		return err
 	}
 
+	err = a.loadTenantPolicy(ctx, model, userResult.Data)
+	if err != nil {
+		logger.Errorf(ctx, "Load casbin tenant policy error: %s", err.Error())
+		return err
+	}
+
 	return nil
 }
 
-// TODO: 加载角色策略(p,role_id,path,method)
+// TODO: 加载角色策略(p,role_id,tenant_id,path,method)
 func (a *CasbinAdapter) loadRolePolicy(ctx context.Context, m casbinModel.Model) error {
 	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
 		Status: 1,
@@ -79,7 +124,7 @@ func (a *CasbinAdapter) loadRolePolicy(ctx context.Context, m casbinModel.Model)
 							continue
 						}
 						mcache[mr.Path+mr.Method] = struct{}{}
-						line := fmt.Sprintf("p,%s,%s,%s", item.ID, mr.Path, mr.Method)
+						line := fmt.Sprintf("p,%s,%s,%s,%s", item.ID, item.TenantID, mr.Path, mr.Method)
 						persist.LoadPolicyLine(line, m)
 					}
 				}
@@ -90,24 +135,19 @@ func (a *CasbinAdapter) loadRolePolicy(ctx context.Context, m casbinModel.Model)
 	return nil
 }
 
-// TODO: 加载用户策略(g,user_id,role_id)
-func (a *CasbinAdapter) loadUserPolicy(ctx context.Context, m casbinModel.Model) error {
-	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
-		Status: 1,
-	})
-	if err != nil {
-		return err
-	} else if len(userResult.Data) > 0 {
+// TODO: 加载用户策略(g,user_id,role_id,tenant_id)
+func (a *CasbinAdapter) loadUserPolicy(ctx context.Context, m casbinModel.Model, users schema.Users) error {
+	if len(users) > 0 {
 		userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{})
 		if err != nil {
 			return err
 		}
 
 		mUserRoles := userRoleResult.Data.ToUserIDMap()
-		for _, uitem := range userResult.Data {
+		for _, uitem := range users {
 			if urs, ok := mUserRoles[uitem.ID]; ok {
 				for _, ur := range urs {
-					line := fmt.Sprintf("g,%s,%s", ur.UserID, ur.RoleID)
+					line := fmt.Sprintf("g,%s,%s,%s", ur.UserID, ur.RoleID, uitem.TenantID)
 					persist.LoadPolicyLine(line, m)
 				}
 			}
@@ -117,6 +157,31 @@ func (a *CasbinAdapter) loadUserPolicy(ctx context.Context, m casbinModel.Model)
 	return nil
 }
 
+// 加载租户管理员策略(p,admin_id,tenant_id,/*,.*)，租户管理员拥有该租户的全部权限(管理员须为启用的用户)
+func (a *CasbinAdapter) loadTenantPolicy(ctx context.Context, m casbinModel.Model, users schema.Users) error {
+	tenantResult, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	mUsers := make(map[string]struct{}, len(users))
+	for _, item := range users {
+		mUsers[item.ID] = struct{}{}
+	}
+
+	for _, item := range tenantResult.Data {
+		if _, ok := mUsers[item.AdminID]; !ok {
+			continue
+		}
+		line := fmt.Sprintf("p,%s,%s,/*,.*", item.AdminID, item.ID)
+		persist.LoadPolicyLine(line, m)
+	}
+
+	return nil
+}
+
 // SavePolicy saves all policy rules to the storage.
 func (a *CasbinAdapter) SavePolicy(model casbinModel.Model) error {
 	return nil
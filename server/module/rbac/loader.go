@@ -0,0 +1,85 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/server/config"
+)
+
+// NewPolicyLoader 创建casbin策略定时加载任务(开关及间隔读取当前配置，支持热更新)
+func NewPolicyLoader(e *casbin.SyncedEnforcer) *PolicyLoader {
+	return &PolicyLoader{
+		e:     e,
+		reset: make(chan struct{}, 1),
+	}
+}
+
+// PolicyLoader casbin策略定时加载任务
+type PolicyLoader struct {
+	e     *casbin.SyncedEnforcer
+	reset chan struct{}
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// Start 启动加载
+func (a *PolicyLoader) Start() {
+	a.stop = make(chan struct{})
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.run()
+	}()
+}
+
+// Stop 停止加载
+func (a *PolicyLoader) Stop() {
+	if a.stop == nil {
+		return
+	}
+	close(a.stop)
+	a.wg.Wait()
+	a.stop = nil
+}
+
+// Reset 按当前配置重新计时(配置变更后调用)
+func (a *PolicyLoader) Reset() {
+	select {
+	case a.reset <- struct{}{}:
+	default:
+	}
+}
+
+func (a *PolicyLoader) run() {
+	for {
+		if !a.wait() {
+			return
+		}
+	}
+}
+
+// wait 等待一个加载周期，停止时返回false
+func (a *PolicyLoader) wait() bool {
+	// 未开启时仅等待配置变更
+	var tick <-chan time.Time
+	if cfg := config.Current().Casbin; cfg.AutoLoad && cfg.AutoLoadInternal > 0 {
+		timer := time.NewTimer(time.Duration(cfg.AutoLoadInternal) * time.Second)
+		defer timer.Stop()
+		tick = timer.C
+	}
+
+	select {
+	case <-a.stop:
+		return false
+	case <-a.reset:
+	case <-tick:
+		if err := a.e.LoadPolicy(); err != nil {
+			logger.Errorf(context.Background(), "Auto load casbin policy error: %s", err.Error())
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package tabular
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+)
+
+// UTF-8 BOM(便于Excel识别编码)
+const utf8BOM = "\ufeff"
+
+type csvWriter struct {
+	w       *csv.Writer
+	started bool
+	out     io.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), out: w}
+}
+
+func (a *csvWriter) Write(record []string) error {
+	if !a.started {
+		a.started = true
+		if _, err := io.WriteString(a.out, utf8BOM); err != nil {
+			return err
+		}
+	}
+	return a.w.Write(escapeRecord(record))
+}
+
+func (a *csvWriter) Close() error {
+	a.w.Flush()
+	return a.w.Error()
+}
+
+type csvReader struct {
+	r *csv.Reader
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && string(b) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	return &csvReader{r: cr}
+}
+
+func (a *csvReader) Read() ([]string, error) {
+	record, err := a.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return unescapeRecord(record), nil
+}
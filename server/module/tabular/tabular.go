@@ -0,0 +1,126 @@
+package tabular
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// Format 表格文件格式
+type Format string
+
+const (
+	// CSV 逗号分隔值(UTF-8)
+	CSV Format = "csv"
+	// XLSX Excel工作簿(仅读写第一个工作表)
+	XLSX Format = "xlsx"
+)
+
+// ErrUnsupportedFormat 不支持的文件格式
+var ErrUnsupportedFormat = errors.New("tabular: unsupported format")
+
+// ParseFormat 解析文件格式(不区分大小写，可为文件扩展名)
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimPrefix(s, "."))); f {
+	case CSV, XLSX:
+		return f, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// ContentType 响应内容类型
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Ext 文件扩展名
+func (f Format) Ext() string {
+	return "." + string(f)
+}
+
+// Writer 按行写入表格数据(写入时直接输出，不缓存全部数据；以=+-@等开头的单元格增加前缀'，避免被作为公式执行)
+type Writer interface {
+	// 写入一行
+	Write(record []string) error
+	// 完成写入(不关闭底层的io.Writer)
+	Close() error
+}
+
+// Reader 按行读取表格数据(去掉导出时增加的公式转义前缀')
+type Reader interface {
+	// 读取一行，没有更多数据时返回io.EOF
+	Read() ([]string, error)
+}
+
+// 以这些字符开头的单元格会被表格软件作为公式执行
+const formulaPrefixes = "=+-@\t\r"
+
+// 单元格是否会被作为公式(忽略开头的转义前缀')
+func isFormula(v string) bool {
+	v = strings.TrimLeft(v, "'")
+	return v != "" && strings.IndexByte(formulaPrefixes, v[0]) >= 0
+}
+
+// 导出时为可能被作为公式的单元格增加前缀'(防止公式注入)
+func escapeFormula(v string) string {
+	if isFormula(v) {
+		return "'" + v
+	}
+	return v
+}
+
+// 导入时去掉导出增加的前缀'(与escapeFormula互逆)
+func unescapeFormula(v string) string {
+	if strings.HasPrefix(v, "'") && isFormula(v) {
+		return v[1:]
+	}
+	return v
+}
+
+func escapeRecord(record []string) []string {
+	var escaped []string
+	for i, v := range record {
+		if ev := escapeFormula(v); ev != v {
+			if escaped == nil {
+				escaped = append([]string(nil), record...)
+			}
+			escaped[i] = ev
+		}
+	}
+	if escaped == nil {
+		return record
+	}
+	return escaped
+}
+
+func unescapeRecord(record []string) []string {
+	for i, v := range record {
+		record[i] = unescapeFormula(v)
+	}
+	return record
+}
+
+// NewWriter 创建指定格式的写入器
+func NewWriter(f Format, w io.Writer) (Writer, error) {
+	switch f {
+	case CSV:
+		return newCSVWriter(w), nil
+	case XLSX:
+		return newXLSXWriter(w)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// NewReader 创建指定格式的读取器
+func NewReader(f Format, r io.ReaderAt, size int64) (Reader, error) {
+	switch f {
+	case CSV:
+		return newCSVReader(io.NewSectionReader(r, 0, size)), nil
+	case XLSX:
+		return newXLSXReader(r, size)
+	}
+	return nil, ErrUnsupportedFormat
+}
@@ -0,0 +1,268 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var roundTripRecords = [][]string{
+	{"user_name", "real_name", "phone", "remark"},
+	{"alice", "爱丽丝", "+8613800000000", "=HYPERLINK(\"http://x\")"},
+	{"bob", "", "-1", "@SUM(A1)"},
+	{"carol", "'quoted", "'=1", "\tcmd"},
+	{"dave", "a,b\n\"c\"", "", "x<y&z"},
+}
+
+func writeRecords(t *testing.T, f Format, records [][]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(f, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func readRecords(t *testing.T, f Format, data []byte) ([][]string, error) {
+	t.Helper()
+	r, err := NewReader(f, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	var records [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+// 去掉行尾的空单元格(xlsx不写入空单元格)
+func trimRecord(record []string) []string {
+	for len(record) > 0 && record[len(record)-1] == "" {
+		record = record[:len(record)-1]
+	}
+	return record
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{CSV, XLSX} {
+		t.Run(string(f), func(t *testing.T) {
+			records, err := readRecords(t, f, writeRecords(t, f, roundTripRecords))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != len(roundTripRecords) {
+				t.Fatalf("got %d records, want %d", len(records), len(roundTripRecords))
+			}
+			for i, record := range records {
+				if got, want := trimRecord(record), trimRecord(roundTripRecords[i]); !reflect.DeepEqual(got, want) {
+					t.Errorf("record %d: got %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormulaEscape(t *testing.T) {
+	for _, tc := range []struct {
+		value, want string
+	}{
+		{"", ""},
+		{"alice", "alice"},
+		{"=1+1", "'=1+1"},
+		{"+86", "'+86"},
+		{"-1", "'-1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tcmd", "'\tcmd"},
+		{"\rcmd", "'\rcmd"},
+		{"'quoted", "'quoted"},
+		{"'=1", "''=1"},
+		{"a=1", "a=1"},
+	} {
+		if got := escapeFormula(tc.value); got != tc.want {
+			t.Errorf("escapeFormula(%q): got %q, want %q", tc.value, got, tc.want)
+		}
+		if got := unescapeFormula(tc.want); got != tc.value {
+			t.Errorf("unescapeFormula(%q): got %q, want %q", tc.want, got, tc.value)
+		}
+	}
+
+	// 导出的单元格不能以公式字符开头
+	data := writeRecords(t, CSV, [][]string{{"=1+1", "@A1"}})
+	if got := strings.TrimPrefix(string(data), utf8BOM); got != "'=1+1,'@A1\n" {
+		t.Errorf("csv: got %q", got)
+	}
+	record := []string{"=1"}
+	writeRecords(t, CSV, [][]string{record})
+	if record[0] != "=1" {
+		t.Errorf("record modified: %q", record[0])
+	}
+}
+
+func TestColumn(t *testing.T) {
+	for _, tc := range []struct {
+		index int
+		name  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	} {
+		if got := columnName(tc.index); got != tc.name {
+			t.Errorf("columnName(%d): got %s, want %s", tc.index, got, tc.name)
+		}
+		if got := columnIndex(tc.name + "12"); got != tc.index {
+			t.Errorf("columnIndex(%s12): got %d, want %d", tc.name, got, tc.index)
+		}
+	}
+	if got := columnIndex("12"); got != -1 {
+		t.Errorf("columnIndex(12): got %d, want -1", got)
+	}
+	if got := columnIndex("ZZZZZZZZZZZZZZ1"); got != maxXLSXColumns {
+		t.Errorf("columnIndex(ZZZZZZZZZZZZZZ1): got %d, want %d", got, maxXLSXColumns)
+	}
+}
+
+// 构造XLSX文件(使用标准的工作簿部件及指定的工作表、共享字符串表)
+func buildXLSX(t *testing.T, sheet, sharedStrings string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range xlsxParts {
+		if part.name == "[Content_Types].xml" {
+			continue
+		}
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(fw, part.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parts := map[string]string{xlsxSheetPath: sheet}
+	if sharedStrings != "" {
+		parts["xl/sharedStrings.xml"] = sharedStrings
+	}
+	for name, content := range parts {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(fw, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const xlsxNS = `xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"`
+
+func TestXLSXReader(t *testing.T) {
+	sst := `<sst ` + xlsxNS + `><si><t>user_name</t></si><si><r><t>real</t></r><r><t>_name</t></r></si><si><t>alice</t></si><si><t>'=1</t></si></sst>`
+	sheet := `<worksheet ` + xlsxNS + `><sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+		`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2" t="inlineStr"><is><r><t>in</t></r><r><t>line</t></r></is></c></row>` +
+		`<row r="5"><c r="B5"><v>42</v></c><c r="AA5" t="s"><v>3</v></c></row>` +
+		`<row><c t="inlineStr"><is><t>next</t></is></c></row>` +
+		`</sheetData></worksheet>`
+
+	records, err := readRecords(t, XLSX, buildXLSX(t, sheet, sst))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aa5 := make([]string, 27)
+	aa5[1], aa5[26] = "42", "=1"
+	want := [][]string{
+		{"user_name", "real_name"},
+		{"alice", "", "inline"},
+		{},
+		{},
+		aa5,
+		{"next"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %q, want %q", records, want)
+	}
+}
+
+func TestXLSXReaderLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		sheet  string
+		sst    string
+		newErr error
+		err    error
+	}{
+		{
+			name:  "shared string out of range",
+			sheet: `<worksheet ` + xlsxNS + `><sheetData><row r="1"><c r="A1" t="s"><v>1</v></c></row></sheetData></worksheet>`,
+			sst:   `<sst ` + xlsxNS + `><si><t>a</t></si></sst>`,
+			err:   ErrInvalidXLSX,
+		},
+		{
+			name:  "too many rows",
+			sheet: `<worksheet ` + xlsxNS + `><sheetData><row r="1048577"><c r="A1048577"><v>1</v></c></row></sheetData></worksheet>`,
+			err:   ErrXLSXTooLarge,
+		},
+		{
+			name:  "too many columns",
+			sheet: `<worksheet ` + xlsxNS + `><sheetData><row r="1"><c r="XFE1"><v>1</v></c></row></sheetData></worksheet>`,
+			err:   ErrXLSXTooLarge,
+		},
+		{
+			name:   "shared strings too large",
+			sheet:  `<worksheet ` + xlsxNS + `><sheetData></sheetData></worksheet>`,
+			sst:    `<sst ` + xlsxNS + `><si><t>` + strings.Repeat("a", maxXLSXPartSize) + `</t></si></sst>`,
+			newErr: ErrXLSXTooLarge,
+		},
+		{
+			name:   "sheet too large",
+			sheet:  `<worksheet ` + xlsxNS + `><sheetData>` + strings.Repeat(" ", maxXLSXPartSize) + `</sheetData></worksheet>`,
+			newErr: ErrXLSXTooLarge,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := buildXLSX(t, tc.sheet, tc.sst)
+			r, err := NewReader(XLSX, bytes.NewReader(data), int64(len(data)))
+			if err != tc.newErr {
+				t.Fatalf("NewReader: got %v, want %v", err, tc.newErr)
+			} else if err != nil {
+				return
+			}
+
+			for err == nil {
+				_, err = r.Read()
+			}
+			if err != tc.err {
+				t.Errorf("Read: got %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidXLSX 无效的XLSX文件
+var ErrInvalidXLSX = errors.New("tabular: invalid xlsx file")
+
+// ErrXLSXTooLarge XLSX文件解压后的数据超出限制
+var ErrXLSXTooLarge = errors.New("tabular: xlsx file too large")
+
+// 读取XLSX时的限制(防止压缩炸弹)
+const (
+	// 单个部件解压后的最大字节数
+	maxXLSXPartSize = 64 << 20
+	// 工作表的最大行数及列数(与Excel一致)
+	maxXLSXRows    = 1 << 20
+	maxXLSXColumns = 1 << 14
+)
+
+const xlsxSheetPath = "xl/worksheets/sheet1.xml"
+
+// 除工作表外的固定部件(工作表使用内联字符串，无需共享字符串表)
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs></styleSheet>`},
+}
+
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(fw, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	// 工作表作为最后一个部件，逐行写入压缩流
+	fw, err := zw.Create(xlsxSheetPath)
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(fw)
+	_, err = sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{zw: zw, sheet: sheet}, nil
+}
+
+func (a *xlsxWriter) Write(record []string) error {
+	a.rows++
+	fmt.Fprintf(a.sheet, `<row r="%d">`, a.rows)
+	for i, v := range record {
+		if v == "" {
+			continue
+		}
+		fmt.Fprintf(a.sheet, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, columnName(i), a.rows)
+		if err := xml.EscapeText(a.sheet, []byte(escapeFormula(v))); err != nil {
+			return err
+		}
+		a.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := a.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (a *xlsxWriter) Close() error {
+	if _, err := a.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := a.sheet.Flush(); err != nil {
+		return err
+	}
+	return a.zw.Close()
+}
+
+// 列名(0:A 25:Z 26:AA)
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// 列序号(A1:0 AA3:26)，无法解析时返回-1
+func columnIndex(ref string) int {
+	n := 0
+	for i, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			if n = n*26 + int(r-'A'+1); n > maxXLSXColumns {
+				return maxXLSXColumns
+			}
+			continue
+		}
+		if i == 0 {
+			return -1
+		}
+		break
+	}
+	return n - 1
+}
+
+type xlsxReader struct {
+	dec        *xml.Decoder
+	closer     io.Closer
+	strings    []string
+	line       int      // 已读取的行数
+	pending    []string // 空行之后的数据行(工作表中省略了空行)
+	pendingRow int      // 待返回的数据行的行号
+}
+
+func newXLSXReader(r io.ReaderAt, size int64) (*xlsxReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalidXLSX
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	sheet, ok := files[sheetPath]
+	if !ok {
+		return nil, ErrInvalidXLSX
+	}
+
+	var sharedStrings []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		sharedStrings, err = readSharedStrings(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rc, err := openXLSXPart(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxReader{dec: newXLSXDecoder(rc), closer: rc, strings: sharedStrings}, nil
+}
+
+// 第一个工作表的路径(按工作簿中的顺序)
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXMLFile(files["xl/workbook.xml"], &workbook); err != nil {
+		return "", err
+	} else if len(workbook.Sheets) == 0 {
+		return "", ErrInvalidXLSX
+	}
+
+	var rels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXMLFile(files["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+		return "", err
+	}
+	for _, item := range rels.Items {
+		if item.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(item.Target, "/") {
+			return strings.TrimPrefix(item.Target, "/"), nil
+		}
+		return path.Join("xl", item.Target), nil
+	}
+	return "", ErrInvalidXLSX
+}
+
+// 打开部件(检查声明的解压后大小，读取时仍需限制实际读取的字节数)
+func openXLSXPart(f *zip.File) (io.ReadCloser, error) {
+	if f.UncompressedSize64 > maxXLSXPartSize {
+		return nil, ErrXLSXTooLarge
+	}
+	return f.Open()
+}
+
+// 读取部件的XML解码器(最多读取maxXLSXPartSize字节)
+func newXLSXDecoder(r io.Reader) *xml.Decoder {
+	return xml.NewDecoder(io.LimitReader(r, maxXLSXPartSize))
+}
+
+func decodeXMLFile(f *zip.File, v interface{}) error {
+	if f == nil {
+		return ErrInvalidXLSX
+	}
+	rc, err := openXLSXPart(f)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := newXLSXDecoder(rc).Decode(v); err != nil {
+		return ErrInvalidXLSX
+	}
+	return nil
+}
+
+// 共享字符串表(富文本按顺序拼接各段文本)
+func readSharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []struct {
+			T    string `xml:"t"`
+			Runs []struct {
+				T string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"si"`
+	}
+	if err := decodeXMLFile(f, &sst); err != nil {
+		return nil, err
+	}
+
+	list := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		var sb strings.Builder
+		sb.WriteString(item.T)
+		for _, r := range item.Runs {
+			sb.WriteString(r.T)
+		}
+		list[i] = sb.String()
+	}
+	return list, nil
+}
+
+type xlsxCell struct {
+	Ref    string `xml:"r,attr"`
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"is"`
+}
+
+// 读取一行(工作表中省略的空行按空记录返回，保持行号与表格一致)
+func (a *xlsxReader) Read() ([]string, error) {
+	if a.pending != nil {
+		a.line++
+		if a.line < a.pendingRow {
+			return []string{}, nil
+		}
+		record := a.pending
+		a.pending = nil
+		return record, nil
+	}
+
+	for {
+		tok, err := a.dec.Token()
+		if err == io.EOF {
+			a.closer.Close()
+			return nil, io.EOF
+		} else if err != nil {
+			return nil, ErrInvalidXLSX
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "row" {
+			continue
+		}
+
+		record, n, err := a.readRow(se)
+		if err != nil {
+			return nil, err
+		}
+		a.line++
+		if a.line > maxXLSXRows || n > maxXLSXRows {
+			return nil, ErrXLSXTooLarge
+		}
+		if n > a.line {
+			a.pending, a.pendingRow = record, n
+			return []string{}, nil
+		}
+		return record, nil
+	}
+}
+
+// 读取行数据及行号(未指定行号时为0)
+func (a *xlsxReader) readRow(start xml.StartElement) ([]string, int, error) {
+	var row struct {
+		Ref   int        `xml:"r,attr"`
+		Cells []xlsxCell `xml:"c"`
+	}
+	if err := a.dec.DecodeElement(&row, &start); err != nil {
+		return nil, 0, ErrInvalidXLSX
+	}
+
+	var record []string
+	for _, cell := range row.Cells {
+		i := len(record)
+		if cell.Ref != "" {
+			if n := columnIndex(cell.Ref); n >= i {
+				i = n
+			}
+		}
+		if i >= maxXLSXColumns {
+			return nil, 0, ErrXLSXTooLarge
+		}
+		for len(record) <= i {
+			record = append(record, "")
+		}
+
+		v, err := a.cellValue(cell)
+		if err != nil {
+			return nil, 0, err
+		}
+		record[i] = unescapeFormula(v)
+	}
+	return record, row.Ref, nil
+}
+
+func (a *xlsxReader) cellValue(cell xlsxCell) (string, error) {
+	switch cell.Type {
+	case "s":
+		i, err := strconv.Atoi(cell.Value)
+		if err != nil || i < 0 || i >= len(a.strings) {
+			return "", ErrInvalidXLSX
+		}
+		return a.strings[i], nil
+	case "inlineStr":
+		var sb strings.Builder
+		sb.WriteString(cell.Inline.T)
+		for _, r := range cell.Inline.Runs {
+			sb.WriteString(r.T)
+		}
+		return sb.String(), nil
+	}
+	return cell.Value, nil
+}
@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/key7men/mag/pkg/logger"
+	icontext "github.com/key7men/mag/server/enhance/context"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 定义投递请求头
+const (
+	HeaderSignature = "X-Mag-Signature"
+	HeaderTimestamp = "X-Mag-Timestamp"
+	HeaderEvent     = "X-Mag-Event"
+	HeaderDelivery  = "X-Mag-Delivery"
+)
+
+// 记录的响应内容最大长度
+const maxResponseBody = 1024
+
+// Options 投递参数
+type Options struct {
+	Interval         time.Duration // 投递记录轮询间隔
+	BatchSize        int           // 每次轮询投递的最大记录数量
+	MaxRetries       int           // 最大投递次数(超过后进入死信)
+	RetryInterval    time.Duration // 重试间隔(按投递次数指数退避)
+	MaxRetryInterval time.Duration // 最大重试间隔
+	Timeout          time.Duration // 请求超时时间
+}
+
+func (o *Options) fill() {
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 10
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 5 * time.Second
+	}
+	if o.MaxRetryInterval < o.RetryInterval {
+		o.MaxRetryInterval = o.RetryInterval
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Second
+	}
+}
+
+// NewDeliverer 创建webhook投递器
+func NewDeliverer(webhookModel model.IWebhook, deliveryModel model.IWebhookDelivery, opts Options) *Deliverer {
+	opts.fill()
+	return &Deliverer{
+		webhookModel:  webhookModel,
+		deliveryModel: deliveryModel,
+		opts:          opts,
+		client:        &http.Client{Timeout: opts.Timeout},
+		notify:        make(chan struct{}, 1),
+	}
+}
+
+// Deliverer webhook投递器(轮询待投递记录，以HMAC-SHA256签名后POST到订阅地址，失败时按退避策略重试)
+type Deliverer struct {
+	webhookModel  model.IWebhook
+	deliveryModel model.IWebhookDelivery
+	opts          Options
+	client        *http.Client
+	notify        chan struct{}
+	stop          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// Start 启动投递
+func (a *Deliverer) Start() {
+	a.stop = make(chan struct{})
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.run()
+	}()
+}
+
+// Stop 停止投递(等待当前批次投递完成)
+func (a *Deliverer) Stop() {
+	if a.stop == nil {
+		return
+	}
+	close(a.stop)
+	a.wg.Wait()
+	a.stop = nil
+}
+
+// Notify 通知投递器立即轮询
+func (a *Deliverer) Notify() {
+	if a == nil {
+		return
+	}
+
+	select {
+	case a.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (a *Deliverer) run() {
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := a.Deliver(icontext.NewAllTenants(context.Background()))
+			if err != nil {
+				logger.Errorf(context.Background(), "Deliver webhooks error: %s", err.Error())
+			}
+			if err != nil || n < a.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+		case <-a.notify:
+		}
+	}
+}
+
+// Deliver 投递一批到期的待投递记录，返回本批次的记录数量
+func (a *Deliverer) Deliver(ctx context.Context) (int, error) {
+	result, err := a.deliveryModel.Query(ctx, schema.WebhookDeliveryQueryParam{
+		PaginationParam: schema.PaginationParam{
+			Pagination: true,
+			NoCount:    true,
+			Current:    1,
+			PageSize:   uint(a.opts.BatchSize),
+		},
+		Status:      int(schema.WebhookDeliveryPending),
+		RetryBefore: time.Now(),
+	}, schema.WebhookDeliveryQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("next_retry_at", schema.OrderByASC)),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range result.Data {
+		hook, err := a.webhookModel.Get(ctx, item.WebhookID)
+		if err != nil {
+			return 0, err
+		}
+
+		if hook == nil || hook.Status != 1 {
+			item.Status = schema.WebhookDeliveryDead
+			item.LastError = "webhook is deleted or disabled"
+			err = a.deliveryModel.Update(ctx, item.ID, *item)
+		} else {
+			err = a.Send(ctx, hook, item)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(result.Data), nil
+}
+
+// Send 立即投递一次并保存投递结果(失败时根据投递次数计划重试或进入死信)
+func (a *Deliverer) Send(ctx context.Context, hook *schema.Webhook, item *schema.WebhookDelivery) error {
+	status, body, err := a.post(ctx, hook, item)
+
+	item.Attempts++
+	item.ResponseStatus = status
+	item.ResponseBody = body
+	if err == nil {
+		item.Status = schema.WebhookDeliverySucceeded
+		item.LastError = ""
+	} else {
+		item.LastError = err.Error()
+		if len(item.LastError) > 1024 {
+			item.LastError = item.LastError[:1024]
+		}
+
+		if item.Attempts >= a.opts.MaxRetries {
+			item.Status = schema.WebhookDeliveryDead
+			logger.Errorf(ctx, "Webhook delivery %s(%s) failed after %d attempts: %s", item.ID, item.EventType, item.Attempts, item.LastError)
+		} else {
+			item.Status = schema.WebhookDeliveryPending
+			item.NextRetryAt = time.Now().Add(event.Backoff(item.Attempts, a.opts.RetryInterval, a.opts.MaxRetryInterval))
+			logger.Warnf(ctx, "Webhook delivery %s(%s) failed, will retry at %s: %s", item.ID, item.EventType, item.NextRetryAt.Format(time.RFC3339), item.LastError)
+		}
+	}
+
+	return a.deliveryModel.Update(ctx, item.ID, *item)
+}
+
+func (a *Deliverer) post(ctx context.Context, hook *schema.Webhook, item *schema.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(item.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req = req.WithContext(ctx)
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderEvent, item.EventType)
+	req.Header.Set(HeaderDelivery, item.ID)
+	if hook.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(hook.Secret, timestamp, item.Payload))
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	buf, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(buf), fmt.Errorf("webhook %s responded with status %d", hook.URL, resp.StatusCode)
+	}
+	return resp.StatusCode, string(buf), nil
+}
+
+// Sign 计算投递内容的签名：sha256=hex(HMAC-SHA256(secret, timestamp + "." + body))
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 配置文件注册的webhook订阅名称
+const configWebhookName = "Event.Webhook"
+
+// NewSecret 生成签名密钥
+func NewSecret() string {
+	return util.SHA1HashString(uuid.NewID())
+}
+
+// Register 将地址注册为订阅全部事件的webhook订阅(已存在相同地址的订阅时跳过)
+func Register(ctx context.Context, webhookModel model.IWebhook, urls []string) error {
+	for _, url := range urls {
+		result, err := webhookModel.Query(ctx, schema.WebhookQueryParam{
+			PaginationParam: schema.PaginationParam{OnlyCount: true},
+			URL:             url,
+		})
+		if err != nil {
+			return err
+		} else if result.PageResult.Total > 0 {
+			continue
+		}
+
+		err = webhookModel.Create(ctx, schema.Webhook{
+			ID:     uuid.NewID(),
+			Name:   configWebhookName,
+			URL:    url,
+			Secret: NewSecret(),
+			Status: 1,
+			Memo:   "由配置文件[Event.Webhook]注册",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
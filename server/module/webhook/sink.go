@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/key7men/mag/pkg/util"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/schema"
+)
+
+var _ event.Sink = (*Sink)(nil)
+
+// NewSink 创建webhook订阅的事件投递目标
+func NewSink(webhookModel model.IWebhook, deliveryModel model.IWebhookDelivery, deliverer *Deliverer) *Sink {
+	return &Sink{
+		webhookModel:  webhookModel,
+		deliveryModel: deliveryModel,
+		deliverer:     deliverer,
+	}
+}
+
+// Sink 将领域事件扇出为各个匹配订阅的投递记录(实际投递由Deliverer异步完成)
+type Sink struct {
+	webhookModel  model.IWebhook
+	deliveryModel model.IWebhookDelivery
+	deliverer     *Deliverer
+}
+
+// Name 投递目标名称
+func (a *Sink) Name() string {
+	return "webhooks"
+}
+
+// Send 为订阅了该事件的启用状态的webhook创建投递记录(同一订阅同一事件只创建一次)
+func (a *Sink) Send(ctx context.Context, e *schema.Event) error {
+	result, err := a.webhookModel.Query(ctx, schema.WebhookQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	payload, err := NewPayload(e.ID, e.Type, e.CreatedAt, e.Payload)
+	if err != nil {
+		return err
+	}
+
+	created := false
+	for _, item := range result.Data {
+		if !item.Match(e.Type) {
+			continue
+		}
+
+		exists, err := a.deliveryModel.Query(ctx, schema.WebhookDeliveryQueryParam{
+			PaginationParam: schema.PaginationParam{OnlyCount: true},
+			WebhookID:       item.ID,
+			EventID:         e.ID,
+		})
+		if err != nil {
+			return err
+		} else if exists.PageResult.Total > 0 {
+			continue
+		}
+
+		err = a.deliveryModel.Create(ctx, NewDelivery(item.ID, e.ID, e.Type, payload))
+		if err != nil {
+			return err
+		}
+		created = true
+	}
+
+	if created {
+		a.deliverer.Notify()
+	}
+	return nil
+}
+
+// NewPayload 构建投递内容
+func NewPayload(eventID, eventType string, createdAt time.Time, data []byte) ([]byte, error) {
+	return util.JSONMarshal(&schema.WebhookPayload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: createdAt,
+		Data:      data,
+	})
+}
+
+// NewDelivery 创建待投递的记录
+func NewDelivery(webhookID, eventID, eventType string, payload []byte) schema.WebhookDelivery {
+	now := time.Now()
+	return schema.WebhookDelivery{
+		ID:          uuid.NewID(),
+		WebhookID:   webhookID,
+		EventID:     eventID,
+		EventType:   eventType,
+		Payload:     payload,
+		Status:      schema.WebhookDeliveryPending,
+		NextRetryAt: now,
+		CreatedAt:   now,
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/server/module/codegen"
+	"github.com/key7men/mag/server/module/openapi"
+	"github.com/key7men/mag/server/router"
+)
+
+// BuildOpenAPI 根据已注册的路由及接口文档生成OpenAPI文档(路由缺少文档时返回错误)
+func BuildOpenAPI(app *gin.Engine, r router.IRouter, version string) (*openapi.Document, error) {
+	return openapi.Build(openapi.Info{
+		Title:       "mag",
+		Description: "基于Gin+Angular开发的管理平台脚手架",
+		Version:     version,
+	}, app.Routes(), r.Docs(), r.Prefixes()...)
+}
+
+// InitOpenAPI 生成OpenAPI文档并注册访问路由(/openapi.json)
+func InitOpenAPI(app *gin.Engine, r router.IRouter, version string) error {
+	doc, err := BuildOpenAPI(app, r, version)
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	app.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", buf)
+	})
+	return nil
+}
+
+// 无需连接存储，仅注册路由(用于构建时生成文档及客户端)
+func registerRoutes() (*gin.Engine, router.IRouter, error) {
+	gin.SetMode(gin.ReleaseMode)
+	app := gin.New()
+	r := new(router.Router)
+	if err := r.Register(app); err != nil {
+		return nil, nil, err
+	}
+	return app, r, nil
+}
+
+// GenerateOpenAPI 无需连接存储，仅注册路由生成OpenAPI文档(用于构建时导出及检查文档)
+func GenerateOpenAPI(version string) ([]byte, error) {
+	app, r, err := registerRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := BuildOpenAPI(app, r, version)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// ClientOptions 客户端生成选项
+type ClientOptions struct {
+	GoOut     string // Go客户端包的输出目录(为空时不生成)
+	GoPackage string // Go客户端包名
+	TSOut     string // TypeScript数据结构及接口服务的输出目录(为空时不生成)
+}
+
+// GenerateClient 根据与OpenAPI文档相同的路由及接口文档生成Go及TypeScript客户端
+func GenerateClient(opts ClientOptions) error {
+	app, r, err := registerRoutes()
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := openapi.Collect(app.Routes(), r.Docs(), r.Prefixes()...)
+	if err != nil {
+		return err
+	}
+
+	if opts.GoOut != "" {
+		files, err := codegen.Go(endpoints, opts.GoPackage)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			if err := writeFile(filepath.Join(opts.GoOut, f.Name), f.Data); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.TSOut != "" {
+		files, err := codegen.TypeScript(endpoints)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(opts.TSOut, "models.ts"), files.Models); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(opts.TSOut, "api.service.ts"), files.Service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, 0644)
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 已提交的文件与重新生成的结果不一致时，需执行提示的命令重新生成
+func expectFile(t *testing.T, name string, data []byte, cmd string) {
+	t.Helper()
+	buf, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, data) {
+		t.Errorf("%s is out of date, run: %s", name, cmd)
+	}
+}
+
+func TestOpenAPIUpToDate(t *testing.T) {
+	buf, err := GenerateOpenAPI("1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectFile(t, "swagger/openapi.json", buf, "go run main.go openapi -o ./server/swagger/openapi.json")
+}
+
+func TestClientUpToDate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mag-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	goOut, tsOut := filepath.Join(dir, "go"), filepath.Join(dir, "ts")
+	err = GenerateClient(ClientOptions{GoOut: goOut, GoPackage: "client", TSOut: tsOut})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for out, target := range map[string]string{
+		goOut: "../pkg/client",
+		tsOut: "../web/src/app/core/api",
+	} {
+		files, err := ioutil.ReadDir(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			buf, err := ioutil.ReadFile(filepath.Join(out, f.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectFile(t, filepath.Join(target, f.Name()), buf, "go run main.go gen client")
+		}
+	}
+}
@@ -1,18 +1,17 @@
 package provider
 
 import (
-	"time"
-
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/persist"
 	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/module/rbac"
 )
 
 // InitCasbin 初始化casbin
 func InitCasbin(adapter persist.Adapter) (*casbin.SyncedEnforcer, func(), error) {
 	cfg := config.C.Casbin
 	if cfg.Model == "" {
-		return new(casbin.SyncedEnforcer), nil, nil
+		return new(casbin.SyncedEnforcer), func() {}, nil
 	}
 
 	e, err := casbin.NewSyncedEnforcer(cfg.Model)
@@ -27,13 +26,15 @@ func InitCasbin(adapter persist.Adapter) (*casbin.SyncedEnforcer, func(), error)
 	}
 	e.EnableEnforce(cfg.Enable)
 
-	cleanFunc := func() {}
-	if cfg.AutoLoad {
-		e.StartAutoLoadPolicy(time.Duration(cfg.AutoLoadInternal) * time.Second)
-		cleanFunc = func() {
-			e.StopAutoLoadPolicy()
+	// 定时加载策略(开关及间隔支持热更新)
+	loader := rbac.NewPolicyLoader(e)
+	loader.Start()
+	config.OnReload(func(old, new *config.Config) {
+		if old.Casbin.AutoLoad != new.Casbin.AutoLoad ||
+			old.Casbin.AutoLoadInternal != new.Casbin.AutoLoadInternal {
+			loader.Reset()
 		}
-	}
+	})
 
-	return e, cleanFunc, nil
+	return e, loader.Stop, nil
 }
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/go-redis/redis"
+	"github.com/key7men/mag/server/biz/impl"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/event"
+	"github.com/key7men/mag/server/module/webhook"
+)
+
+// InitEventDispatcher 初始化领域事件分发器(进程内订阅者及webhook订阅始终启用，redis stream按配置启用)
+func InitEventDispatcher(
+	outboxModel model.IOutbox,
+	webhookModel model.IWebhook,
+	deliveryModel model.IWebhookDelivery,
+	deliverer *webhook.Deliverer,
+	e *casbin.SyncedEnforcer,
+) (*event.Dispatcher, func(), error) {
+	cfg := config.C.Event
+
+	// 配置文件中的webhook地址注册为webhook订阅，与管理接口创建的订阅使用相同的投递机制
+	if c := cfg.Webhook; c.Enable && len(c.URLs) > 0 {
+		if err := webhook.Register(context.Background(), webhookModel, c.URLs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	bus := event.NewBus()
+	impl.SubscribeCasbinPolicy(bus, e)
+	sinks := []event.Sink{bus, webhook.NewSink(webhookModel, deliveryModel, deliverer)}
+
+	var redisSink *event.RedisStreamSink
+	if c := cfg.RedisStream; c.Enable {
+		rc := config.C.Redis
+		redisSink = event.NewRedisStreamSink(redis.NewClient(&redis.Options{
+			Addr:     rc.Addr,
+			Password: rc.Password,
+			DB:       c.RedisDB,
+		}), c.Stream, c.MaxLen)
+		sinks = append(sinks, redisSink)
+	}
+
+	dispatcher := event.NewDispatcher(outboxModel, event.Options{
+		Interval:         time.Duration(cfg.Interval) * time.Second,
+		BatchSize:        cfg.BatchSize,
+		MaxRetries:       cfg.MaxRetries,
+		RetryInterval:    time.Duration(cfg.RetryInterval) * time.Second,
+		MaxRetryInterval: time.Duration(cfg.MaxRetryInterval) * time.Second,
+		LeaseTimeout:     time.Duration(cfg.LeaseTimeout) * time.Second,
+	}, sinks...)
+	dispatcher.Start()
+
+	return dispatcher, func() {
+		dispatcher.Stop()
+		if redisSink != nil {
+			_ = redisSink.Close()
+		}
+	}, nil
+}
@@ -2,10 +2,13 @@ package provider
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 
 	"github.com/jinzhu/gorm"
 	"github.com/key7men/mag/server/config"
 	igorm "github.com/key7men/mag/server/model/gorm"
+	"github.com/key7men/mag/server/module/metrics"
 )
 
 // InitGormDB 初始化gorm存储
@@ -16,6 +19,13 @@ func InitGormDB() (*gorm.DB, func(), error) {
 		return nil, cleanFunc, err
 	}
 
+	if config.C.Metrics.Enable {
+		err = metrics.RegisterDB("gorm", db.DB())
+		if err != nil {
+			return nil, cleanFunc, err
+		}
+	}
+
 	if cfg.EnableAutoMigrate {
 		err = igorm.AutoMigrate(db)
 		if err != nil {
@@ -35,6 +45,9 @@ func NewGormDB() (*gorm.DB, func(), error) {
 		dsn = cfg.MySQL.DSN()
 	case "postgres":
 		dsn = cfg.Postgres.DSN()
+	case "sqlite3":
+		dsn = cfg.Sqlite3.DSN()
+		_ = os.MkdirAll(filepath.Dir(dsn), 0777)
 	default:
 		return nil, nil, errors.New("unknown db")
 	}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis"
+	"github.com/jinzhu/gorm"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/module/health"
+	"github.com/key7men/mag/server/module/rbac"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// InitGormHealth 初始化健康检查(gorm存储)
+func InitGormHealth(db *gorm.DB, adapter *rbac.CasbinAdapter) (*health.Health, func(), error) {
+	h, cleanFunc := newHealth(adapter)
+	h.Register("db", func(ctx context.Context) error {
+		return db.DB().PingContext(ctx)
+	})
+	return h, cleanFunc, nil
+}
+
+// InitMongoHealth 初始化健康检查(mongo存储)
+func InitMongoHealth(cli *mongo.Client, adapter *rbac.CasbinAdapter) (*health.Health, func(), error) {
+	h, cleanFunc := newHealth(adapter)
+	h.Register("db", func(ctx context.Context) error {
+		return cli.Ping(ctx, readpref.Primary())
+	})
+	return h, cleanFunc, nil
+}
+
+func newHealth(adapter *rbac.CasbinAdapter) (*health.Health, func()) {
+	cfg := config.C
+	h := health.New(0)
+
+	// 按使用redis的模块检查(同一个库只创建一个连接)
+	clients := make(map[int]*redis.Client)
+	addRedis := func(name string, db int) {
+		cli, ok := clients[db]
+		if !ok {
+			cli = redis.NewClient(&redis.Options{
+				Addr:     cfg.Redis.Addr,
+				Password: cfg.Redis.Password,
+				DB:       db,
+			})
+			clients[db] = cli
+		}
+		h.Register(name, func(ctx context.Context) error {
+			return cli.WithContext(ctx).Ping().Err()
+		})
+	}
+	if cfg.JWTAuth.Enable && cfg.JWTAuth.Store == "redis" {
+		addRedis("redis.jwt", cfg.JWTAuth.RedisDB)
+	}
+	if cfg.Captcha.Store == "redis" {
+		addRedis("redis.captcha", cfg.Captcha.RedisDB)
+	}
+	if cfg.RateLimiter.Enable && cfg.RateLimiter.Store == "redis" {
+		addRedis("redis.ratelimiter", cfg.RateLimiter.RedisDB)
+	}
+
+	if cfg.Casbin.Enable && cfg.Casbin.Model != "" {
+		h.Register("casbin", func(context.Context) error {
+			loaded, err := adapter.LoadState()
+			if err != nil {
+				return fmt.Errorf("load policy: %s", err.Error())
+			} else if !loaded {
+				return errors.New("policy not loaded")
+			}
+			return nil
+		})
+	}
+
+	if cfg.Menu.Enable && cfg.Menu.Data != "" {
+		h.Wait("menu")
+	}
+
+	return h, func() {
+		for _, cli := range clients {
+			_ = cli.Close()
+		}
+	}
+}
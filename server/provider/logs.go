@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/logs"
+)
+
+// InitLogRetention 初始化日志清理任务(未启用时不执行清理)
+func InitLogRetention(logModel model.ILog) (*logs.Retention, func(), error) {
+	cfg := config.C.LogRetention
+
+	retention := logs.NewRetention(logModel, logs.Options{
+		Interval:   time.Duration(cfg.Interval) * time.Second,
+		MaxAge:     time.Duration(cfg.MaxAge) * 24 * time.Hour,
+		BatchSize:  cfg.BatchSize,
+		ArchiveDir: cfg.ArchiveDir,
+	})
+	if cfg.Enable {
+		retention.Start()
+	}
+
+	return retention, retention.Stop, nil
+}
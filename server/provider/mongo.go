@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/key7men/mag/server/config"
+	imongo "github.com/key7men/mag/server/model/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// InitMongoClient 初始化mongo存储
+func InitMongoClient() (*mongo.Client, func(), error) {
+	cfg := config.C.Mongo
+	cli, cleanFunc, err := imongo.NewClient(&imongo.Config{
+		URI:     cfg.URI,
+		Timeout: cfg.Timeout,
+	})
+	if err != nil {
+		return nil, cleanFunc, err
+	}
+
+	err = imongo.CreateIndexes(context.Background(), cli)
+	if err != nil {
+		return nil, cleanFunc, err
+	}
+
+	return cli, cleanFunc, nil
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 	"github.com/key7men/mag/pkg/auth"
+	"github.com/key7men/mag/server/module/health"
+	"github.com/key7men/mag/server/module/logs"
+	"github.com/key7men/mag/server/router"
 )
 
 type Provider struct {
@@ -13,6 +16,10 @@ type Provider struct {
 	Auth		auth.Auther
 	CasbinEnforcer *casbin.SyncedEnforcer
 	MenuBiz		biz.IMenu
+	RBACBiz		biz.IRBAC
+	LogRetention	*logs.Retention
+	Health		*health.Health
+	Router		router.IRouter
 }
 
 var ProviderSet = wire.NewSet(wire.Struct(new(Provider), "*"))
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"github.com/key7men/mag/server/config"
 	"github.com/key7men/mag/server/middleware"
 	"github.com/key7men/mag/server/assist/gzip"
+	"github.com/key7men/mag/server/module/metrics"
 	"github.com/key7men/mag/server/router"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	swaggerFiles "github.com/swaggo/gin-swagger/swaggerFiles"
@@ -23,6 +24,9 @@ func InitGinEngine(r router.IRouter) *gin.Engine {
 	// Trace ID
 	app.Use(middleware.TraceMiddleware(middleware.AllowPathPrefixNoSkipper(prefixes...)))
 
+	// Metrics
+	app.Use(middleware.MetricsMiddleware(middleware.AllowPathPrefixNoSkipper(prefixes...)))
+
 	// Copy body
 	app.Use(middleware.CopyBodyMiddleware(middleware.AllowPathPrefixNoSkipper(prefixes...)))
 
@@ -48,9 +52,14 @@ func InitGinEngine(r router.IRouter) *gin.Engine {
 	// Router register
 	r.Register(app)
 
-	// Swagger
+	// Metrics endpoint
+	if c := config.C.Metrics; c.Enable && c.Addr == "" {
+		app.GET(c.Path, gin.WrapH(metrics.Handler()))
+	}
+
+	// Swagger(使用根据路由生成的OpenAPI文档)
 	if config.C.Swagger {
-		app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.json")))
 	}
 
 	// Static Website
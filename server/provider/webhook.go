@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/model"
+	"github.com/key7men/mag/server/module/webhook"
+)
+
+// InitWebhookDeliverer 初始化webhook订阅投递器
+func InitWebhookDeliverer(webhookModel model.IWebhook, deliveryModel model.IWebhookDelivery) (*webhook.Deliverer, func(), error) {
+	cfg := config.C.Webhook
+
+	deliverer := webhook.NewDeliverer(webhookModel, deliveryModel, webhook.Options{
+		Interval:         time.Duration(cfg.Interval) * time.Second,
+		BatchSize:        cfg.BatchSize,
+		MaxRetries:       cfg.MaxRetries,
+		RetryInterval:    time.Duration(cfg.RetryInterval) * time.Second,
+		MaxRetryInterval: time.Duration(cfg.MaxRetryInterval) * time.Second,
+		Timeout:          time.Duration(cfg.Timeout) * time.Second,
+	})
+	deliverer.Start()
+
+	return deliverer, deliverer.Stop, nil
+}
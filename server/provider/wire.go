@@ -11,15 +11,20 @@ import (
 	"github.com/google/wire"
 
 	gormModel "github.com/key7men/mag/server/model/gorm/dao"
+	mongoModel "github.com/key7men/mag/server/model/mongo/dao"
 )
 
 func BuildInjector() (*Provider, func(), error) {
 	// 依赖顺序有wire去帮你构建，此处只需将你的provider加进来就行
 	wire.Build(
 		InitGormDB,
+		InitGormHealth,
 		gormModel.ModelSet,
 		InitAuth,
 		InitCasbin,
+		InitEventDispatcher,
+		InitWebhookDeliverer,
+		InitLogRetention,
 		InitGinEngine,
 		impl.BizImplSet,
 		handler.HandlerSet,
@@ -28,4 +33,24 @@ func BuildInjector() (*Provider, func(), error) {
 		ProviderSet,
 	)
 	return new(Provider), nil, nil // 本质上返回值没有任何含义
-}
\ No newline at end of file
+}
+
+func BuildMongoInjector() (*Provider, func(), error) {
+	wire.Build(
+		InitMongoClient,
+		InitMongoHealth,
+		mongoModel.ModelSet,
+		InitAuth,
+		InitCasbin,
+		InitEventDispatcher,
+		InitWebhookDeliverer,
+		InitLogRetention,
+		InitGinEngine,
+		impl.BizImplSet,
+		handler.HandlerSet,
+		router.RouterSet,
+		rbac.CasbinAdapterSet,
+		ProviderSet,
+	)
+	return new(Provider), nil, nil
+}
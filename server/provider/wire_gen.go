@@ -9,6 +9,7 @@ import (
 	"github.com/key7men/mag/server/biz/impl"
 	"github.com/key7men/mag/server/handler"
 	"github.com/key7men/mag/server/model/gorm/dao"
+	dao2 "github.com/key7men/mag/server/model/mongo/dao"
 	"github.com/key7men/mag/server/module/rbac"
 	"github.com/key7men/mag/server/router"
 )
@@ -40,12 +41,16 @@ func BuildInjector() (*Provider, func(), error) {
 	userRole := &dao.UserRole{
 		DB: db,
 	}
+	tenant := &dao.Tenant{
+		DB: db,
+	}
 	casbinAdapter := &rbac.CasbinAdapter{
 		RoleModel:         role,
 		RoleMenuModel:     roleMenu,
 		MenuResourceModel: menuActionResource,
 		UserModel:         user,
 		UserRoleModel:     userRole,
+		TenantModel:       tenant,
 	}
 	syncedEnforcer, cleanup3, err := InitCasbin(casbinAdapter)
 	if err != nil {
@@ -53,15 +58,77 @@ func BuildInjector() (*Provider, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
+	audit := &dao.Audit{
+		DB: db,
+	}
+	implAudit := &impl.Audit{
+		AuditModel: audit,
+	}
+	handlerAudit := &handler.Audit{
+		AuditBiz: implAudit,
+	}
+	config := &handler.Config{}
+	trans := &dao.Trans{
+		DB: db,
+	}
 	demo := &dao.Demo{
 		DB: db,
 	}
+	outbox := &dao.Outbox{
+		DB: db,
+	}
+	webhook := &dao.Webhook{
+		DB: db,
+	}
+	webhookDelivery := &dao.WebhookDelivery{
+		DB: db,
+	}
+	deliverer, cleanup4, err := InitWebhookDeliverer(webhook, webhookDelivery)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dispatcher, cleanup5, err := InitEventDispatcher(outbox, webhook, webhookDelivery, deliverer, syncedEnforcer)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	implDemo := &impl.Demo{
-		DemoModel: demo,
+		TransModel:      trans,
+		DemoModel:       demo,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		EventDispatcher: dispatcher,
 	}
 	handlerDemo := &handler.Demo{
 		DemoBiz: implDemo,
 	}
+	health, cleanup6, err := InitGormHealth(db, casbinAdapter)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	handlerHealth := &handler.Health{
+		Health: health,
+	}
+	log := &dao.Log{
+		DB: db,
+	}
+	implLog := &impl.Log{
+		LogModel: log,
+	}
+	handlerLog := &handler.Log{
+		LogBiz: implLog,
+	}
 	menu := &dao.Menu{
 		DB: db,
 	}
@@ -76,59 +143,387 @@ func BuildInjector() (*Provider, func(), error) {
 		RoleMenuModel:   roleMenu,
 		MenuModel:       menu,
 		MenuActionModel: menuAction,
+		TenantModel:     tenant,
 	}
 	handlerLogin := &handler.Login{
 		LoginBiz: login,
 	}
-	trans := &dao.Trans{
-		DB: db,
+	implMenu := &impl.Menu{
+		TransModel:              trans,
+		MenuModel:               menu,
+		MenuActionModel:         menuAction,
+		MenuActionResourceModel: menuActionResource,
+		RoleMenuModel:           roleMenu,
+		AuditModel:              audit,
+		OutboxModel:             outbox,
+		EventDispatcher:         dispatcher,
+	}
+	handlerMenu := &handler.Menu{
+		MenuBll: implMenu,
+	}
+	implRole := &impl.Role{
+		TransModel:      trans,
+		RoleModel:       role,
+		RoleMenuModel:   roleMenu,
+		MenuModel:       menu,
+		MenuActionModel: menuAction,
+		UserModel:       user,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		EventDispatcher: dispatcher,
+	}
+	handlerRole := &handler.Role{
+		RoleBll: implRole,
+	}
+	implRBAC := &impl.RBAC{
+		TransModel:              trans,
+		MenuModel:               menu,
+		MenuActionModel:         menuAction,
+		MenuActionResourceModel: menuActionResource,
+		RoleModel:               role,
+		RoleMenuModel:           roleMenu,
+		AuditModel:              audit,
+		OutboxModel:             outbox,
+		EventDispatcher:         dispatcher,
+		MenuBiz:                 implMenu,
+		RoleBiz:                 implRole,
+	}
+	handlerRBAC := &handler.RBAC{
+		RBACBiz: implRBAC,
+	}
+	implUser := &impl.User{
+		TransModel:      trans,
+		UserModel:       user,
+		UserRoleModel:   userRole,
+		RoleModel:       role,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		TenantModel:     tenant,
+		EventDispatcher: dispatcher,
+	}
+	implTenant := &impl.Tenant{
+		TransModel:      trans,
+		TenantModel:     tenant,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		EventDispatcher: dispatcher,
+		UserBiz:         implUser,
+	}
+	handlerTenant := &handler.Tenant{
+		TenantBiz: implTenant,
+	}
+	handlerUser := &handler.User{
+		UserBll: implUser,
+	}
+	implWebhook := &impl.Webhook{
+		TransModel:           trans,
+		WebhookModel:         webhook,
+		WebhookDeliveryModel: webhookDelivery,
+		Deliverer:            deliverer,
+	}
+	handlerWebhook := &handler.Webhook{
+		WebhookBiz: implWebhook,
+	}
+	routerRouter := &router.Router{
+		Auth:           auther,
+		CasbinEnforcer: syncedEnforcer,
+		TenantBiz:      implTenant,
+		AuditAPI:       handlerAudit,
+		ConfigAPI:      config,
+		DemoAPI:        handlerDemo,
+		HealthAPI:      handlerHealth,
+		LogAPI:         handlerLog,
+		LoginAPI:       handlerLogin,
+		MenuAPI:        handlerMenu,
+		RBACAPI:        handlerRBAC,
+		RoleAPI:        handlerRole,
+		TenantAPI:      handlerTenant,
+		UserAPI:        handlerUser,
+		WebhookAPI:     handlerWebhook,
+	}
+	engine := InitGinEngine(routerRouter)
+	retention, cleanup7, err := InitLogRetention(log)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	provider := &Provider{
+		Engine:         engine,
+		Auth:           auther,
+		CasbinEnforcer: syncedEnforcer,
+		MenuBiz:        implMenu,
+		RBACBiz:        implRBAC,
+		LogRetention:   retention,
+		Health:         health,
+		Router:         routerRouter,
+	}
+	return provider, func() {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func BuildMongoInjector() (*Provider, func(), error) {
+	auther, cleanup, err := InitAuth()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, cleanup2, err := InitMongoClient()
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	role := &dao2.Role{
+		Client: client,
+	}
+	roleMenu := &dao2.RoleMenu{
+		Client: client,
+	}
+	menuActionResource := &dao2.MenuActionResource{
+		Client: client,
+	}
+	user := &dao2.User{
+		Client: client,
+	}
+	userRole := &dao2.UserRole{
+		Client: client,
+	}
+	tenant := &dao2.Tenant{
+		Client: client,
+	}
+	casbinAdapter := &rbac.CasbinAdapter{
+		RoleModel:         role,
+		RoleMenuModel:     roleMenu,
+		MenuResourceModel: menuActionResource,
+		UserModel:         user,
+		UserRoleModel:     userRole,
+		TenantModel:       tenant,
+	}
+	syncedEnforcer, cleanup3, err := InitCasbin(casbinAdapter)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	audit := &dao2.Audit{
+		Client: client,
+	}
+	implAudit := &impl.Audit{
+		AuditModel: audit,
+	}
+	handlerAudit := &handler.Audit{
+		AuditBiz: implAudit,
+	}
+	config := &handler.Config{}
+	trans := &dao2.Trans{
+		Client: client,
+	}
+	demo := &dao2.Demo{
+		Client: client,
+	}
+	outbox := &dao2.Outbox{
+		Client: client,
+	}
+	webhook := &dao2.Webhook{
+		Client: client,
+	}
+	webhookDelivery := &dao2.WebhookDelivery{
+		Client: client,
+	}
+	deliverer, cleanup4, err := InitWebhookDeliverer(webhook, webhookDelivery)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	dispatcher, cleanup5, err := InitEventDispatcher(outbox, webhook, webhookDelivery, deliverer, syncedEnforcer)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	implDemo := &impl.Demo{
+		TransModel:      trans,
+		DemoModel:       demo,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		EventDispatcher: dispatcher,
+	}
+	handlerDemo := &handler.Demo{
+		DemoBiz: implDemo,
+	}
+	health, cleanup6, err := InitMongoHealth(client, casbinAdapter)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	handlerHealth := &handler.Health{
+		Health: health,
+	}
+	log := &dao2.Log{
+		Client: client,
+	}
+	implLog := &impl.Log{
+		LogModel: log,
+	}
+	handlerLog := &handler.Log{
+		LogBiz: implLog,
+	}
+	menu := &dao2.Menu{
+		Client: client,
+	}
+	menuAction := &dao2.MenuAction{
+		Client: client,
+	}
+	login := &impl.Login{
+		Auth:            auther,
+		UserModel:       user,
+		UserRoleModel:   userRole,
+		RoleModel:       role,
+		RoleMenuModel:   roleMenu,
+		MenuModel:       menu,
+		MenuActionModel: menuAction,
+		TenantModel:     tenant,
+	}
+	handlerLogin := &handler.Login{
+		LoginBiz: login,
 	}
 	implMenu := &impl.Menu{
 		TransModel:              trans,
 		MenuModel:               menu,
 		MenuActionModel:         menuAction,
 		MenuActionResourceModel: menuActionResource,
+		RoleMenuModel:           roleMenu,
+		AuditModel:              audit,
+		OutboxModel:             outbox,
+		EventDispatcher:         dispatcher,
 	}
 	handlerMenu := &handler.Menu{
 		MenuBll: implMenu,
 	}
 	implRole := &impl.Role{
-		Enforcer:      syncedEnforcer,
-		TransModel:    trans,
-		RoleModel:     role,
-		RoleMenuModel: roleMenu,
-		UserModel:     user,
+		TransModel:      trans,
+		RoleModel:       role,
+		RoleMenuModel:   roleMenu,
+		MenuModel:       menu,
+		MenuActionModel: menuAction,
+		UserModel:       user,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		EventDispatcher: dispatcher,
 	}
 	handlerRole := &handler.Role{
 		RoleBll: implRole,
 	}
+	implRBAC := &impl.RBAC{
+		TransModel:              trans,
+		MenuModel:               menu,
+		MenuActionModel:         menuAction,
+		MenuActionResourceModel: menuActionResource,
+		RoleModel:               role,
+		RoleMenuModel:           roleMenu,
+		AuditModel:              audit,
+		OutboxModel:             outbox,
+		EventDispatcher:         dispatcher,
+		MenuBiz:                 implMenu,
+		RoleBiz:                 implRole,
+	}
+	handlerRBAC := &handler.RBAC{
+		RBACBiz: implRBAC,
+	}
 	implUser := &impl.User{
-		Enforcer:      syncedEnforcer,
-		TransModel:    trans,
-		UserModel:     user,
-		UserRoleModel: userRole,
-		RoleModel:     role,
+		TransModel:      trans,
+		UserModel:       user,
+		UserRoleModel:   userRole,
+		RoleModel:       role,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		TenantModel:     tenant,
+		EventDispatcher: dispatcher,
+	}
+	implTenant := &impl.Tenant{
+		TransModel:      trans,
+		TenantModel:     tenant,
+		AuditModel:      audit,
+		OutboxModel:     outbox,
+		EventDispatcher: dispatcher,
+		UserBiz:         implUser,
+	}
+	handlerTenant := &handler.Tenant{
+		TenantBiz: implTenant,
 	}
 	handlerUser := &handler.User{
 		UserBll: implUser,
 	}
+	implWebhook := &impl.Webhook{
+		TransModel:           trans,
+		WebhookModel:         webhook,
+		WebhookDeliveryModel: webhookDelivery,
+		Deliverer:            deliverer,
+	}
+	handlerWebhook := &handler.Webhook{
+		WebhookBiz: implWebhook,
+	}
 	routerRouter := &router.Router{
 		Auth:           auther,
 		CasbinEnforcer: syncedEnforcer,
+		TenantBiz:      implTenant,
+		AuditAPI:       handlerAudit,
+		ConfigAPI:      config,
 		DemoAPI:        handlerDemo,
+		HealthAPI:      handlerHealth,
+		LogAPI:         handlerLog,
 		LoginAPI:       handlerLogin,
 		MenuAPI:        handlerMenu,
+		RBACAPI:        handlerRBAC,
 		RoleAPI:        handlerRole,
+		TenantAPI:      handlerTenant,
 		UserAPI:        handlerUser,
+		WebhookAPI:     handlerWebhook,
 	}
 	engine := InitGinEngine(routerRouter)
+	retention, cleanup7, err := InitLogRetention(log)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	provider := &Provider{
 		Engine:         engine,
 		Auth:           auther,
 		CasbinEnforcer: syncedEnforcer,
 		MenuBiz:        implMenu,
+		RBACBiz:        implRBAC,
+		LogRetention:   retention,
+		Health:         health,
+		Router:         routerRouter,
 	}
 	return provider, func() {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
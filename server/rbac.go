@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/pkg/logger"
+	"github.com/key7men/mag/server/assist/uuid"
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/provider"
+	"github.com/key7men/mag/server/schema"
+)
+
+// RBACOptions 命令行导出及导入RBAC模型配置的参数
+type RBACOptions struct {
+	ConfigFile string // 配置文件
+	File       string // 导出或导入的文件(为空时使用标准输出或标准输入)
+	Format     string // 文件格式(yaml或json，为空时按文件扩展名识别，默认为yaml)
+	DryRun     bool   // 是否仅对比差异(导入)
+	Prune      bool   // 是否删除文档中不存在的数据(导入)
+}
+
+func (a RBACOptions) format() (string, error) {
+	if a.Format != "" {
+		return schema.ParseRBACFormat(a.Format)
+	}
+	return schema.ParseRBACFormat(filepath.Ext(a.File))
+}
+
+// 连接存储并创建依赖注入器(不启动HTTP服务，不加载casbin权限策略，标准输出仅用于导出数据)
+func buildRBACInjector(configFile string) (*provider.Provider, func(), error) {
+	config.MustLoad(configFile)
+	config.Override(func(c *config.Config) {
+		c.RunMode = gin.ReleaseMode
+		c.Casbin.Enable = false
+		c.Casbin.Model = ""
+		c.Casbin.AutoLoad = false
+	})
+
+	uuid.InitID()
+	if err := InitI18n(); err != nil {
+		return nil, nil, err
+	}
+	return BuildInjector()
+}
+
+// ExportRBAC 导出菜单、动作、资源、角色及授权(命令行)
+func ExportRBAC(ctx context.Context, opts RBACOptions) error {
+	format, err := opts.format()
+	if err != nil {
+		return err
+	}
+
+	injector, cleanFunc, err := buildRBACInjector(opts.ConfigFile)
+	if err != nil {
+		return err
+	}
+	defer cleanFunc()
+
+	doc, err := injector.RBACBiz.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	buf, err := schema.MarshalRBACDocument(doc, format)
+	if err != nil {
+		return err
+	}
+
+	if opts.File == "" {
+		_, err = os.Stdout.Write(buf)
+		return err
+	}
+	return ioutil.WriteFile(opts.File, buf, 0644)
+}
+
+// ImportRBAC 按业务标识对比并导入菜单、动作、资源、角色及授权(命令行)
+// 权限策略由运行中的服务定期加载(Casbin.AutoLoad)或重启后生效
+func ImportRBAC(ctx context.Context, opts RBACOptions) (*schema.RBACImportResult, error) {
+	format, err := opts.format()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf []byte
+	if opts.File == "" {
+		buf, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		buf, err = ioutil.ReadFile(opts.File)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := schema.UnmarshalRBACDocument(buf, format)
+	if err != nil {
+		return nil, err
+	}
+
+	injector, cleanFunc, err := buildRBACInjector(opts.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanFunc()
+
+	return injector.RBACBiz.Import(ctx, doc, schema.RBACImportParam{
+		DryRun: opts.DryRun,
+		Prune:  opts.Prune,
+	})
+}
+
+// InitMenuData 初始化菜单数据(Menu.Merge启用时合并数据文件，否则仅在不存在菜单时初始化)
+func InitMenuData(ctx context.Context, injector *provider.Provider) error {
+	cfg := config.C.Menu
+	if !cfg.Merge {
+		return injector.MenuBiz.InitData(ctx, cfg.Data)
+	}
+
+	result, err := injector.RBACBiz.MergeMenus(ctx, cfg.Data, cfg.Prune)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range result.Changes {
+		if item.Op == schema.RBACOpUpdate {
+			logger.Infof(ctx, "菜单数据合并：%s %s %s (%s -> %s)", item.Op, item.Kind, item.Key, item.From, item.To)
+			continue
+		}
+		logger.Infof(ctx, "菜单数据合并：%s %s %s", item.Op, item.Kind, item.Key)
+	}
+	logger.Printf(ctx, "菜单数据合并完成，新建：%d，更新：%d，删除：%d", result.Created, result.Updated, result.Deleted)
+	return nil
+}
@@ -0,0 +1,152 @@
+package router
+
+import (
+	"github.com/key7men/mag/server/config"
+	"github.com/key7men/mag/server/module/openapi"
+	"github.com/key7men/mag/server/schema"
+)
+
+// 导出接口的响应内容类型
+const exportContentType = "text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// 导出接口的文件格式参数
+var exportFormatParam = &openapi.Parameter{Name: "format", In: "query", Description: "文件格式(csv或xlsx，默认为csv)", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"csv", "xlsx"}}}
+
+// Docs 接口文档(新增或修改路由时需同步维护，缺少文档的路由会导致生成OpenAPI文档失败)
+func (r *Router) Docs() openapi.Routes {
+	return openapi.Routes{
+		// 健康检查
+		"GET /healthz": {Name: "Live", Summary: "存活检查", Tags: []string{"健康检查"}, Public: true, Response: schema.HealthResult{}},
+		"GET /readyz":  {Name: "Ready", Summary: "就绪检查(依赖不可用时返回503)", Tags: []string{"健康检查"}, Public: true, Response: schema.HealthResult{}},
+
+		// 登录管理
+		"GET /api/v1/pub/login/captchaid": {Name: "GetCaptchaID", Summary: "获取验证码ID", Tags: []string{"登录管理"}, Public: true, Response: schema.LoginCaptcha{}},
+		"GET /api/v1/pub/login/captcha": {Name: "GetCaptchaPic", Summary: "获取验证码图片", Tags: []string{"登录管理"}, Public: true, ContentType: "image/png",
+			Params: []*openapi.Parameter{
+				{Name: "id", In: "query", Description: "验证码ID", Required: true, Schema: &openapi.Schema{Type: "string"}},
+				{Name: "reload", In: "query", Description: "是否重新生成(不为空时重新生成)", Schema: &openapi.Schema{Type: "string"}},
+			}},
+		"POST /api/v1/pub/login":           {Name: "Login", Token: openapi.TokenSet, Summary: "用户登录", Tags: []string{"登录管理"}, Public: true, Body: schema.LoginParam{}, Response: schema.LoginTokenInfo{}},
+		"POST /api/v1/pub/login/exit":      {Name: "Logout", Token: openapi.TokenClear, Summary: "用户登出", Tags: []string{"登录管理"}, Public: true, NoBody: true, Response: openapi.OK},
+		"POST /api/v1/pub/refresh-token":   {Name: "RefreshToken", Token: openapi.TokenSet, Summary: "刷新令牌", Tags: []string{"登录管理"}, NoBody: true, Response: schema.LoginTokenInfo{}},
+		"GET /api/v1/pub/current/user":     {Name: "GetCurrentUser", Summary: "获取当前用户信息", Tags: []string{"登录管理"}, Response: schema.UserLoginInfo{}},
+		"GET /api/v1/pub/current/menutree": {Name: "QueryCurrentMenuTree", Summary: "查询当前用户菜单树", Tags: []string{"登录管理"}, Response: openapi.List(schema.MenuTree{})},
+		"PUT /api/v1/pub/current/password": {Name: "UpdatePassword", Summary: "更新个人密码", Tags: []string{"登录管理"}, Body: schema.UpdatePasswordParam{}, Response: openapi.OK},
+
+		// 审计记录
+		"GET /api/v1/audits":     {Name: "QueryAudit", Summary: "查询审计记录", Tags: []string{"审计记录"}, Query: schema.AuditQueryParam{}, Whitelist: &schema.AuditQueryWhitelist, Response: openapi.Page(schema.Audit{})},
+		"GET /api/v1/audits/:id": {Name: "GetAudit", Summary: "查询指定审计记录", Tags: []string{"审计记录"}, Response: schema.Audit{}},
+
+		// 示例程序
+		"GET /api/v1/demos":               {Name: "QueryDemo", Summary: "查询数据", Tags: []string{"示例程序"}, Query: schema.DemoQueryParam{}, Whitelist: &schema.DemoQueryWhitelist, Response: openapi.Page(schema.Demo{})},
+		"GET /api/v1/demos/:id":           {Name: "GetDemo", Summary: "查询指定数据", Tags: []string{"示例程序"}, Response: schema.Demo{}},
+		"POST /api/v1/demos":              {Name: "CreateDemo", Summary: "创建数据", Tags: []string{"示例程序"}, Body: schema.Demo{}, Response: schema.IDResult{}},
+		"PUT /api/v1/demos/:id":           {Name: "UpdateDemo", Summary: "更新数据", Tags: []string{"示例程序"}, Body: schema.Demo{}, Response: openapi.OK},
+		"DELETE /api/v1/demos/:id":        {Name: "DeleteDemo", Summary: "删除数据", Tags: []string{"示例程序"}, Response: openapi.OK},
+		"PATCH /api/v1/demos/:id/enable":  {Name: "EnableDemo", Summary: "启用数据", Tags: []string{"示例程序"}, Response: openapi.OK},
+		"PATCH /api/v1/demos/:id/disable": {Name: "DisableDemo", Summary: "禁用数据", Tags: []string{"示例程序"}, Response: openapi.OK},
+		"GET /api/v1/demos.export": {Name: "ExportDemo", Summary: "导出数据", Tags: []string{"示例程序"}, Whitelist: &schema.DemoQueryWhitelist, ContentType: exportContentType,
+			Params: []*openapi.Parameter{
+				{Name: "queryValue", In: "query", Description: "模糊查询", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "status", In: "query", Description: "状态(1:启用 2:停用)", Schema: &openapi.Schema{Type: "integer"}},
+				exportFormatParam,
+			}},
+
+		// 日志管理
+		"GET /api/v1/logs":                 {Name: "QueryLog", Summary: "查询日志", Tags: []string{"日志管理"}, Query: schema.LogQueryParam{}, Whitelist: &schema.LogQueryWhitelist, Response: openapi.Page(schema.Log{})},
+		"GET /api/v1/logs/traces/:traceID": {Name: "QueryLogTrace", Summary: "查询跟踪ID的完整日志链路", Tags: []string{"日志管理"}, Response: openapi.List(schema.Log{})},
+
+		// 菜单管理
+		"GET /api/v1/menus":               {Name: "QueryMenu", Summary: "查询数据", Tags: []string{"菜单管理"}, Query: schema.MenuQueryParam{}, Whitelist: &schema.MenuQueryWhitelist, Response: openapi.Page(schema.Menu{})},
+		"GET /api/v1/menus.tree":          {Name: "QueryMenuTree", Summary: "查询菜单树", Tags: []string{"菜单管理"}, Query: schema.MenuQueryParam{}, Response: openapi.List(schema.MenuTree{})},
+		"GET /api/v1/menus/:id":           {Name: "GetMenu", Summary: "查询指定数据", Tags: []string{"菜单管理"}, Response: schema.Menu{}},
+		"POST /api/v1/menus":              {Name: "CreateMenu", Summary: "创建数据", Tags: []string{"菜单管理"}, Body: schema.Menu{}, Response: schema.IDResult{}},
+		"PUT /api/v1/menus/:id":           {Name: "UpdateMenu", Summary: "更新数据", Tags: []string{"菜单管理"}, Body: schema.Menu{}, Response: openapi.OK},
+		"DELETE /api/v1/menus/:id":        {Name: "DeleteMenu", Summary: "删除数据", Tags: []string{"菜单管理"}, Response: openapi.OK},
+		"PATCH /api/v1/menus/:id/enable":  {Name: "EnableMenu", Summary: "启用数据", Tags: []string{"菜单管理"}, Response: openapi.OK},
+		"PATCH /api/v1/menus/:id/disable": {Name: "DisableMenu", Summary: "禁用数据", Tags: []string{"菜单管理"}, Response: openapi.OK},
+		"POST /api/v1/menus.bulk/create":  {Name: "BulkCreateMenu", Summary: "批量创建数据", Tags: []string{"菜单管理"}, Body: schema.BulkMenuCreateParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/menus.bulk/enable":  {Name: "BulkEnableMenu", Summary: "批量启用数据", Tags: []string{"菜单管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/menus.bulk/disable": {Name: "BulkDisableMenu", Summary: "批量禁用数据", Tags: []string{"菜单管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/menus.bulk/delete":  {Name: "BulkDeleteMenu", Summary: "批量删除数据", Tags: []string{"菜单管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+
+		// 权限配置
+		"GET /api/v1/rbac.export": {Name: "ExportRBAC", Summary: "导出菜单、动作、资源、角色及授权", Tags: []string{"权限配置"}, ContentType: "application/x-yaml, application/json",
+			Params: []*openapi.Parameter{
+				{Name: "format", In: "query", Description: "文件格式(yaml或json，默认为yaml)", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"yaml", "json"}}},
+			}},
+		"POST /api/v1/rbac.import": {Name: "ImportRBAC", Summary: "按业务标识对比并导入菜单、动作、资源、角色及授权", Tags: []string{"权限配置"}, Query: schema.RBACImportParam{}, Body: schema.RBACDocument{}, Response: schema.RBACImportResult{}},
+
+		// 角色管理
+		"GET /api/v1/roles":        {Name: "QueryRole", Summary: "查询数据", Tags: []string{"角色管理"}, Query: schema.RoleQueryParam{}, Whitelist: &schema.RoleQueryWhitelist, Response: openapi.Page(schema.Role{})},
+		"GET /api/v1/roles.select": {Name: "QueryRoleSelect", Summary: "查询选择数据", Tags: []string{"角色管理"}, Query: schema.RoleQueryParam{}, Response: openapi.List(schema.Role{})},
+		"GET /api/v1/roles.export": {Name: "ExportRole", Summary: "导出数据及菜单授权", Tags: []string{"角色管理"}, Whitelist: &schema.RoleQueryWhitelist, ContentType: exportContentType,
+			Params: []*openapi.Parameter{
+				{Name: "queryValue", In: "query", Description: "模糊查询", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "status", In: "query", Description: "状态(1:启用 2:禁用)", Schema: &openapi.Schema{Type: "integer"}},
+				exportFormatParam,
+			}},
+		"GET /api/v1/roles/:id":           {Name: "GetRole", Summary: "查询指定数据", Tags: []string{"角色管理"}, Response: schema.Role{}},
+		"POST /api/v1/roles":              {Name: "CreateRole", Summary: "创建数据", Tags: []string{"角色管理"}, Body: schema.Role{}, Response: schema.IDResult{}},
+		"PUT /api/v1/roles/:id":           {Name: "UpdateRole", Summary: "更新数据", Tags: []string{"角色管理"}, Body: schema.Role{}, Response: openapi.OK},
+		"DELETE /api/v1/roles/:id":        {Name: "DeleteRole", Summary: "删除数据", Tags: []string{"角色管理"}, Response: openapi.OK},
+		"PATCH /api/v1/roles/:id/enable":  {Name: "EnableRole", Summary: "启用数据", Tags: []string{"角色管理"}, Response: openapi.OK},
+		"PATCH /api/v1/roles/:id/disable": {Name: "DisableRole", Summary: "禁用数据", Tags: []string{"角色管理"}, Response: openapi.OK},
+		"POST /api/v1/roles.bulk/create":  {Name: "BulkCreateRole", Summary: "批量创建数据", Tags: []string{"角色管理"}, Body: schema.BulkRoleCreateParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/roles.bulk/enable":  {Name: "BulkEnableRole", Summary: "批量启用数据", Tags: []string{"角色管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/roles.bulk/disable": {Name: "BulkDisableRole", Summary: "批量禁用数据", Tags: []string{"角色管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/roles.bulk/delete":  {Name: "BulkDeleteRole", Summary: "批量删除数据", Tags: []string{"角色管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+
+		// 租户管理
+		"GET /api/v1/tenants":               {Name: "QueryTenant", Summary: "查询数据", Tags: []string{"租户管理"}, Query: schema.TenantQueryParam{}, Response: openapi.Page(schema.Tenant{})},
+		"GET /api/v1/tenants/:id":           {Name: "GetTenant", Summary: "查询指定数据", Tags: []string{"租户管理"}, Response: schema.Tenant{}},
+		"POST /api/v1/tenants":              {Name: "CreateTenant", Summary: "创建数据(同时开通租户管理员)", Tags: []string{"租户管理"}, Body: schema.Tenant{}, Response: schema.IDResult{}},
+		"PUT /api/v1/tenants/:id":           {Name: "UpdateTenant", Summary: "更新数据", Tags: []string{"租户管理"}, Body: schema.Tenant{}, Response: openapi.OK},
+		"DELETE /api/v1/tenants/:id":        {Name: "DeleteTenant", Summary: "删除数据", Tags: []string{"租户管理"}, Response: openapi.OK},
+		"PATCH /api/v1/tenants/:id/enable":  {Name: "EnableTenant", Summary: "启用数据", Tags: []string{"租户管理"}, Response: openapi.OK},
+		"PATCH /api/v1/tenants/:id/disable": {Name: "DisableTenant", Summary: "禁用数据", Tags: []string{"租户管理"}, Response: openapi.OK},
+
+		// 用户管理
+		"GET /api/v1/users": {Name: "QueryUser", Summary: "查询数据", Tags: []string{"用户管理"}, Query: schema.UserQueryParam{}, Whitelist: &schema.UserQueryWhitelist, Response: openapi.Page(schema.UserShow{}),
+			Params: []*openapi.Parameter{
+				{Name: "roleIDs", In: "query", Description: "角色ID列表(逗号分隔)", Schema: &openapi.Schema{Type: "string"}},
+			}},
+		"GET /api/v1/users/:id":           {Name: "GetUser", Summary: "查询指定数据", Tags: []string{"用户管理"}, Response: schema.User{}},
+		"POST /api/v1/users":              {Name: "CreateUser", Summary: "创建数据", Tags: []string{"用户管理"}, Body: schema.User{}, Response: schema.IDResult{}},
+		"PUT /api/v1/users/:id":           {Name: "UpdateUser", Summary: "更新数据", Tags: []string{"用户管理"}, Body: schema.User{}, Response: openapi.OK},
+		"DELETE /api/v1/users/:id":        {Name: "DeleteUser", Summary: "删除数据", Tags: []string{"用户管理"}, Response: openapi.OK},
+		"PATCH /api/v1/users/:id/enable":  {Name: "EnableUser", Summary: "启用数据", Tags: []string{"用户管理"}, Response: openapi.OK},
+		"PATCH /api/v1/users/:id/disable": {Name: "DisableUser", Summary: "禁用数据", Tags: []string{"用户管理"}, Response: openapi.OK},
+		"GET /api/v1/users.export": {Name: "ExportUser", Summary: "导出数据", Tags: []string{"用户管理"}, Whitelist: &schema.UserQueryWhitelist, ContentType: exportContentType,
+			Params: []*openapi.Parameter{
+				{Name: "userName", In: "query", Description: "用户名", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "queryValue", In: "query", Description: "模糊查询", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "status", In: "query", Description: "用户状态(1:启用 2:停用)", Schema: &openapi.Schema{Type: "integer"}},
+				{Name: "roleIDs", In: "query", Description: "角色ID列表(逗号分隔)", Schema: &openapi.Schema{Type: "string"}},
+				exportFormatParam,
+			}},
+		"POST /api/v1/users.import":       {Name: "ImportUser", Summary: "导入数据", Tags: []string{"用户管理"}, Query: schema.ImportParam{}, Upload: "file", Response: schema.ImportResult{}},
+		"POST /api/v1/users.bulk/create":  {Name: "BulkCreateUser", Summary: "批量创建数据", Tags: []string{"用户管理"}, Body: schema.BulkUserCreateParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/users.bulk/enable":  {Name: "BulkEnableUser", Summary: "批量启用数据", Tags: []string{"用户管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/users.bulk/disable": {Name: "BulkDisableUser", Summary: "批量禁用数据", Tags: []string{"用户管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/users.bulk/delete":  {Name: "BulkDeleteUser", Summary: "批量删除数据", Tags: []string{"用户管理"}, Body: schema.BulkIDParam{}, Response: schema.BulkResult{}},
+		"POST /api/v1/users.bulk/roles":   {Name: "BulkAssignUserRoles", Summary: "批量分配角色", Tags: []string{"用户管理"}, Body: schema.BulkUserRoleParam{}, Response: schema.BulkResult{}},
+
+		// Webhook订阅
+		"GET /api/v1/webhooks":                                       {Name: "QueryWebhook", Summary: "查询数据", Tags: []string{"Webhook订阅"}, Query: schema.WebhookQueryParam{}, Response: openapi.Page(schema.Webhook{})},
+		"GET /api/v1/webhooks/:id":                                   {Name: "GetWebhook", Summary: "查询指定数据", Tags: []string{"Webhook订阅"}, Response: schema.Webhook{}},
+		"POST /api/v1/webhooks":                                      {Name: "CreateWebhook", Summary: "创建数据", Tags: []string{"Webhook订阅"}, Body: schema.Webhook{}, Response: schema.WebhookSecretResult{}},
+		"PUT /api/v1/webhooks/:id":                                   {Name: "UpdateWebhook", Summary: "更新数据", Tags: []string{"Webhook订阅"}, Body: schema.Webhook{}, Response: openapi.OK},
+		"DELETE /api/v1/webhooks/:id":                                {Name: "DeleteWebhook", Summary: "删除数据", Tags: []string{"Webhook订阅"}, Response: openapi.OK},
+		"PATCH /api/v1/webhooks/:id/enable":                          {Name: "EnableWebhook", Summary: "启用数据", Tags: []string{"Webhook订阅"}, Response: openapi.OK},
+		"PATCH /api/v1/webhooks/:id/disable":                         {Name: "DisableWebhook", Summary: "禁用数据", Tags: []string{"Webhook订阅"}, Response: openapi.OK},
+		"POST /api/v1/webhooks/:id/secret":                           {Name: "RotateWebhookSecret", Summary: "重新生成签名密钥", Tags: []string{"Webhook订阅"}, NoBody: true, Response: schema.WebhookSecretResult{}},
+		"POST /api/v1/webhooks/:id/ping":                             {Name: "PingWebhook", Summary: "发送测试事件", Tags: []string{"Webhook订阅"}, NoBody: true, Response: schema.WebhookDelivery{}},
+		"GET /api/v1/webhooks/:id/deliveries":                        {Name: "QueryWebhookDelivery", Summary: "查询投递记录", Tags: []string{"Webhook订阅"}, Query: schema.WebhookDeliveryQueryParam{}, Response: openapi.Page(schema.WebhookDelivery{})},
+		"POST /api/v1/webhooks/:id/deliveries/:deliveryID/redeliver": {Name: "RedeliverWebhookDelivery", Summary: "重新投递", Tags: []string{"Webhook订阅"}, NoBody: true, Response: schema.WebhookDelivery{}},
+		"GET /api/v1/webhooks.deadletters":                           {Name: "QueryWebhookDeadLetter", Summary: "查询死信投递记录", Tags: []string{"Webhook订阅"}, Query: schema.WebhookDeliveryQueryParam{}, Response: openapi.Page(schema.WebhookDelivery{})},
+
+		// 系统配置
+		"POST /api/v1/config.reload": {Name: "ReloadConfig", Summary: "重新加载配置文件", Tags: []string{"系统配置"}, NoBody: true, Response: config.ReloadResult{}},
+	}
+}
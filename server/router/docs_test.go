@@ -0,0 +1,64 @@
+package router_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/key7men/mag/server/module/openapi"
+	"github.com/key7men/mag/server/router"
+)
+
+func registeredRoutes(t *testing.T) (gin.RoutesInfo, *router.Router) {
+	t.Helper()
+	gin.SetMode(gin.ReleaseMode)
+	app := gin.New()
+	r := new(router.Router)
+	if err := r.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	return app.Routes(), r
+}
+
+// 每个已注册的路由都有接口文档，每个接口文档都对应已注册的路由
+func TestDocsMatchRoutes(t *testing.T) {
+	routes, r := registeredRoutes(t)
+	docs := r.Docs()
+
+	registered := make(map[string]bool)
+	var missing []string
+	for _, ri := range routes {
+		key := ri.Method + " " + ri.Path
+		registered[key] = true
+		if _, ok := docs[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	var stale []string
+	for key := range docs {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(stale)
+	for _, key := range missing {
+		t.Errorf("%s: registered route without doc", key)
+	}
+	for _, key := range stale {
+		t.Errorf("%s: doc without registered route", key)
+	}
+}
+
+func TestDocsCollect(t *testing.T) {
+	routes, r := registeredRoutes(t)
+	endpoints, err := openapi.Collect(routes, r.Docs(), r.Prefixes()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != len(routes) {
+		t.Errorf("got %d endpoints, want %d", len(endpoints), len(routes))
+	}
+}
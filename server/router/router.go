@@ -9,8 +9,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 	"github.com/key7men/mag/pkg/auth"
+	"github.com/key7men/mag/server/biz"
 	"github.com/key7men/mag/server/handler"
 	"github.com/key7men/mag/server/middleware"
+	"github.com/key7men/mag/server/module/openapi"
 )
 
 var _ IRouter = (*Router)(nil)
@@ -22,21 +24,31 @@ var RouterSet = wire.NewSet(wire.Struct(new(Router), "*"), wire.Bind(new(IRouter
 type IRouter interface {
 	Register(app *gin.Engine) error
 	Prefixes() []string
+	Docs() openapi.Routes
 }
 
 // Router 路由管理器
 type Router struct {
 	Auth           	auth.Auther
 	CasbinEnforcer 	*casbin.SyncedEnforcer
+	TenantBiz		biz.ITenant
+	AuditAPI        *handler.Audit
+	ConfigAPI		*handler.Config
 	DemoAPI        	*handler.Demo
+	HealthAPI		*handler.Health
+	LogAPI			*handler.Log
 	LoginAPI 	   	*handler.Login
 	MenuAPI 		*handler.Menu
+	RBACAPI			*handler.RBAC
 	RoleAPI 		*handler.Role
+	TenantAPI		*handler.Tenant
 	UserAPI			*handler.User
+	WebhookAPI		*handler.Webhook
 }
 
 // Register 注册路由
 func (r *Router) Register(app *gin.Engine) error {
+	r.RegisterHealth(app)
 	r.RegisterAPI(app)
 	return nil
 }
@@ -48,6 +60,12 @@ func (r *Router) Prefixes() []string {
 	}
 }
 
+// RegisterHealth 注册存活及就绪检查(无需认证，供负载均衡使用)
+func (r *Router) RegisterHealth(app *gin.Engine) {
+	app.GET("/healthz", r.HealthAPI.Live)
+	app.GET("/readyz", r.HealthAPI.Ready)
+}
+
 // RegisterAPI register api group router
 func (r *Router) RegisterAPI(app *gin.Engine) {
 	g := app.Group("/api")
@@ -56,6 +74,26 @@ func (r *Router) RegisterAPI(app *gin.Engine) {
 		middleware.AllowPathPrefixSkipper("/api/v1/pub/login"),
 	))
 
+	g.Use(middleware.TenantMiddleware(r.TenantBiz))
+
+	// 平台级接口(租户、全局菜单及系统配置)不允许租户内访问
+	g.Use(middleware.PlatformMiddleware(
+		middleware.AllowMethodAndPathPrefixNoSkipper(
+			"POST/api/v1/menus",
+			"PUT/api/v1/menus",
+			"DELETE/api/v1/menus",
+			"PATCH/api/v1/menus",
+			"POST/api/v1/rbac.import",
+			"POST/api/v1/config.reload",
+			"GET/api/v1/logs",
+			"GET/api/v1/tenants",
+			"POST/api/v1/tenants",
+			"PUT/api/v1/tenants",
+			"DELETE/api/v1/tenants",
+			"PATCH/api/v1/tenants",
+		),
+	))
+
 	g.Use(middleware.CasbinMiddleware(r.CasbinEnforcer,
 		middleware.AllowPathPrefixSkipper("/api/v1/pub"),
 	))
@@ -83,6 +121,12 @@ func (r *Router) RegisterAPI(app *gin.Engine) {
 			pub.POST("/refresh-token", r.LoginAPI.RefreshToken)
 		}
 
+		gAudit := v1.Group("audits")
+		{
+			gAudit.GET("", r.AuditAPI.Query)
+			gAudit.GET(":id", r.AuditAPI.Get)
+		}
+
 		gDemo := v1.Group("demos")
 		{
 			gDemo.GET("", r.DemoAPI.Query)
@@ -93,6 +137,13 @@ func (r *Router) RegisterAPI(app *gin.Engine) {
 			gDemo.PATCH(":id/enable", r.DemoAPI.Enable)
 			gDemo.PATCH(":id/disable", r.DemoAPI.Disable)
 		}
+		v1.GET("/demos.export", r.DemoAPI.Export)
+
+		gLog := v1.Group("logs")
+		{
+			gLog.GET("", r.LogAPI.Query)
+			gLog.GET("traces/:traceID", r.LogAPI.QueryTrace)
+		}
 
 		gMenu := v1.Group("menus")
 		{
@@ -106,6 +157,17 @@ func (r *Router) RegisterAPI(app *gin.Engine) {
 		}
 		v1.GET("/menus.tree", r.MenuAPI.QueryTree)
 
+		gMenuBulk := v1.Group("menus.bulk")
+		{
+			gMenuBulk.POST("create", r.MenuAPI.BulkCreate)
+			gMenuBulk.POST("enable", r.MenuAPI.BulkEnable)
+			gMenuBulk.POST("disable", r.MenuAPI.BulkDisable)
+			gMenuBulk.POST("delete", r.MenuAPI.BulkDelete)
+		}
+
+		v1.GET("/rbac.export", r.RBACAPI.Export)
+		v1.POST("/rbac.import", r.RBACAPI.Import)
+
 		gRole := v1.Group("roles")
 		{
 			gRole.GET("", r.RoleAPI.Query)
@@ -117,6 +179,26 @@ func (r *Router) RegisterAPI(app *gin.Engine) {
 			gRole.PATCH(":id/disable", r.RoleAPI.Disable)
 		}
 		v1.GET("/roles.select", r.RoleAPI.QuerySelect)
+		v1.GET("/roles.export", r.RoleAPI.Export)
+
+		gRoleBulk := v1.Group("roles.bulk")
+		{
+			gRoleBulk.POST("create", r.RoleAPI.BulkCreate)
+			gRoleBulk.POST("enable", r.RoleAPI.BulkEnable)
+			gRoleBulk.POST("disable", r.RoleAPI.BulkDisable)
+			gRoleBulk.POST("delete", r.RoleAPI.BulkDelete)
+		}
+
+		gTenant := v1.Group("tenants")
+		{
+			gTenant.GET("", r.TenantAPI.Query)
+			gTenant.GET(":id", r.TenantAPI.Get)
+			gTenant.POST("", r.TenantAPI.Create)
+			gTenant.PUT(":id", r.TenantAPI.Update)
+			gTenant.DELETE(":id", r.TenantAPI.Delete)
+			gTenant.PATCH(":id/enable", r.TenantAPI.Enable)
+			gTenant.PATCH(":id/disable", r.TenantAPI.Disable)
+		}
 
 		gUser := v1.Group("users")
 		{
@@ -128,5 +210,34 @@ func (r *Router) RegisterAPI(app *gin.Engine) {
 			gUser.PATCH(":id/enable", r.UserAPI.Enable)
 			gUser.PATCH(":id/disable", r.UserAPI.Disable)
 		}
+		v1.GET("/users.export", r.UserAPI.Export)
+		v1.POST("/users.import", r.UserAPI.Import)
+
+		gUserBulk := v1.Group("users.bulk")
+		{
+			gUserBulk.POST("create", r.UserAPI.BulkCreate)
+			gUserBulk.POST("enable", r.UserAPI.BulkEnable)
+			gUserBulk.POST("disable", r.UserAPI.BulkDisable)
+			gUserBulk.POST("delete", r.UserAPI.BulkDelete)
+			gUserBulk.POST("roles", r.UserAPI.BulkAssignRoles)
+		}
+
+		gWebhook := v1.Group("webhooks")
+		{
+			gWebhook.GET("", r.WebhookAPI.Query)
+			gWebhook.GET(":id", r.WebhookAPI.Get)
+			gWebhook.POST("", r.WebhookAPI.Create)
+			gWebhook.PUT(":id", r.WebhookAPI.Update)
+			gWebhook.DELETE(":id", r.WebhookAPI.Delete)
+			gWebhook.PATCH(":id/enable", r.WebhookAPI.Enable)
+			gWebhook.PATCH(":id/disable", r.WebhookAPI.Disable)
+			gWebhook.POST(":id/secret", r.WebhookAPI.RotateSecret)
+			gWebhook.POST(":id/ping", r.WebhookAPI.Ping)
+			gWebhook.GET(":id/deliveries", r.WebhookAPI.QueryDeliveries)
+			gWebhook.POST(":id/deliveries/:deliveryID/redeliver", r.WebhookAPI.Redeliver)
+		}
+		v1.GET("/webhooks.deadletters", r.WebhookAPI.QueryDeadLetters)
+
+		v1.POST("/config.reload", r.ConfigAPI.Reload)
 	}
 }
@@ -0,0 +1,217 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// AuditAction 审计动作
+type AuditAction string
+
+// 定义审计动作常量
+const (
+	AuditCreate       AuditAction = "create"        // 创建
+	AuditUpdate       AuditAction = "update"        // 更新
+	AuditDelete       AuditAction = "delete"        // 删除
+	AuditUpdateStatus AuditAction = "update_status" // 更新状态
+)
+
+// 定义审计实体类型常量
+const (
+	AuditEntityDemo   = "demo"
+	AuditEntityMenu   = "menu"
+	AuditEntityRole   = "role"
+	AuditEntityUser   = "user"
+	AuditEntityTenant = "tenant"
+)
+
+// Audit 审计日志对象
+type Audit struct {
+	ID         string       `json:"id"`          // 唯一标识
+	EntityType string       `json:"entity_type"` // 实体类型
+	EntityID   string       `json:"entity_id"`   // 实体ID
+	Action     AuditAction  `json:"action"`      // 审计动作
+	ActorID    string       `json:"actor_id"`    // 操作人
+	TraceID    string       `json:"trace_id"`    // 追踪ID
+	Changes    AuditChanges `json:"changes"`     // 变更字段列表
+	CreatedAt  time.Time    `json:"created_at"`  // 创建时间
+}
+
+// AuditQueryParam 查询条件
+type AuditQueryParam struct {
+	PaginationParam
+	EntityType string         `form:"entityType"` // 实体类型
+	EntityID   string         `form:"entityID"`   // 实体ID
+	ActorID    string         `form:"actorID"`    // 操作人
+	Action     string         `form:"action"`     // 审计动作
+	StartTime  time.Time      `form:"startTime"`  // 开始时间(RFC3339格式)
+	EndTime    time.Time      `form:"endTime"`    // 结束时间(RFC3339格式)
+	Filters    []*FilterField `form:"-"`          // 通用过滤条件
+}
+
+// AuditQueryWhitelist 审计日志通用查询白名单
+var AuditQueryWhitelist = QueryWhitelist{
+	Filters: map[string]string{
+		"entity_type": "entity_type",
+		"entity_id":   "entity_id",
+		"actor_id":    "actor_id",
+		"action":      "action",
+		"trace_id":    "trace_id",
+		"created_at":  "created_at",
+	},
+	Sorts: map[string]string{
+		"entity_type": "entity_type",
+		"actor_id":    "actor_id",
+		"created_at":  "created_at",
+	},
+	Fields: []string{"id", "entity_type", "entity_id", "action", "actor_id", "trace_id", "changes", "created_at"},
+}
+
+// AuditQueryOptions 查询可选参数项
+type AuditQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// AuditQueryResult 查询结果
+type AuditQueryResult struct {
+	Data       Audits
+	PageResult *PaginationResult
+}
+
+// Audits 审计日志列表
+type Audits []*Audit
+
+// AuditChange 字段变更项
+type AuditChange struct {
+	Field  string          `json:"field"`            // 字段名(JSON键)
+	Before json.RawMessage `json:"before,omitempty"` // 变更前的值
+	After  json.RawMessage `json:"after,omitempty"`  // 变更后的值
+}
+
+// AuditChanges 字段变更列表
+type AuditChanges []*AuditChange
+
+// 不参与比对的字段(由存储自动维护)
+var auditIgnoreFields = map[string]struct{}{
+	"created_at": {},
+	"updated_at": {},
+}
+
+// 仅记录变更而不记录值的敏感字段(含嵌套对象中的同名字段)
+var auditSecureFields = map[string]struct{}{
+	"password": {},
+	"secret":   {},
+}
+
+var auditSecureValue = json.RawMessage(`"******"`)
+
+// NewAuditChanges 比对变更前后的对象(以JSON字段为单位)，before或after为nil时分别表示创建和删除
+func NewAuditChanges(before, after interface{}) (AuditChanges, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(beforeFields)+len(afterFields))
+	for key := range beforeFields {
+		keys = append(keys, key)
+	}
+	for key := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes AuditChanges
+	for _, key := range keys {
+		if _, ok := auditIgnoreFields[key]; ok {
+			continue
+		}
+
+		bv, bok := beforeFields[key]
+		av, aok := afterFields[key]
+		if bok && aok && bytes.Equal(bv, av) {
+			continue
+		}
+
+		if _, ok := auditSecureFields[key]; ok {
+			if bok {
+				bv = auditSecureValue
+			}
+			if aok {
+				av = auditSecureValue
+			}
+		} else {
+			bv, av = maskAuditValue(bv), maskAuditValue(av)
+		}
+		changes = append(changes, &AuditChange{Field: key, Before: bv, After: av})
+	}
+	return changes, nil
+}
+
+// 屏蔽嵌套对象及数组中敏感字段的值
+func maskAuditValue(v json.RawMessage) json.RawMessage {
+	if len(v) == 0 || (v[0] != '{' && v[0] != '[') {
+		return v
+	}
+
+	d := json.NewDecoder(bytes.NewReader(v))
+	d.UseNumber()
+	var data interface{}
+	if err := d.Decode(&data); err != nil || !maskAuditData(data) {
+		return v
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return v
+	}
+	return buf
+}
+
+// 屏蔽敏感字段的值，返回是否有字段被屏蔽
+func maskAuditData(data interface{}) bool {
+	var masked bool
+	switch data := data.(type) {
+	case map[string]interface{}:
+		for key, value := range data {
+			if _, ok := auditSecureFields[key]; ok {
+				data[key] = auditSecureValue
+				masked = true
+			} else if maskAuditData(value) {
+				masked = true
+			}
+		}
+	case []interface{}:
+		for _, value := range data {
+			if maskAuditData(value) {
+				masked = true
+			}
+		}
+	}
+	return masked
+}
+
+func auditFields(v interface{}) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type auditProfile struct {
+	Phone    string `json:"phone"`
+	Password string `json:"password,omitempty"`
+}
+
+type auditHook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+type auditUser struct {
+	UserName  string        `json:"user_name"`
+	Password  string        `json:"password"`
+	Status    int           `json:"status"`
+	Profile   *auditProfile `json:"profile,omitempty"`
+	Hooks     []auditHook   `json:"hooks,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// 以field:before>after的形式输出变更，便于比对
+func formatAuditChanges(changes AuditChanges) []string {
+	list := make([]string, len(changes))
+	for i, c := range changes {
+		list[i] = c.Field + ":" + string(c.Before) + ">" + string(c.After)
+	}
+	return list
+}
+
+func TestNewAuditChanges(t *testing.T) {
+	user := auditUser{
+		UserName:  "alice",
+		Password:  "hash1",
+		Status:    1,
+		Profile:   &auditProfile{Phone: "138", Password: "nested"},
+		Hooks:     []auditHook{{URL: "http://a", Secret: "s1"}},
+		CreatedAt: time.Unix(1, 0),
+		UpdatedAt: time.Unix(1, 0),
+	}
+	with := func(fn func(u *auditUser)) auditUser {
+		u := user
+		p := *user.Profile
+		u.Profile = &p
+		u.Hooks = append([]auditHook(nil), user.Hooks...)
+		fn(&u)
+		return u
+	}
+
+	for _, c := range []struct {
+		name          string
+		before, after interface{}
+		want          []string
+	}{
+		{"unchanged", user, with(func(u *auditUser) {}), nil},
+		{"timestamps ignored", user, with(func(u *auditUser) { u.UpdatedAt = time.Unix(2, 0) }), nil},
+		{"scalar", user, with(func(u *auditUser) { u.Status = 2 }), []string{"status:1>2"}},
+		{"password redacted", user, with(func(u *auditUser) { u.Password = "hash2" }), []string{`password:"******">"******"`}},
+		{"nested", user, with(func(u *auditUser) { u.Profile.Phone = "139" }), []string{
+			`profile:{"password":"******","phone":"138"}>{"password":"******","phone":"139"}`,
+		}},
+		{"nested password redacted", user, with(func(u *auditUser) { u.Profile.Password = "changed" }), []string{
+			`profile:{"password":"******","phone":"138"}>{"password":"******","phone":"138"}`,
+		}},
+		{"nested removed", user, with(func(u *auditUser) { u.Profile = nil }), []string{
+			`profile:{"password":"******","phone":"138"}>`,
+		}},
+		{"array secret redacted", user, with(func(u *auditUser) { u.Hooks[0].URL = "http://b" }), []string{
+			`hooks:[{"secret":"******","url":"http://a"}]>[{"secret":"******","url":"http://b"}]`,
+		}},
+		{"create", nil, auditProfile{Phone: "138", Password: "x"}, []string{`password:>"******"`, `phone:>"138"`}},
+		{"delete", auditProfile{Phone: "138", Password: "x"}, nil, []string{`password:"******">`, `phone:"138">`}},
+	} {
+		changes, err := NewAuditChanges(c.before, c.after)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		got := formatAuditChanges(changes)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMaskAuditValue(t *testing.T) {
+	for _, c := range []struct {
+		value, want string
+	}{
+		{`"password"`, `"password"`},
+		{`12`, `12`},
+		{`{"name":"a"}`, `{"name":"a"}`},
+		{`{"big":12345678901234567890,"password":"x"}`, `{"big":12345678901234567890,"password":"******"}`},
+		{`[{"a":{"secret":"x"}},1]`, `[{"a":{"secret":"******"}},1]`},
+	} {
+		if got := string(maskAuditValue(json.RawMessage(c.value))); got != c.want {
+			t.Errorf("maskAuditValue(%s): got %s, want %s", c.value, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package schema
+
+// BulkIDParam 批量操作的ID列表
+type BulkIDParam struct {
+	IDs []string `json:"ids" binding:"required,min=1,dive,required"` // 唯一标识列表
+}
+
+// BulkUserCreateParam 批量创建用户参数
+type BulkUserCreateParam struct {
+	Items []*User `json:"items" binding:"required,min=1,dive"` // 用户列表
+}
+
+// BulkUserRoleParam 批量分配用户角色参数
+type BulkUserRoleParam struct {
+	IDs     []string `json:"ids" binding:"required,min=1,dive,required"`      // 用户ID列表
+	RoleIDs []string `json:"role_ids" binding:"required,min=1,dive,required"` // 角色ID列表
+	Replace bool     `json:"replace"`                                         // 是否替换用户已有的角色(默认追加)
+}
+
+// BulkRoleCreateParam 批量创建角色参数
+type BulkRoleCreateParam struct {
+	Items []*Role `json:"items" binding:"required,min=1,dive"` // 角色列表
+}
+
+// BulkMenuCreateParam 批量创建菜单参数
+type BulkMenuCreateParam struct {
+	Items []*Menu `json:"items" binding:"required,min=1,dive"` // 菜单列表(按顺序创建)
+}
+
+// BulkResult 批量操作结果(业务校验失败的项不影响其他项，存储错误时全部回滚)
+type BulkResult struct {
+	Total     int               `json:"total"`     // 总数
+	Succeeded int               `json:"succeeded"` // 成功数
+	Failed    int               `json:"failed"`    // 失败数
+	Items     []*BulkItemResult `json:"items"`     // 各项的处理结果(与请求顺序一致)
+}
+
+// BulkItemResult 批量操作中单项的处理结果
+type BulkItemResult struct {
+	Index  int        `json:"index"`           // 请求中的序号(从0开始)
+	ID     string     `json:"id,omitempty"`    // 唯一标识
+	Status StatusText `json:"status"`          // 状态(OK/FAIL)
+	Error  *ErrorItem `json:"error,omitempty"` // 错误项(失败时有效)
+	Err    error      `json:"-"`               // 失败原因(响应时转换为错误项)
+}
@@ -17,8 +17,28 @@ type Demo struct {
 // DemoQueryParam 查询条件
 type DemoQueryParam struct {
 	PaginationParam
-	Code       string `form:"-"`          // 编号
-	QueryValue string `form:"queryValue"` // 查询值
+	Code       string         `form:"-"`          // 编号
+	QueryValue string         `form:"queryValue"` // 查询值
+	Filters    []*FilterField `form:"-"`          // 通用过滤条件
+}
+
+// DemoQueryWhitelist 示例对象通用查询白名单
+var DemoQueryWhitelist = QueryWhitelist{
+	Filters: map[string]string{
+		"code":       "code",
+		"name":       "name",
+		"status":     "status",
+		"creator":    "creator",
+		"created_at": "created_at",
+	},
+	Sorts: map[string]string{
+		"code":       "code",
+		"name":       "name",
+		"status":     "status",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Fields: []string{"id", "code", "name", "memo", "status", "creator", "created_at", "updated_at"},
 }
 
 // DemoQueryOptions 示例对象查询可选参数项
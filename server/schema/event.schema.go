@@ -0,0 +1,101 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventStatus 事件投递状态
+type EventStatus int
+
+// 定义事件投递状态常量
+const (
+	EventPending    EventStatus = 1 // 待投递
+	EventDispatched EventStatus = 2 // 已投递
+	EventDead       EventStatus = 3 // 超过最大重试次数
+)
+
+// 定义领域事件类型常量
+const (
+	EventUserCreated       = "user.created"
+	EventUserUpdated       = "user.updated"
+	EventUserRolesChanged  = "user.roles_changed"
+	EventUserDeleted       = "user.deleted"
+	EventUserStatusChanged = "user.status_changed"
+	EventRoleCreated       = "role.created"
+	EventRoleUpdated       = "role.updated"
+	EventRoleMenusChanged  = "role.menus_changed"
+	EventRoleDeleted       = "role.deleted"
+	EventRoleStatusChanged = "role.status_changed"
+	EventMenuCreated       = "menu.created"
+	EventMenuUpdated       = "menu.updated"
+	EventMenuDeleted       = "menu.deleted"
+	EventMenuStatusChanged = "menu.status_changed"
+	EventDemoCreated       = "demo.created"
+	EventDemoUpdated       = "demo.updated"
+	EventDemoDeleted       = "demo.deleted"
+	EventDemoStatusChanged = "demo.status_changed"
+)
+
+// 定义批量操作的领域事件类型常量(每次批量操作发布一个事件，数据为成功项的快照列表)
+const (
+	EventUserBulkCreated       = "user.bulk_created"
+	EventUserBulkDeleted       = "user.bulk_deleted"
+	EventUserBulkStatusChanged = "user.bulk_status_changed"
+	EventUserBulkRolesChanged  = "user.bulk_roles_changed"
+	EventRoleBulkCreated       = "role.bulk_created"
+	EventRoleBulkDeleted       = "role.bulk_deleted"
+	EventRoleBulkStatusChanged = "role.bulk_status_changed"
+	EventMenuBulkCreated       = "menu.bulk_created"
+	EventMenuBulkDeleted       = "menu.bulk_deleted"
+	EventMenuBulkStatusChanged = "menu.bulk_status_changed"
+)
+
+// EventRBACImported 导入RBAC模型配置的领域事件(每次导入发布一个事件，数据为导入结果)
+const EventRBACImported = "rbac.imported"
+
+// 定义租户的领域事件类型常量
+const (
+	EventTenantCreated       = "tenant.created"
+	EventTenantUpdated       = "tenant.updated"
+	EventTenantDeleted       = "tenant.deleted"
+	EventTenantStatusChanged = "tenant.status_changed"
+)
+
+// Event 领域事件(经由发件箱表投递)
+type Event struct {
+	ID          string          `json:"id"`            // 唯一标识
+	Type        string          `json:"type"`          // 事件类型
+	AggregateID string          `json:"aggregate_id"`  // 聚合(实体)ID
+	Payload     json.RawMessage `json:"payload"`       // 事件数据(实体快照)
+	ActorID     string          `json:"actor_id"`      // 操作人
+	TraceID     string          `json:"trace_id"`      // 追踪ID
+	TenantID    string          `json:"tenant_id"`     // 所属租户
+	Status      EventStatus     `json:"status"`        // 投递状态
+	Attempts    int             `json:"attempts"`      // 投递次数
+	LastError   string          `json:"last_error"`    // 最后一次投递错误
+	SentSinks   []string        `json:"sent_sinks"`    // 已投递成功的目标(重试时不再投递)
+	NextRetryAt time.Time       `json:"next_retry_at"` // 下次投递时间
+	CreatedAt   time.Time       `json:"created_at"`    // 创建时间
+}
+
+// EventQueryParam 查询条件
+type EventQueryParam struct {
+	PaginationParam
+	Status      EventStatus // 投递状态
+	RetryBefore time.Time   // 下次投递时间不晚于
+}
+
+// EventQueryOptions 查询可选参数项
+type EventQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// EventQueryResult 查询结果
+type EventQueryResult struct {
+	Data       Events
+	PageResult *PaginationResult
+}
+
+// Events 领域事件列表
+type Events []*Event
@@ -0,0 +1,15 @@
+package schema
+
+// HealthResult 健康检查结果
+type HealthResult struct {
+	Status StatusText     `json:"status"`           // 状态(OK/FAIL)
+	Checks []*HealthCheck `json:"checks,omitempty"` // 各依赖的检查结果
+}
+
+// HealthCheck 依赖检查结果
+type HealthCheck struct {
+	Name     string     `json:"name"`            // 依赖名称
+	Status   StatusText `json:"status"`          // 状态(OK/FAIL)
+	Error    string     `json:"error,omitempty"` // 错误信息
+	Duration int64      `json:"duration_ms"`     // 检查耗时(毫秒)
+}
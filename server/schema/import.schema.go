@@ -0,0 +1,64 @@
+package schema
+
+// ImportAction 导入行的处理方式
+type ImportAction string
+
+// 定义导入行的处理方式常量
+const (
+	ImportCreate ImportAction = "create"
+	ImportUpdate ImportAction = "update"
+)
+
+// ImportParam 导入参数
+type ImportParam struct {
+	DryRun bool `form:"dryRun"` // 是否仅校验(不导入数据)
+}
+
+// UserImportRequiredColumns 用户导入的必需列(可选列：password、phone、email、status、roles，其他列忽略)
+var UserImportRequiredColumns = []string{"user_name", "real_name"}
+
+// UserImportRow 用户导入行(单元格原始值，由业务逻辑校验)
+type UserImportRow struct {
+	Row      int    // 行号(含表头，从1开始)
+	UserName string // 用户名
+	RealName string // 真实姓名
+	Password string // 密码(新建用户时必填，更新时为空则不修改)
+	Phone    string // 手机号
+	Email    string // 邮箱
+	Status   string // 用户状态(1:启用 2:停用，新建用户时为空则启用)
+	Roles    string // 角色名称列表(以逗号或分号分隔，新建用户时必填)
+}
+
+// ImportResult 导入结果(存在失败行时不导入任何数据)
+type ImportResult struct {
+	DryRun  bool               `json:"dry_run"` // 是否仅校验
+	Applied bool               `json:"applied"` // 是否已导入
+	Total   int                `json:"total"`   // 总行数
+	Created int                `json:"created"` // 新建数
+	Updated int                `json:"updated"` // 更新数
+	Failed  int                `json:"failed"`  // 失败数
+	Rows    []*ImportRowResult `json:"rows"`    // 各行的处理结果
+}
+
+// ImportRowResult 导入行的处理结果
+type ImportRowResult struct {
+	Row       int               `json:"row"`              // 行号(含表头，从1开始)
+	ID        string            `json:"id,omitempty"`     // 唯一标识(更新或导入后有效)
+	Key       string            `json:"key"`              // 业务标识(如用户名)
+	Action    ImportAction      `json:"action,omitempty"` // 处理方式(create/update)
+	Status    StatusText        `json:"status"`           // 状态(OK/FAIL)
+	Errors    []*FieldError     `json:"errors,omitempty"` // 错误列表(失败时有效)
+	FieldErrs []*ImportFieldErr `json:"-"`                // 失败原因(响应时转换为错误列表)
+}
+
+// ImportFieldErr 导入行中指定列的错误
+type ImportFieldErr struct {
+	Field string // 列名(整行的错误为空)
+	Err   error  // 错误
+}
+
+// AddError 添加错误
+func (a *ImportRowResult) AddError(field string, err error) {
+	a.Status = FailStatus
+	a.FieldErrs = append(a.FieldErrs, &ImportFieldErr{Field: field, Err: err})
+}
@@ -0,0 +1,61 @@
+package schema
+
+import "time"
+
+// Log 系统日志对象(由日志钩子写入)
+type Log struct {
+	ID           string    `json:"id"`            // 唯一标识
+	Level        string    `json:"level"`         // 日志级别
+	Message      string    `json:"message"`       // 消息
+	TraceID      string    `json:"trace_id"`      // 跟踪ID
+	UserID       string    `json:"user_id"`       // 用户ID
+	SpanTitle    string    `json:"span_title"`    // 跟踪单元标题
+	SpanFunction string    `json:"span_function"` // 跟踪单元函数名
+	Data         string    `json:"data"`          // 日志数据(json)
+	Version      string    `json:"version"`       // 服务版本号
+	CreatedAt    time.Time `json:"created_at"`    // 创建时间
+}
+
+// LogQueryParam 查询条件
+type LogQueryParam struct {
+	PaginationParam
+	IDs        []string       `form:"-"`          // 唯一标识列表
+	Levels     []string       `form:"level"`      // 日志级别(可指定多个)
+	TraceID    string         `form:"traceID"`    // 跟踪ID
+	UserID     string         `form:"userID"`     // 用户ID
+	QueryValue string         `form:"queryValue"` // 模糊查询(消息及日志数据)
+	StartTime  time.Time      `form:"startTime"`  // 开始时间(RFC3339格式)
+	EndTime    time.Time      `form:"endTime"`    // 结束时间(RFC3339格式)
+	Before     time.Time      `form:"-"`          // 创建时间早于(不含)
+	Filters    []*FilterField `form:"-"`          // 通用过滤条件
+}
+
+// LogQueryWhitelist 系统日志通用查询白名单
+var LogQueryWhitelist = QueryWhitelist{
+	Filters: map[string]string{
+		"level":      "level",
+		"trace_id":   "trace_id",
+		"user_id":    "user_id",
+		"version":    "version",
+		"created_at": "created_at",
+	},
+	Sorts: map[string]string{
+		"level":      "level",
+		"created_at": "created_at",
+	},
+	Fields: []string{"id", "level", "message", "trace_id", "user_id", "span_title", "span_function", "data", "version", "created_at"},
+}
+
+// LogQueryOptions 查询可选参数项
+type LogQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// LogQueryResult 查询结果
+type LogQueryResult struct {
+	Data       Logs
+	PageResult *PaginationResult
+}
+
+// Logs 系统日志列表
+type Logs []*Log
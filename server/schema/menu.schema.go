@@ -32,13 +32,34 @@ func (a *Menu) String() string {
 // MenuQueryParam 查询条件
 type MenuQueryParam struct {
 	PaginationParam
-	IDs              []string `form:"-"`          // 唯一标识列表
-	Name             string   `form:"-"`          // 菜单名称
-	PrefixParentPath string   `form:"-"`          // 父级路径(前缀模糊查询)
-	QueryValue       string   `form:"queryValue"` // 模糊查询
-	ParentID         *string  `form:"parentID"`   // 父级内码
-	ShowStatus       int      `form:"showStatus"` // 显示状态(1:显示 2:隐藏)
-	Status           int      `form:"status"`     // 状态(1:启用 2:禁用)
+	IDs              []string       `form:"-"`          // 唯一标识列表
+	Name             string         `form:"-"`          // 菜单名称
+	PrefixParentPath string         `form:"-"`          // 父级路径(前缀模糊查询)
+	QueryValue       string         `form:"queryValue"` // 模糊查询
+	ParentID         *string        `form:"parentID"`   // 父级内码
+	ShowStatus       int            `form:"showStatus"` // 显示状态(1:显示 2:隐藏)
+	Status           int            `form:"status"`     // 状态(1:启用 2:禁用)
+	Filters          []*FilterField `form:"-"`          // 通用过滤条件
+}
+
+// MenuQueryWhitelist 菜单通用查询白名单
+var MenuQueryWhitelist = QueryWhitelist{
+	Filters: map[string]string{
+		"name":        "name",
+		"router":      "router",
+		"parent_id":   "parent_id",
+		"show_status": "show_status",
+		"status":      "status",
+		"created_at":  "created_at",
+	},
+	Sorts: map[string]string{
+		"name":       "name",
+		"sequence":   "sequence",
+		"status":     "status",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Fields: []string{"id", "name", "sequence", "icon", "router", "parent_id", "parent_path", "show_status", "status", "memo", "creator", "created_at", "updated_at", "actions"},
 }
 
 // MenuQueryOptions 查询可选参数项
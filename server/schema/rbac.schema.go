@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/key7men/mag/pkg/util"
+)
+
+// RBAC配置文档的格式
+const (
+	RBACFormatYAML = "yaml"
+	RBACFormatJSON = "json"
+)
+
+// ErrUnsupportedRBACFormat 不支持的RBAC配置文档格式
+var ErrUnsupportedRBACFormat = errors.New("unsupported rbac document format")
+
+// RBACMenuPathSep 菜单路径(各级菜单名称)的分隔符
+const RBACMenuPathSep = "/"
+
+// RBACDocument RBAC模型配置文档(菜单按名称路径、动作按编号、角色按名称关联，不包含生成的ID，用于在环境之间迁移)
+type RBACDocument struct {
+	Menus RBACMenus `yaml:"menus" json:"menus"` // 菜单树
+	Roles RBACRoles `yaml:"roles" json:"roles"` // 角色列表
+}
+
+// RBACMenu 菜单配置(同级菜单名称唯一)
+type RBACMenu struct {
+	Name       string      `yaml:"name" json:"name"`                             // 菜单名称
+	Icon       string      `yaml:"icon,omitempty" json:"icon,omitempty"`         // 菜单图标
+	Router     string      `yaml:"router,omitempty" json:"router,omitempty"`     // 访问路由
+	Sequence   int         `yaml:"sequence" json:"sequence"`                     // 排序值
+	ShowStatus int         `yaml:"show_status" json:"show_status"`               // 显示状态(1:显示 2:隐藏)
+	Status     int         `yaml:"status" json:"status"`                         // 状态(1:启用 2:禁用)
+	Memo       string      `yaml:"memo,omitempty" json:"memo,omitempty"`         // 备注
+	Actions    RBACActions `yaml:"actions,omitempty" json:"actions,omitempty"`   // 动作列表
+	Children   RBACMenus   `yaml:"children,omitempty" json:"children,omitempty"` // 子级菜单
+}
+
+// RBACMenus 菜单配置列表
+type RBACMenus []*RBACMenu
+
+// RBACAction 菜单动作配置(同一菜单下编号唯一)
+type RBACAction struct {
+	Code      string        `yaml:"code" json:"code"`                               // 动作编号
+	Name      string        `yaml:"name" json:"name"`                               // 动作名称
+	Resources RBACResources `yaml:"resources,omitempty" json:"resources,omitempty"` // 资源列表
+}
+
+// RBACActions 菜单动作配置列表
+type RBACActions []*RBACAction
+
+// RBACResource 动作关联的资源配置(按请求方式及路径识别)
+type RBACResource struct {
+	Method string `yaml:"method" json:"method"` // 资源请求方式
+	Path   string `yaml:"path" json:"path"`     // 资源请求路径
+}
+
+// RBACResources 资源配置列表
+type RBACResources []*RBACResource
+
+// RBACRole 角色配置(名称唯一)
+type RBACRole struct {
+	Name     string     `yaml:"name" json:"name"`                         // 角色名称
+	Sequence int        `yaml:"sequence" json:"sequence"`                 // 排序值
+	Status   int        `yaml:"status" json:"status"`                     // 状态(1:启用 2:禁用)
+	Memo     string     `yaml:"memo,omitempty" json:"memo,omitempty"`     // 备注
+	Grants   RBACGrants `yaml:"grants,omitempty" json:"grants,omitempty"` // 菜单授权
+}
+
+// RBACRoles 角色配置列表
+type RBACRoles []*RBACRole
+
+// RBACGrant 角色对菜单的授权
+type RBACGrant struct {
+	Menu    string   `yaml:"menu" json:"menu"`       // 菜单路径(各级菜单名称以/分隔)
+	Actions []string `yaml:"actions" json:"actions"` // 动作编号列表
+}
+
+// RBACGrants 角色的菜单授权列表
+type RBACGrants []*RBACGrant
+
+// JoinRBACMenuPath 拼接菜单路径
+func JoinRBACMenuPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + RBACMenuPathSep + name
+}
+
+// ParseRBACFormat 解析配置文档格式(不区分大小写，可为文件扩展名或内容类型，为空时使用YAML)
+func ParseRBACFormat(s string) (string, error) {
+	s = strings.ToLower(strings.TrimPrefix(s, "."))
+	switch {
+	case s == "", s == "yml", strings.Contains(s, "yaml"):
+		return RBACFormatYAML, nil
+	case strings.Contains(s, "json"):
+		return RBACFormatJSON, nil
+	}
+	return "", ErrUnsupportedRBACFormat
+}
+
+// MarshalRBACDocument 按指定格式序列化配置文档
+func MarshalRBACDocument(doc *RBACDocument, format string) ([]byte, error) {
+	if format == RBACFormatJSON {
+		return util.JSONMarshalIndent(doc, "", "  ")
+	}
+	return util.YAMLMarshal(doc)
+}
+
+// UnmarshalRBACDocument 按指定格式解析配置文档(不允许未知字段)
+func UnmarshalRBACDocument(data []byte, format string) (*RBACDocument, error) {
+	doc := new(RBACDocument)
+	if format == RBACFormatJSON {
+		d := util.JSONNewDecoder(bytes.NewReader(data))
+		d.DisallowUnknownFields()
+		if err := d.Decode(doc); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+
+	d := util.YAMLNewDecoder(bytes.NewReader(data))
+	d.SetStrict(true)
+	if err := d.Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// RBACChangeKind 变更的对象类型
+type RBACChangeKind string
+
+// 定义变更的对象类型常量
+const (
+	RBACKindMenu     RBACChangeKind = "menu"
+	RBACKindAction   RBACChangeKind = "action"
+	RBACKindResource RBACChangeKind = "resource"
+	RBACKindRole     RBACChangeKind = "role"
+	RBACKindGrant    RBACChangeKind = "grant"
+)
+
+// RBACChangeOp 变更操作
+type RBACChangeOp string
+
+// 定义变更操作常量
+const (
+	RBACOpCreate RBACChangeOp = "create"
+	RBACOpUpdate RBACChangeOp = "update"
+	RBACOpDelete RBACChangeOp = "delete"
+)
+
+// RBACImportParam 导入参数
+type RBACImportParam struct {
+	DryRun bool `form:"dryRun"` // 是否仅对比差异(不修改数据)
+	Prune  bool `form:"prune"`  // 是否删除文档中不存在的菜单、动作、资源、角色及授权
+}
+
+// RBACChange 与当前数据的差异项
+type RBACChange struct {
+	Kind RBACChangeKind `json:"kind"`           // 对象类型
+	Op   RBACChangeOp   `json:"op"`             // 变更操作
+	Key  string         `json:"key"`            // 业务标识(菜单路径、菜单路径#动作编号、角色名称等)
+	From string         `json:"from,omitempty"` // 变更前的值(仅更新)
+	To   string         `json:"to,omitempty"`   // 变更后的值(仅更新)
+}
+
+// RBACImportResult 导入结果
+type RBACImportResult struct {
+	DryRun  bool          `json:"dry_run"` // 是否仅对比差异
+	Applied bool          `json:"applied"` // 是否已修改数据
+	Created int           `json:"created"` // 新建数
+	Updated int           `json:"updated"` // 更新数
+	Deleted int           `json:"deleted"` // 删除数
+	Changes []*RBACChange `json:"changes"` // 差异列表
+}
+
+// AddChange 添加差异项
+func (a *RBACImportResult) AddChange(kind RBACChangeKind, op RBACChangeOp, key string, fromTo ...string) {
+	item := &RBACChange{Kind: kind, Op: op, Key: key}
+	if len(fromTo) == 2 {
+		item.From, item.To = fromTo[0], fromTo[1]
+	}
+	switch op {
+	case RBACOpCreate:
+		a.Created++
+	case RBACOpUpdate:
+		a.Updated++
+	case RBACOpDelete:
+		a.Deleted++
+	}
+	a.Changes = append(a.Changes, item)
+}
@@ -13,16 +13,37 @@ type Role struct {
 	CreatedAt time.Time `json:"created_at"`                            // 创建时间
 	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
 	RoleMenus RoleMenus `json:"role_menus" binding:"required,gt=0"`    // 角色菜单列表
+	TenantID  string    `json:"-"`                                     // 所属租户
 }
 
 // RoleQueryParam 查询条件
 type RoleQueryParam struct {
 	PaginationParam
-	IDs        []string `form:"-"`          // 唯一标识列表
-	Name       string   `form:"-"`          // 角色名称
-	QueryValue string   `form:"queryValue"` // 模糊查询
-	UserID     string   `form:"-"`          // 用户ID
-	Status     int      `form:"status"`     // 状态(1:启用 2:禁用)
+	IDs        []string       `form:"-"`          // 唯一标识列表
+	Name       string         `form:"-"`          // 角色名称
+	QueryValue string         `form:"queryValue"` // 模糊查询
+	UserID     string         `form:"-"`          // 用户ID
+	Status     int            `form:"status"`     // 状态(1:启用 2:禁用)
+	Filters    []*FilterField `form:"-"`          // 通用过滤条件
+}
+
+// RoleQueryWhitelist 角色通用查询白名单
+var RoleQueryWhitelist = QueryWhitelist{
+	Filters: map[string]string{
+		"name":       "name",
+		"status":     "status",
+		"sequence":   "sequence",
+		"creator":    "creator",
+		"created_at": "created_at",
+	},
+	Sorts: map[string]string{
+		"name":       "name",
+		"status":     "status",
+		"sequence":   "sequence",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	Fields: []string{"id", "name", "sequence", "memo", "status", "creator", "created_at", "updated_at", "role_menus"},
 }
 
 // RoleQueryOptions 查询可选参数项
@@ -135,3 +156,30 @@ func (a RoleMenus) ToActionIDs() []string {
 	}
 	return idList
 }
+
+// ----------------------------------------RoleGrant--------------------------------------
+
+// RoleGrant 角色菜单授权显示项
+type RoleGrant struct {
+	MenuID     string `json:"menu_id"`     // 菜单ID
+	MenuName   string `json:"menu_name"`   // 菜单名称
+	ActionCode string `json:"action_code"` // 动作编号
+	ActionName string `json:"action_name"` // 动作名称
+}
+
+// String 授权的显示文本(菜单名称:动作名称)
+func (a *RoleGrant) String() string {
+	return a.MenuName + ":" + a.ActionName
+}
+
+// RoleGrants 角色菜单授权显示项列表
+type RoleGrants []*RoleGrant
+
+// ToStrings 转换为显示文本列表
+func (a RoleGrants) ToStrings() []string {
+	list := make([]string, len(a))
+	for i, item := range a {
+		list[i] = item.String()
+	}
+	return list
+}
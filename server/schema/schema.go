@@ -1,6 +1,15 @@
 
 package schema
 
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/key7men/mag/pkg/util"
+)
+
 // StatusText 定义状态文本
 type StatusText string
 
@@ -27,7 +36,26 @@ type ErrorResult struct {
 
 // ErrorItem 响应错误项
 type ErrorItem struct {
-	Code    int    `json:"code"`    // 错误码
+	Code    int    `json:"code"`         // 错误码
+	ID      string `json:"id,omitempty"` // 错误标识(多语言消息目录中的键)
+	Message string `json:"message"`      // 错误信息
+}
+
+// ProblemResult RFC 7807 problem+json格式的响应错误
+type ProblemResult struct {
+	Type    string        `json:"type"`               // 错误类型(URI)
+	Title   string        `json:"title"`              // 错误类型的简要说明
+	Status  int           `json:"status"`             // 响应状态码
+	Detail  string        `json:"detail"`             // 错误详情
+	Code    int           `json:"code"`               // 错误码
+	TraceID string        `json:"trace_id,omitempty"` // 跟踪ID
+	Errors  []*FieldError `json:"errors,omitempty"`   // 字段校验错误
+}
+
+// FieldError 字段校验错误
+type FieldError struct {
+	Field   string `json:"field"`   // 字段名称
+	Rule    string `json:"rule"`    // 校验规则
 	Message string `json:"message"` // 错误信息
 }
 
@@ -39,17 +67,27 @@ type ListResult struct {
 
 // PaginationResult 分页查询结果
 type PaginationResult struct {
-	Total    int  `json:"total"`
-	Current  uint `json:"current"`
-	PageSize uint `json:"pageSize"`
+	Total      int    `json:"total"`                // 总数(未查询总数时为0)
+	Current    uint   `json:"current"`              // 当前页(游标分页时为0)
+	PageSize   uint   `json:"pageSize"`             // 页大小
+	NextCursor string `json:"nextCursor,omitempty"` // 下一页游标(游标分页时有效)
+	PrevCursor string `json:"prevCursor,omitempty"` // 上一页游标(游标分页时有效)
 }
 
 // PaginationParam 分页查询条件
 type PaginationParam struct {
-	Pagination bool `form:"-"`                                     // 是否使用分页查询
-	OnlyCount  bool `form:"-"`                                     // 是否仅查询count
-	Current    uint `form:"current,default=1"`                     // 当前页
-	PageSize   uint `form:"pageSize,default=10" binding:"max=100"` // 页大小
+	Pagination bool   `form:"-"`                                     // 是否使用分页查询
+	OnlyCount  bool   `form:"-"`                                     // 是否仅查询count
+	Current    uint   `form:"current,default=1"`                     // 当前页
+	PageSize   uint   `form:"pageSize,default=10" binding:"max=100"` // 页大小
+	Cursor     string `form:"cursor"`                                // 游标(由上一次查询结果返回)
+	UseCursor  bool   `form:"useCursor"`                             // 是否使用游标分页(查询首页时指定)
+	NoCount    bool   `form:"noCount"`                               // 是否不查询总数
+}
+
+// IsCursor 是否使用游标分页
+func (a PaginationParam) IsCursor() bool {
+	return a.UseCursor || a.Cursor != ""
 }
 
 // GetCurrent 获取当前页
@@ -117,6 +155,59 @@ type OrderField struct {
 	Direction OrderDirection // 排序方向
 }
 
+// PageCursor 分页游标(以排序字段及ID作为定位条件)
+type PageCursor struct {
+	Prev   bool              `json:"p,omitempty"` // 是否向前翻页
+	Order  string            `json:"o"`           // 生成游标时的排序规则(如code:desc,id:asc)
+	Values []json.RawMessage `json:"v"`           // 定位记录的排序字段值(与排序字段一一对应，NULL值为null)
+}
+
+// FormatCursorOrder 格式化游标的排序规则(如code:desc,id:asc)
+func FormatCursorOrder(items []*OrderField) string {
+	orders := make([]string, len(items))
+	for i, item := range items {
+		direction := "asc"
+		if item.Direction == OrderByDESC {
+			direction = "desc"
+		}
+		orders[i] = item.Key + ":" + direction
+	}
+	return strings.Join(orders, ",")
+}
+
+// Match 游标是否由相同的排序规则生成(排序规则变化后游标无效)
+func (a *PageCursor) Match(items []*OrderField) bool {
+	return a.Order == FormatCursorOrder(items) && len(a.Values) == len(items)
+}
+
+// IsNull 指定位置的排序字段值是否为NULL
+func (a *PageCursor) IsNull(i int) bool {
+	return bytes.Equal(bytes.TrimSpace(a.Values[i]), []byte("null"))
+}
+
+// EncodeCursor 将游标编码为不透明的字符串
+func EncodeCursor(c *PageCursor) string {
+	buf, err := util.JSONMarshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeCursor 解析不透明的游标字符串
+func DecodeCursor(s string) (*PageCursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(PageCursor)
+	if err := util.JSONUnmarshal(buf, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // NewIDResult 创建响应唯一标识实例
 func NewIDResult(id string) *IDResult {
 	return &IDResult{
@@ -128,3 +219,115 @@ func NewIDResult(id string) *IDResult {
 type IDResult struct {
 	ID string `json:"id"`
 }
+
+// FilterOperator 过滤操作符
+type FilterOperator string
+
+// 定义过滤操作符常量
+const (
+	FilterEQ   FilterOperator = "eq"   // 等于
+	FilterNE   FilterOperator = "ne"   // 不等于
+	FilterGT   FilterOperator = "gt"   // 大于
+	FilterGTE  FilterOperator = "gte"  // 大于等于
+	FilterLT   FilterOperator = "lt"   // 小于
+	FilterLTE  FilterOperator = "lte"  // 小于等于
+	FilterLike FilterOperator = "like" // 模糊匹配
+	FilterIn   FilterOperator = "in"   // 包含(多个值以逗号分隔)
+)
+
+// IsValid 检查操作符是否有效
+func (a FilterOperator) IsValid() bool {
+	switch a {
+	case FilterEQ, FilterNE, FilterGT, FilterGTE, FilterLT, FilterLTE, FilterLike, FilterIn:
+		return true
+	}
+	return false
+}
+
+// FilterField 过滤字段
+type FilterField struct {
+	Key      string         // 字段名(白名单映射后的列名，约束为小写蛇形)
+	Operator FilterOperator // 操作符
+	Values   []string       // 过滤值(仅in操作符允许多个值)
+}
+
+// QueryWhitelist 通用查询白名单(查询键映射为列名)
+type QueryWhitelist struct {
+	Filters map[string]string // 允许过滤的字段
+	Sorts   map[string]string // 允许排序的字段
+	Fields  []string          // 允许选择的响应字段(JSON键)
+}
+
+// QueryCondition 通用查询条件(由查询字符串 filter[key][op]=v&sort=-a,b&fields=a,b 解析)
+type QueryCondition struct {
+	Filters     []*FilterField // 过滤条件
+	OrderFields []*OrderField  // 排序字段
+	Fields      []string       // 选择字段
+}
+
+// MergeOrderFields 合并排序字段(客户端指定的排序优先，默认排序作为补充)
+func (a *QueryCondition) MergeOrderFields(defaults ...*OrderField) []*OrderField {
+	if a == nil || len(a.OrderFields) == 0 {
+		return defaults
+	}
+
+	m := make(map[string]struct{})
+	fields := make([]*OrderField, 0, len(a.OrderFields)+len(defaults))
+	for _, item := range a.OrderFields {
+		m[item.Key] = struct{}{}
+		fields = append(fields, item)
+	}
+	for _, item := range defaults {
+		if _, ok := m[item.Key]; !ok {
+			fields = append(fields, item)
+		}
+	}
+	return fields
+}
+
+// SelectFields 按选择字段裁剪响应数据(支持对象及对象列表，字段按选择顺序输出，未指定字段时原样返回)
+func (a *QueryCondition) SelectFields(v interface{}) (interface{}, error) {
+	if a == nil || len(a.Fields) == 0 {
+		return v, nil
+	}
+
+	buf, err := util.JSONMarshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &list); err == nil {
+		result := make([]json.RawMessage, len(list))
+		for i, item := range list {
+			result[i] = a.selectFields(item)
+		}
+		return result, nil
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &item); err != nil {
+		return nil, err
+	}
+	return a.selectFields(item), nil
+}
+
+func (a *QueryCondition) selectFields(item map[string]json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, key := range a.Fields {
+		v, ok := item[key]
+		if !ok {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	orderFields := NewOrderFields(NewOrderField("code", OrderByDESC), NewOrderField("id", OrderByASC))
+	order := FormatCursorOrder(orderFields)
+	if order != "code:desc,id:asc" {
+		t.Fatalf("order: got %s", order)
+	}
+
+	c := &PageCursor{Prev: true, Order: order, Values: []json.RawMessage{json.RawMessage("null"), json.RawMessage(`"demo-001"`)}}
+	decoded, err := DecodeCursor(EncodeCursor(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, c) {
+		t.Fatalf("decode: got %+v, want %+v", decoded, c)
+	}
+	if !decoded.IsNull(0) || decoded.IsNull(1) {
+		t.Errorf("null values: got %v,%v", decoded.IsNull(0), decoded.IsNull(1))
+	}
+
+	for _, tc := range []struct {
+		name        string
+		orderFields []*OrderField
+		match       bool
+	}{
+		{"same order", orderFields, true},
+		{"other direction", NewOrderFields(NewOrderField("code", OrderByASC), NewOrderField("id", OrderByASC)), false},
+		{"other field", NewOrderFields(NewOrderField("name", OrderByDESC), NewOrderField("id", OrderByASC)), false},
+		{"fewer fields", NewOrderFields(NewOrderField("id", OrderByASC)), false},
+	} {
+		if got := decoded.Match(tc.orderFields); got != tc.match {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.match)
+		}
+	}
+
+	// 排序规则相同但值的数量不一致时无效
+	c.Values = c.Values[:1]
+	if c.Match(orderFields) {
+		t.Error("cursor with missing values matched")
+	}
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	for _, s := range []string{"invalid!", "bm90IGpzb24", ""} {
+		if _, err := DecodeCursor(s); err == nil {
+			t.Errorf("DecodeCursor(%q): expected error", s)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"strings"
+	"time"
+)
+
+// Tenant 租户对象
+type Tenant struct {
+	ID        string       `json:"id"`                                    // 唯一标识
+	Code      string       `json:"code" binding:"required,max=50"`        // 租户编号(唯一，可作为子域名识别租户)
+	Name      string       `json:"name" binding:"required"`               // 租户名称
+	Domain    string       `json:"domain"`                                // 绑定的域名(唯一)
+	Status    int          `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:禁用)
+	Memo      string       `json:"memo"`                                  // 备注
+	AdminID   string       `json:"admin_id"`                              // 租户管理员(租户内拥有全部权限的用户)
+	Creator   string       `json:"creator"`                               // 创建者
+	CreatedAt time.Time    `json:"created_at"`                            // 创建时间
+	UpdatedAt time.Time    `json:"updated_at"`                            // 更新时间
+	Admin     *TenantAdmin `json:"admin,omitempty"`                       // 租户管理员账号(仅创建时提供)
+}
+
+// TenantAdmin 创建租户时开通的管理员账号
+type TenantAdmin struct {
+	UserName string `json:"user_name" binding:"required"` // 用户名
+	RealName string `json:"real_name" binding:"required"` // 真实姓名
+	Password string `json:"password" binding:"required"`  // 密码
+	Phone    string `json:"phone"`                        // 手机号
+	Email    string `json:"email"`                        // 邮箱
+}
+
+// TenantQueryParam 查询条件
+type TenantQueryParam struct {
+	PaginationParam
+	Code       string `form:"code"`       // 租户编号
+	Domain     string `form:"-"`          // 绑定的域名
+	QueryValue string `form:"queryValue"` // 模糊查询
+	Status     int    `form:"status"`     // 状态(1:启用 2:禁用)
+}
+
+// TenantQueryOptions 查询可选参数项
+type TenantQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// TenantQueryResult 查询结果
+type TenantQueryResult struct {
+	Data       Tenants
+	PageResult *PaginationResult
+}
+
+// Tenants 租户列表
+type Tenants []*Tenant
+
+// ToMap 转换为键值存储
+func (a Tenants) ToMap() map[string]*Tenant {
+	m := make(map[string]*Tenant)
+	for _, item := range a {
+		m[item.ID] = item
+	}
+	return m
+}
+
+// TenantHost 去除请求Host中的端口并转为小写
+func TenantHost(host string) string {
+	if i := strings.LastIndexByte(host, ':'); i > strings.LastIndexByte(host, ']') {
+		host = host[:i]
+	}
+	return strings.ToLower(host)
+}
+
+// TenantCodeOfHost 按上级域名从Host中解析租户编号(如上级域名为example.com时，acme.example.com解析为acme)
+func TenantCodeOfHost(host, domain string) (string, bool) {
+	if domain == "" {
+		return "", false
+	}
+
+	suffix := "." + strings.ToLower(strings.TrimPrefix(domain, "."))
+	if !strings.HasSuffix(host, suffix) {
+		return "", false
+	}
+
+	code := host[:len(host)-len(suffix)]
+	if code == "" || strings.Contains(code, ".") {
+		return "", false
+	}
+	return code, true
+}
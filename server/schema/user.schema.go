@@ -36,6 +36,7 @@ type User struct {
 	Creator   string    `json:"creator"`                               // 创建者
 	CreatedAt time.Time `json:"created_at"`                            // 创建时间
 	UserRoles UserRoles `json:"user_roles" binding:"required,gt=0"`    // 角色授权
+	TenantID  string    `json:"-"`                                     // 所属租户
 }
 
 func (a *User) String() string {
@@ -51,10 +52,30 @@ func (a *User) CleanSecure() *User {
 // UserQueryParam 查询条件
 type UserQueryParam struct {
 	PaginationParam
-	UserName   string   `form:"userName"`   // 用户名
-	QueryValue string   `form:"queryValue"` // 模糊查询
-	Status     int      `form:"status"`     // 用户状态(1:启用 2:停用)
-	RoleIDs    []string `form:"-"`          // 角色ID列表
+	UserName   string         `form:"userName"`   // 用户名
+	QueryValue string         `form:"queryValue"` // 模糊查询
+	Status     int            `form:"status"`     // 用户状态(1:启用 2:停用)
+	RoleIDs    []string       `form:"-"`          // 角色ID列表
+	Filters    []*FilterField `form:"-"`          // 通用过滤条件
+}
+
+// UserQueryWhitelist 用户通用查询白名单
+var UserQueryWhitelist = QueryWhitelist{
+	Filters: map[string]string{
+		"user_name":  "user_name",
+		"real_name":  "real_name",
+		"phone":      "phone",
+		"email":      "email",
+		"status":     "status",
+		"created_at": "created_at",
+	},
+	Sorts: map[string]string{
+		"user_name":  "user_name",
+		"real_name":  "real_name",
+		"status":     "status",
+		"created_at": "created_at",
+	},
+	Fields: []string{"id", "user_name", "real_name", "phone", "email", "status", "created_at", "roles"},
 }
 
 // UserQueryOptions 查询可选参数项
@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// EventWebhookPing webhook测试事件
+const EventWebhookPing = "webhook.ping"
+
+// Webhook webhook订阅对象
+type Webhook struct {
+	ID        string    `json:"id"`                                    // 唯一标识
+	Name      string    `json:"name" binding:"required"`               // 名称
+	URL       string    `json:"url" binding:"required,url"`            // 投递地址
+	Secret    string    `json:"secret"`                                // 签名密钥(HMAC-SHA256，仅写入，查询时不返回)
+	Events    []string  `json:"events"`                                // 订阅的事件类型(支持user.*形式的通配，为空表示全部)
+	Status    int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:禁用)
+	Memo      string    `json:"memo"`                                  // 备注
+	Creator   string    `json:"creator"`                               // 创建者
+	CreatedAt time.Time `json:"created_at"`                            // 创建时间
+	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
+}
+
+// CleanSecure 清理安全数据
+func (a *Webhook) CleanSecure() *Webhook {
+	a.Secret = ""
+	return a
+}
+
+// WebhookSecretResult 签名密钥(仅在创建及重新生成时返回)
+type WebhookSecretResult struct {
+	ID     string `json:"id"`     // 唯一标识
+	Secret string `json:"secret"` // 签名密钥
+}
+
+// Match 检查是否订阅了指定的事件类型
+func (a *Webhook) Match(eventType string) bool {
+	if len(a.Events) == 0 || eventType == EventWebhookPing {
+		return true
+	}
+
+	for _, e := range a.Events {
+		if e == "*" || e == eventType {
+			return true
+		}
+		if strings.HasSuffix(e, ".*") && strings.HasPrefix(eventType, e[:len(e)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookQueryParam 查询条件
+type WebhookQueryParam struct {
+	PaginationParam
+	QueryValue string `form:"queryValue"` // 模糊查询
+	Status     int    `form:"status"`     // 状态(1:启用 2:禁用)
+	URL        string `form:"-"`          // 投递地址
+}
+
+// WebhookQueryOptions 查询可选参数项
+type WebhookQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// WebhookQueryResult 查询结果
+type WebhookQueryResult struct {
+	Data       Webhooks
+	PageResult *PaginationResult
+}
+
+// Webhooks webhook订阅列表
+type Webhooks []*Webhook
+
+// ToMap 转换为键值存储
+func (a Webhooks) ToMap() map[string]*Webhook {
+	m := make(map[string]*Webhook)
+	for _, item := range a {
+		m[item.ID] = item
+	}
+	return m
+}
+
+// ----------------------------------------WebhookDelivery--------------------------------------
+
+// WebhookDeliveryStatus webhook投递状态
+type WebhookDeliveryStatus int
+
+// 定义webhook投递状态常量
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = 1 // 待投递
+	WebhookDeliverySucceeded WebhookDeliveryStatus = 2 // 投递成功
+	WebhookDeliveryDead      WebhookDeliveryStatus = 3 // 超过最大重试次数(死信)
+)
+
+// WebhookDelivery webhook投递记录
+type WebhookDelivery struct {
+	ID             string                `json:"id"`              // 唯一标识
+	WebhookID      string                `json:"webhook_id"`      // webhook订阅ID
+	EventID        string                `json:"event_id"`        // 事件ID
+	EventType      string                `json:"event_type"`      // 事件类型
+	Payload        json.RawMessage       `json:"payload"`         // 投递内容(每次重试内容不变)
+	Status         WebhookDeliveryStatus `json:"status"`          // 投递状态
+	Attempts       int                   `json:"attempts"`        // 投递次数
+	ResponseStatus int                   `json:"response_status"` // 最后一次响应状态码
+	ResponseBody   string                `json:"response_body"`   // 最后一次响应内容(截断)
+	LastError      string                `json:"last_error"`      // 最后一次投递错误
+	NextRetryAt    time.Time             `json:"next_retry_at"`   // 下次投递时间
+	CreatedAt      time.Time             `json:"created_at"`      // 创建时间
+	UpdatedAt      time.Time             `json:"updated_at"`      // 更新时间
+}
+
+// WebhookPayload webhook投递内容
+type WebhookPayload struct {
+	ID        string          `json:"id"`         // 事件ID
+	Type      string          `json:"type"`       // 事件类型
+	CreatedAt time.Time       `json:"created_at"` // 事件时间
+	Data      json.RawMessage `json:"data"`       // 事件数据
+}
+
+// WebhookDeliveryQueryParam 查询条件
+type WebhookDeliveryQueryParam struct {
+	PaginationParam
+	WebhookID   string    `form:"-"`         // webhook订阅ID
+	EventID     string    `form:"-"`         // 事件ID
+	EventType   string    `form:"eventType"` // 事件类型
+	Status      int       `form:"status"`    // 投递状态(1:待投递 2:投递成功 3:死信)
+	RetryBefore time.Time `form:"-"`         // 下次投递时间不晚于
+}
+
+// WebhookDeliveryQueryOptions 查询可选参数项
+type WebhookDeliveryQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// WebhookDeliveryQueryResult 查询结果
+type WebhookDeliveryQueryResult struct {
+	Data       WebhookDeliveries
+	PageResult *PaginationResult
+}
+
+// WebhookDeliveries webhook投递记录列表
+type WebhookDeliveries []*WebhookDelivery
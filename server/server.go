@@ -5,10 +5,10 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -16,12 +16,17 @@ import (
 	"github.com/dchest/captcha"
 	"github.com/go-redis/redis"
 	"github.com/google/gops/agent"
+	"github.com/key7men/mag/pkg/i18n"
 	"github.com/key7men/mag/pkg/logger"
 	loggerhook "github.com/key7men/mag/pkg/logger/hook"
 	loggergormhook "github.com/key7men/mag/pkg/logger/hook/gorm"
+	loggermongohook "github.com/key7men/mag/pkg/logger/hook/mongo"
+	"github.com/key7men/mag/pkg/trace"
 	"github.com/key7men/mag/server/assist/uuid"
 	"github.com/key7men/mag/server/config"
 	ecaptcha "github.com/key7men/mag/server/enhance/captcha"
+	"github.com/key7men/mag/server/module/health"
+	"github.com/key7men/mag/server/module/metrics"
 	"github.com/key7men/mag/server/provider"
 	"github.com/sirupsen/logrus"
 
@@ -84,12 +89,17 @@ func Init(ctx context.Context, opts ...Option) (func(), error) {
 	}
 
 	config.MustLoad(o.ConfigFile)
-	if v := o.ModelFile; v != "" {
-		config.C.Casbin.Model = v
-	}
-	if v := o.StaticDir; v != "" {
-		config.C.Static = v
-	}
+	config.Override(func(c *config.Config) {
+		if v := o.ModelFile; v != "" {
+			c.Casbin.Model = v
+		}
+		if v := o.StaticDir; v != "" {
+			c.Static = v
+		}
+		if v := o.MenuFile; v != "" {
+			c.Menu.Data = v
+		}
+	})
 
 	config.PrintWithJSON()
 
@@ -104,36 +114,70 @@ func Init(ctx context.Context, opts ...Option) (func(), error) {
 		return nil, err
 	}
 
+	// 初始化链路跟踪
+	traceCleanFunc, err := InitTrace(ctx, o.Version)
+	if err != nil {
+		return nil, err
+	}
+
 	// 初始化服务运行监控
 	InitMonitor(ctx)
 
 	// 初始化图形验证码
 	InitCaptcha()
 
+	// 初始化多语言消息
+	err = InitI18n()
+	if err != nil {
+		return nil, err
+	}
+
 	// 初始化依赖注入器
-	injector, injectorCleanFunc, err := provider.BuildInjector()
+	injector, injectorCleanFunc, err := BuildInjector()
 	if err != nil {
 		return nil, err
 	}
 
 	// 初始化菜单数据
 	if config.C.Menu.Enable && config.C.Menu.Data != "" {
-		err = injector.MenuBiz.InitData(ctx, config.C.Menu.Data)
+		err = InitMenuData(ctx, injector)
+		if err != nil {
+			return nil, err
+		}
+		injector.Health.Done("menu")
+	}
+
+	// 初始化OpenAPI文档
+	if config.C.Swagger {
+		err = InitOpenAPI(injector.Engine, injector.Router, o.Version)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// 初始化HTTP服务
-	httpServerCleanFunc := InitHTTPServer(ctx, injector.Engine)
+	httpServerCleanFunc := InitHTTPServer(ctx, injector.Engine, injector.Health)
+
+	// 初始化独立的指标服务
+	metricsServerCleanFunc := InitMetricsServer(ctx)
 
 	return func() {
+		metricsServerCleanFunc()
 		httpServerCleanFunc()
 		injectorCleanFunc()
+		traceCleanFunc()
 		loggerCleanFunc()
 	}, nil
 }
 
+// BuildInjector 根据存储引擎创建依赖注入器
+func BuildInjector() (*provider.Provider, func(), error) {
+	if config.C.Store == "mongo" {
+		return provider.BuildMongoInjector()
+	}
+	return provider.BuildInjector()
+}
+
 // InitCaptcha 初始化验证码生成器
 func InitCaptcha() {
 	cfg := config.C.Captcha
@@ -147,6 +191,46 @@ func InitCaptcha() {
 	}
 }
 
+// InitI18n 初始化多语言消息(加载语言文件并设定默认语言)
+func InitI18n() error {
+	cfg := config.C.I18n
+	if cfg.Dir != "" {
+		if err := i18n.LoadDir(cfg.Dir); err != nil {
+			return err
+		}
+	}
+	if cfg.DefaultLocale != "" {
+		i18n.SetDefaultLocale(cfg.DefaultLocale)
+	}
+
+	config.OnReload(func(old, new *config.Config) {
+		if new.I18n.DefaultLocale != old.I18n.DefaultLocale && new.I18n.DefaultLocale != "" {
+			i18n.SetDefaultLocale(new.I18n.DefaultLocale)
+		}
+	})
+	return nil
+}
+
+// InitTrace 初始化链路跟踪
+func InitTrace(ctx context.Context, version string) (func(), error) {
+	c := config.C.Trace
+	if !c.Enable {
+		return func() {}, nil
+	}
+
+	return trace.InitProvider(ctx, &trace.Config{
+		ServiceName:    c.ServiceName,
+		ServiceVersion: version,
+		Exporter:       c.Exporter,
+		Endpoint:       c.Endpoint,
+		URLPath:        c.URLPath,
+		Insecure:       c.Insecure,
+		Headers:        c.Headers,
+		File:           c.File,
+		SampleRatio:    c.SampleRatio,
+	})
+}
+
 // InitMonitor 初始化服务监控
 func InitMonitor(ctx context.Context) {
 	if c := config.C.Monitor; c.Enable {
@@ -158,7 +242,7 @@ func InitMonitor(ctx context.Context) {
 }
 
 // InitHTTPServer 初始化http服务
-func InitHTTPServer(ctx context.Context, handler http.Handler) func() {
+func InitHTTPServer(ctx context.Context, handler http.Handler, h *health.Health) func() {
 	cfg := config.C.HTTP
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 	srv := &http.Server{
@@ -184,6 +268,12 @@ func InitHTTPServer(ctx context.Context, handler http.Handler) func() {
 	}()
 
 	return func() {
+		// 就绪检查先返回失败，等待负载均衡摘除后再关闭
+		h.Shutdown()
+		if cfg.ShutdownDelay > 0 {
+			time.Sleep(time.Second * time.Duration(cfg.ShutdownDelay))
+		}
+
 		ctx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(cfg.ShutdownTimeout))
 		defer cancel()
 
@@ -194,36 +284,116 @@ func InitHTTPServer(ctx context.Context, handler http.Handler) func() {
 	}
 }
 
+// InitMetricsServer 初始化独立监听的prometheus指标服务
+func InitMetricsServer(ctx context.Context) func() {
+	cfg := config.C.Metrics
+	if !cfg.Enable || cfg.Addr == "" {
+		return func() {}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, metrics.Handler())
+	srv := &http.Server{
+		Addr:         cfg.Addr,
+		Handler:      mux,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		logger.Printf(ctx, "Metrics server is running at %s.", cfg.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf(ctx, "Metrics server error: %s", err.Error())
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Errorf(ctx, err.Error())
+		}
+	}
+}
+
 // InitLogger 初始化日志模块
 func InitLogger() (func(), error) {
 	c := config.C.Log
-	logger.SetLevel(c.Level)
-	logger.SetFormatter(c.Format)
 
-	// 设定日志输出
-	var file *os.File
-	if c.Output != "" {
-		switch c.Output {
+	// 设定日志输出(未配置多个输出时使用Output/OutputFile)
+	items := c.Outputs
+	if len(items) == 0 && c.Output != "" {
+		items = []config.LogOutput{{Output: c.Output, File: c.OutputFile}}
+	}
+
+	var (
+		outputs []logger.Output
+		files   []*logger.RotateWriter
+	)
+	for _, item := range items {
+		var w io.Writer
+		switch item.Output {
 		case "stdout":
-			logger.SetOutput(os.Stdout)
+			w = os.Stdout
 		case "stderr":
-			logger.SetOutput(os.Stderr)
+			w = os.Stderr
 		case "file":
-			if name := c.OutputFile; name != "" {
-				_ = os.MkdirAll(filepath.Dir(name), 0777)
+			if item.File == "" {
+				continue
+			}
+			rc := c.Rotate
+			f := logger.NewRotateWriter(logger.RotateConfig{
+				Filename:   item.File,
+				MaxSize:    rc.MaxSize,
+				MaxBackups: rc.MaxBackups,
+				MaxAge:     rc.MaxAge,
+				Compress:   rc.Compress,
+				LocalTime:  rc.LocalTime,
+				Interval:   time.Duration(rc.Interval) * time.Second,
+			})
+			w = f
+			files = append(files, f)
+		default:
+			continue
+		}
+		outputs = append(outputs, logger.Output{Writer: w, Level: item.Level, Format: item.Format})
+	}
 
-				f, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0666)
-				if err != nil {
-					return nil, err
-				}
-				logger.SetOutput(f)
-				file = f
+	// 未单独设定级别及格式的输出使用[Log]中的配置(支持热更新)
+	setOutputs := func(c config.Log) {
+		logger.SetLevel(c.Level)
+		logger.SetFormatter(c.Format)
+		if len(outputs) == 0 {
+			return
+		}
+
+		items := make([]logger.Output, len(outputs))
+		for i, o := range outputs {
+			if o.Level == 0 {
+				o.Level = c.Level
+			}
+			if o.Format == "" {
+				o.Format = c.Format
 			}
+			items[i] = o
 		}
+		logger.SetOutputs(items...)
 	}
+	setOutputs(c)
+	config.OnReload(func(old, new *config.Config) {
+		if old.Log.Level != new.Log.Level || old.Log.Format != new.Log.Format {
+			setOutputs(new.Log)
+		}
+	})
 
 	var hook *loggerhook.Hook
 	if c.EnableHook {
+		// 系统日志接口读取的存储须与钩子写入的存储一致
+		if err := config.C.CheckLogStore(); err != nil {
+			return nil, err
+		}
+
 		var hookLevels []logrus.Level
 		for _, lvl := range c.HookLevels {
 			plvl, err := logrus.ParseLevel(lvl)
@@ -233,6 +403,7 @@ func InitLogger() (func(), error) {
 			hookLevels = append(hookLevels, plvl)
 		}
 
+		var exec loggerhook.ExecCloser
 		switch {
 		case c.Hook.IsGorm():
 			hc := config.C.LogGormHook
@@ -249,34 +420,80 @@ func InitLogger() (func(), error) {
 				return nil, errors.New("unknown db")
 			}
 
-			h := loggerhook.New(loggergormhook.New(&loggergormhook.Config{
+			exec = loggergormhook.New(&loggergormhook.Config{
 				DBType:       hc.DBType,
 				DSN:          dsn,
 				MaxLifetime:  hc.MaxLifetime,
 				MaxOpenConns: hc.MaxOpenConns,
 				MaxIdleConns: hc.MaxIdleConns,
 				TableName:    hc.Table,
-			}),
+			})
+		case c.Hook.IsMongo():
+			hc := config.C.LogMongoHook
+			mc := config.C.Mongo
+
+			exec = loggermongohook.New(&loggermongohook.Config{
+				URI:        mc.URI,
+				Database:   mc.Database,
+				Collection: hc.Collection,
+				Timeout:    mc.Timeout,
+				TTL:        hc.TTL,
+			})
+		}
+
+		if exec != nil {
+			hook = loggerhook.New(exec,
 				loggerhook.SetMaxWorkers(c.HookMaxThread),
 				loggerhook.SetMaxQueues(c.HookMaxBuffer),
+				loggerhook.SetBatchSize(c.HookBatchSize),
+				loggerhook.SetFlushInterval(time.Duration(c.HookFlushInterval)*time.Millisecond),
+				loggerhook.SetOverflowPolicy(loggerhook.OverflowPolicy(c.HookOverflow)),
+				loggerhook.SetRetry(c.HookMaxRetries, time.Duration(c.HookRetryInterval)*time.Millisecond),
+				loggerhook.SetSpoolDir(c.HookSpoolDir),
 				loggerhook.SetLevels(hookLevels...),
 			)
-			logger.AddHook(h)
-			hook = h
+			logger.AddHook(hook)
+
+			if config.C.Metrics.Enable {
+				if err := metrics.RegisterLogHook(hook); err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
 	return func() {
-		if file != nil {
-			file.Close()
-		}
-
 		if hook != nil {
 			hook.Flush()
+			if s := hook.Stats(); s.Dropped > 0 || s.Failed > 0 || s.Spooled > 0 {
+				fmt.Fprintf(os.Stderr, "[logrus-hook] written: %d, dropped: %d, failed: %d, spooled: %d\n",
+					s.Written, s.Dropped, s.Failed, s.Spooled)
+			}
+		}
+
+		for _, f := range files {
+			_ = f.Close()
 		}
 	}, nil
 }
 
+// ReloadConfig 重新加载配置文件并生效支持热更新的配置项
+func ReloadConfig(ctx context.Context) {
+	result, err := config.Reload()
+	if err != nil {
+		logger.Errorf(ctx, "Reload config error: %s", err.Error())
+		return
+	}
+
+	if dir := config.C.I18n.Dir; dir != "" {
+		if err := i18n.LoadDir(dir); err != nil {
+			logger.Errorf(ctx, "Reload locale files error: %s", err.Error())
+		}
+	}
+
+	logger.Printf(ctx, "配置已重新加载，已生效：%v，需重启生效：%v", result.Applied, result.RestartRequired)
+}
+
 // Run 运行服务
 func Run(ctx context.Context, opts ...Option) error {
 	var state int32 = 1
@@ -296,6 +513,11 @@ EXIT:
 			atomic.CompareAndSwapInt32(&state, 1, 0)
 			break EXIT
 		case syscall.SIGHUP:
+			// 重新打开日志文件(配合logrotate等外部工具使用)
+			if err := logger.Reopen(); err != nil {
+				logger.Errorf(ctx, "Reopen log files error: %s", err.Error())
+			}
+			ReloadConfig(ctx)
 		default:
 			break EXIT
 		}
@@ -0,0 +1,513 @@
+// Code generated by mag gen client. DO NOT EDIT.
+import { HttpClient, HttpParams } from '@angular/common/http';
+import { Inject, Injectable } from '@angular/core';
+import { DA_SERVICE_TOKEN, ITokenService } from '@delon/auth';
+import { Observable } from 'rxjs';
+import { tap } from 'rxjs/operators';
+import {
+  Audit,
+  BulkIDParam,
+  BulkMenuCreateParam,
+  BulkResult,
+  BulkRoleCreateParam,
+  BulkUserCreateParam,
+  BulkUserRoleParam,
+  Demo,
+  ExportDemoQuery,
+  ExportRBACQuery,
+  ExportRoleQuery,
+  ExportUserQuery,
+  GetCaptchaPicQuery,
+  HealthResult,
+  IDResult,
+  ImportRBACQuery,
+  ImportResult,
+  ImportUserQuery,
+  ListResult,
+  Log,
+  LoginCaptcha,
+  LoginParam,
+  LoginTokenInfo,
+  Menu,
+  MenuTree,
+  QueryAuditQuery,
+  QueryDemoQuery,
+  QueryFilter,
+  QueryLogQuery,
+  QueryMenuQuery,
+  QueryMenuTreeQuery,
+  QueryRoleQuery,
+  QueryRoleSelectQuery,
+  QueryTenantQuery,
+  QueryUserQuery,
+  QueryWebhookDeadLetterQuery,
+  QueryWebhookDeliveryQuery,
+  QueryWebhookQuery,
+  RBACDocument,
+  RBACImportResult,
+  ReloadResult,
+  Role,
+  StatusResult,
+  Tenant,
+  UpdatePasswordParam,
+  User,
+  UserLoginInfo,
+  UserShow,
+  Webhook,
+  WebhookDelivery,
+  WebhookSecretResult,
+} from './models';
+
+/** 转换查询参数(空值忽略，数组按多个同名参数添加，filter转换为filter[key][op]=value) */
+function toParams(query?: object): HttpParams {
+  let params = new HttpParams();
+  if (!query) {
+    return params;
+  }
+
+  Object.keys(query).forEach((key) => {
+    const value = (query as any)[key];
+    if (value === undefined || value === null || value === '') {
+      return;
+    }
+    if (key === 'filter') {
+      (value as QueryFilter[]).forEach((f) => {
+        params = params.append(f.op ? `filter[${f.key}][${f.op}]` : `filter[${f.key}]`, f.value);
+      });
+      return;
+    }
+    (Array.isArray(value) ? value : [value]).forEach((v) => {
+      params = params.append(key, String(v));
+    });
+  });
+  return params;
+}
+
+/**
+ * 接口服务(登录及刷新令牌后自动保存访问令牌，请求时由JWTInterceptor添加认证信息)
+ */
+@Injectable({ providedIn: 'root' })
+export class ApiService {
+  constructor(private http: HttpClient, @Inject(DA_SERVICE_TOKEN) private tokenService: ITokenService) {}
+
+  /** 查询审计记录(GET /api/v1/audits) */
+  queryAudit(query?: QueryAuditQuery): Observable<ListResult<Audit>> {
+    return this.http.get<ListResult<Audit>>('/api/v1/audits', { params: toParams(query) });
+  }
+
+  /** 查询指定审计记录(GET /api/v1/audits/:id) */
+  getAudit(id: string): Observable<Audit> {
+    return this.http.get<Audit>(`/api/v1/audits/${encodeURIComponent(id)}`);
+  }
+
+  /** 重新加载配置文件(POST /api/v1/config.reload) */
+  reloadConfig(): Observable<ReloadResult> {
+    return this.http.post<ReloadResult>('/api/v1/config.reload', null);
+  }
+
+  /** 查询数据(GET /api/v1/demos) */
+  queryDemo(query?: QueryDemoQuery): Observable<ListResult<Demo>> {
+    return this.http.get<ListResult<Demo>>('/api/v1/demos', { params: toParams(query) });
+  }
+
+  /** 创建数据(POST /api/v1/demos) */
+  createDemo(body: Demo): Observable<IDResult> {
+    return this.http.post<IDResult>('/api/v1/demos', body);
+  }
+
+  /** 导出数据(GET /api/v1/demos.export) */
+  exportDemo(query?: ExportDemoQuery): Observable<Blob> {
+    return this.http.get('/api/v1/demos.export', { params: toParams(query), responseType: 'blob' });
+  }
+
+  /** 删除数据(DELETE /api/v1/demos/:id) */
+  deleteDemo(id: string): Observable<StatusResult> {
+    return this.http.delete<StatusResult>(`/api/v1/demos/${encodeURIComponent(id)}`);
+  }
+
+  /** 查询指定数据(GET /api/v1/demos/:id) */
+  getDemo(id: string): Observable<Demo> {
+    return this.http.get<Demo>(`/api/v1/demos/${encodeURIComponent(id)}`);
+  }
+
+  /** 更新数据(PUT /api/v1/demos/:id) */
+  updateDemo(id: string, body: Demo): Observable<StatusResult> {
+    return this.http.put<StatusResult>(`/api/v1/demos/${encodeURIComponent(id)}`, body);
+  }
+
+  /** 禁用数据(PATCH /api/v1/demos/:id/disable) */
+  disableDemo(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/demos/${encodeURIComponent(id)}/disable`, null);
+  }
+
+  /** 启用数据(PATCH /api/v1/demos/:id/enable) */
+  enableDemo(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/demos/${encodeURIComponent(id)}/enable`, null);
+  }
+
+  /** 查询日志(GET /api/v1/logs) */
+  queryLog(query?: QueryLogQuery): Observable<ListResult<Log>> {
+    return this.http.get<ListResult<Log>>('/api/v1/logs', { params: toParams(query) });
+  }
+
+  /** 查询跟踪ID的完整日志链路(GET /api/v1/logs/traces/:traceID) */
+  queryLogTrace(traceID: string): Observable<ListResult<Log>> {
+    return this.http.get<ListResult<Log>>(`/api/v1/logs/traces/${encodeURIComponent(traceID)}`);
+  }
+
+  /** 查询数据(GET /api/v1/menus) */
+  queryMenu(query?: QueryMenuQuery): Observable<ListResult<Menu>> {
+    return this.http.get<ListResult<Menu>>('/api/v1/menus', { params: toParams(query) });
+  }
+
+  /** 创建数据(POST /api/v1/menus) */
+  createMenu(body: Menu): Observable<IDResult> {
+    return this.http.post<IDResult>('/api/v1/menus', body);
+  }
+
+  /** 批量创建数据(POST /api/v1/menus.bulk/create) */
+  bulkCreateMenu(body: BulkMenuCreateParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/menus.bulk/create', body);
+  }
+
+  /** 批量删除数据(POST /api/v1/menus.bulk/delete) */
+  bulkDeleteMenu(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/menus.bulk/delete', body);
+  }
+
+  /** 批量禁用数据(POST /api/v1/menus.bulk/disable) */
+  bulkDisableMenu(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/menus.bulk/disable', body);
+  }
+
+  /** 批量启用数据(POST /api/v1/menus.bulk/enable) */
+  bulkEnableMenu(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/menus.bulk/enable', body);
+  }
+
+  /** 查询菜单树(GET /api/v1/menus.tree) */
+  queryMenuTree(query?: QueryMenuTreeQuery): Observable<ListResult<MenuTree>> {
+    return this.http.get<ListResult<MenuTree>>('/api/v1/menus.tree', { params: toParams(query) });
+  }
+
+  /** 删除数据(DELETE /api/v1/menus/:id) */
+  deleteMenu(id: string): Observable<StatusResult> {
+    return this.http.delete<StatusResult>(`/api/v1/menus/${encodeURIComponent(id)}`);
+  }
+
+  /** 查询指定数据(GET /api/v1/menus/:id) */
+  getMenu(id: string): Observable<Menu> {
+    return this.http.get<Menu>(`/api/v1/menus/${encodeURIComponent(id)}`);
+  }
+
+  /** 更新数据(PUT /api/v1/menus/:id) */
+  updateMenu(id: string, body: Menu): Observable<StatusResult> {
+    return this.http.put<StatusResult>(`/api/v1/menus/${encodeURIComponent(id)}`, body);
+  }
+
+  /** 禁用数据(PATCH /api/v1/menus/:id/disable) */
+  disableMenu(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/menus/${encodeURIComponent(id)}/disable`, null);
+  }
+
+  /** 启用数据(PATCH /api/v1/menus/:id/enable) */
+  enableMenu(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/menus/${encodeURIComponent(id)}/enable`, null);
+  }
+
+  /** 查询当前用户菜单树(GET /api/v1/pub/current/menutree) */
+  queryCurrentMenuTree(): Observable<ListResult<MenuTree>> {
+    return this.http.get<ListResult<MenuTree>>('/api/v1/pub/current/menutree');
+  }
+
+  /** 更新个人密码(PUT /api/v1/pub/current/password) */
+  updatePassword(body: UpdatePasswordParam): Observable<StatusResult> {
+    return this.http.put<StatusResult>('/api/v1/pub/current/password', body);
+  }
+
+  /** 获取当前用户信息(GET /api/v1/pub/current/user) */
+  getCurrentUser(): Observable<UserLoginInfo> {
+    return this.http.get<UserLoginInfo>('/api/v1/pub/current/user');
+  }
+
+  /** 用户登录(POST /api/v1/pub/login) */
+  login(body: LoginParam): Observable<LoginTokenInfo> {
+    return this.http
+      .post<LoginTokenInfo>('/api/v1/pub/login', body)
+      .pipe(tap((res) => this.tokenService.set({ token: res.access_token })));
+  }
+
+  /** 获取验证码图片(GET /api/v1/pub/login/captcha) */
+  getCaptchaPic(query?: GetCaptchaPicQuery): Observable<Blob> {
+    return this.http.get('/api/v1/pub/login/captcha', { params: toParams(query), responseType: 'blob' });
+  }
+
+  /** 获取验证码ID(GET /api/v1/pub/login/captchaid) */
+  getCaptchaID(): Observable<LoginCaptcha> {
+    return this.http.get<LoginCaptcha>('/api/v1/pub/login/captchaid');
+  }
+
+  /** 用户登出(POST /api/v1/pub/login/exit) */
+  logout(): Observable<StatusResult> {
+    return this.http.post<StatusResult>('/api/v1/pub/login/exit', null).pipe(tap(() => this.tokenService.clear()));
+  }
+
+  /** 刷新令牌(POST /api/v1/pub/refresh-token) */
+  refreshToken(): Observable<LoginTokenInfo> {
+    return this.http
+      .post<LoginTokenInfo>('/api/v1/pub/refresh-token', null)
+      .pipe(tap((res) => this.tokenService.set({ token: res.access_token })));
+  }
+
+  /** 导出菜单、动作、资源、角色及授权(GET /api/v1/rbac.export) */
+  exportRBAC(query?: ExportRBACQuery): Observable<Blob> {
+    return this.http.get('/api/v1/rbac.export', { params: toParams(query), responseType: 'blob' });
+  }
+
+  /** 按业务标识对比并导入菜单、动作、资源、角色及授权(POST /api/v1/rbac.import) */
+  importRBAC(body: RBACDocument, query?: ImportRBACQuery): Observable<RBACImportResult> {
+    return this.http.post<RBACImportResult>('/api/v1/rbac.import', body, { params: toParams(query) });
+  }
+
+  /** 查询数据(GET /api/v1/roles) */
+  queryRole(query?: QueryRoleQuery): Observable<ListResult<Role>> {
+    return this.http.get<ListResult<Role>>('/api/v1/roles', { params: toParams(query) });
+  }
+
+  /** 创建数据(POST /api/v1/roles) */
+  createRole(body: Role): Observable<IDResult> {
+    return this.http.post<IDResult>('/api/v1/roles', body);
+  }
+
+  /** 批量创建数据(POST /api/v1/roles.bulk/create) */
+  bulkCreateRole(body: BulkRoleCreateParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/roles.bulk/create', body);
+  }
+
+  /** 批量删除数据(POST /api/v1/roles.bulk/delete) */
+  bulkDeleteRole(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/roles.bulk/delete', body);
+  }
+
+  /** 批量禁用数据(POST /api/v1/roles.bulk/disable) */
+  bulkDisableRole(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/roles.bulk/disable', body);
+  }
+
+  /** 批量启用数据(POST /api/v1/roles.bulk/enable) */
+  bulkEnableRole(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/roles.bulk/enable', body);
+  }
+
+  /** 导出数据及菜单授权(GET /api/v1/roles.export) */
+  exportRole(query?: ExportRoleQuery): Observable<Blob> {
+    return this.http.get('/api/v1/roles.export', { params: toParams(query), responseType: 'blob' });
+  }
+
+  /** 查询选择数据(GET /api/v1/roles.select) */
+  queryRoleSelect(query?: QueryRoleSelectQuery): Observable<ListResult<Role>> {
+    return this.http.get<ListResult<Role>>('/api/v1/roles.select', { params: toParams(query) });
+  }
+
+  /** 删除数据(DELETE /api/v1/roles/:id) */
+  deleteRole(id: string): Observable<StatusResult> {
+    return this.http.delete<StatusResult>(`/api/v1/roles/${encodeURIComponent(id)}`);
+  }
+
+  /** 查询指定数据(GET /api/v1/roles/:id) */
+  getRole(id: string): Observable<Role> {
+    return this.http.get<Role>(`/api/v1/roles/${encodeURIComponent(id)}`);
+  }
+
+  /** 更新数据(PUT /api/v1/roles/:id) */
+  updateRole(id: string, body: Role): Observable<StatusResult> {
+    return this.http.put<StatusResult>(`/api/v1/roles/${encodeURIComponent(id)}`, body);
+  }
+
+  /** 禁用数据(PATCH /api/v1/roles/:id/disable) */
+  disableRole(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/roles/${encodeURIComponent(id)}/disable`, null);
+  }
+
+  /** 启用数据(PATCH /api/v1/roles/:id/enable) */
+  enableRole(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/roles/${encodeURIComponent(id)}/enable`, null);
+  }
+
+  /** 查询数据(GET /api/v1/tenants) */
+  queryTenant(query?: QueryTenantQuery): Observable<ListResult<Tenant>> {
+    return this.http.get<ListResult<Tenant>>('/api/v1/tenants', { params: toParams(query) });
+  }
+
+  /** 创建数据(同时开通租户管理员)(POST /api/v1/tenants) */
+  createTenant(body: Tenant): Observable<IDResult> {
+    return this.http.post<IDResult>('/api/v1/tenants', body);
+  }
+
+  /** 删除数据(DELETE /api/v1/tenants/:id) */
+  deleteTenant(id: string): Observable<StatusResult> {
+    return this.http.delete<StatusResult>(`/api/v1/tenants/${encodeURIComponent(id)}`);
+  }
+
+  /** 查询指定数据(GET /api/v1/tenants/:id) */
+  getTenant(id: string): Observable<Tenant> {
+    return this.http.get<Tenant>(`/api/v1/tenants/${encodeURIComponent(id)}`);
+  }
+
+  /** 更新数据(PUT /api/v1/tenants/:id) */
+  updateTenant(id: string, body: Tenant): Observable<StatusResult> {
+    return this.http.put<StatusResult>(`/api/v1/tenants/${encodeURIComponent(id)}`, body);
+  }
+
+  /** 禁用数据(PATCH /api/v1/tenants/:id/disable) */
+  disableTenant(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/tenants/${encodeURIComponent(id)}/disable`, null);
+  }
+
+  /** 启用数据(PATCH /api/v1/tenants/:id/enable) */
+  enableTenant(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/tenants/${encodeURIComponent(id)}/enable`, null);
+  }
+
+  /** 查询数据(GET /api/v1/users) */
+  queryUser(query?: QueryUserQuery): Observable<ListResult<UserShow>> {
+    return this.http.get<ListResult<UserShow>>('/api/v1/users', { params: toParams(query) });
+  }
+
+  /** 创建数据(POST /api/v1/users) */
+  createUser(body: User): Observable<IDResult> {
+    return this.http.post<IDResult>('/api/v1/users', body);
+  }
+
+  /** 批量创建数据(POST /api/v1/users.bulk/create) */
+  bulkCreateUser(body: BulkUserCreateParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/users.bulk/create', body);
+  }
+
+  /** 批量删除数据(POST /api/v1/users.bulk/delete) */
+  bulkDeleteUser(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/users.bulk/delete', body);
+  }
+
+  /** 批量禁用数据(POST /api/v1/users.bulk/disable) */
+  bulkDisableUser(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/users.bulk/disable', body);
+  }
+
+  /** 批量启用数据(POST /api/v1/users.bulk/enable) */
+  bulkEnableUser(body: BulkIDParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/users.bulk/enable', body);
+  }
+
+  /** 批量分配角色(POST /api/v1/users.bulk/roles) */
+  bulkAssignUserRoles(body: BulkUserRoleParam): Observable<BulkResult> {
+    return this.http.post<BulkResult>('/api/v1/users.bulk/roles', body);
+  }
+
+  /** 导出数据(GET /api/v1/users.export) */
+  exportUser(query?: ExportUserQuery): Observable<Blob> {
+    return this.http.get('/api/v1/users.export', { params: toParams(query), responseType: 'blob' });
+  }
+
+  /** 导入数据(POST /api/v1/users.import) */
+  importUser(file: File, query?: ImportUserQuery): Observable<ImportResult> {
+    const form = new FormData();
+    form.append('file', file);
+    return this.http.post<ImportResult>('/api/v1/users.import', form, { params: toParams(query) });
+  }
+
+  /** 删除数据(DELETE /api/v1/users/:id) */
+  deleteUser(id: string): Observable<StatusResult> {
+    return this.http.delete<StatusResult>(`/api/v1/users/${encodeURIComponent(id)}`);
+  }
+
+  /** 查询指定数据(GET /api/v1/users/:id) */
+  getUser(id: string): Observable<User> {
+    return this.http.get<User>(`/api/v1/users/${encodeURIComponent(id)}`);
+  }
+
+  /** 更新数据(PUT /api/v1/users/:id) */
+  updateUser(id: string, body: User): Observable<StatusResult> {
+    return this.http.put<StatusResult>(`/api/v1/users/${encodeURIComponent(id)}`, body);
+  }
+
+  /** 禁用数据(PATCH /api/v1/users/:id/disable) */
+  disableUser(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/users/${encodeURIComponent(id)}/disable`, null);
+  }
+
+  /** 启用数据(PATCH /api/v1/users/:id/enable) */
+  enableUser(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/users/${encodeURIComponent(id)}/enable`, null);
+  }
+
+  /** 查询数据(GET /api/v1/webhooks) */
+  queryWebhook(query?: QueryWebhookQuery): Observable<ListResult<Webhook>> {
+    return this.http.get<ListResult<Webhook>>('/api/v1/webhooks', { params: toParams(query) });
+  }
+
+  /** 创建数据(POST /api/v1/webhooks) */
+  createWebhook(body: Webhook): Observable<WebhookSecretResult> {
+    return this.http.post<WebhookSecretResult>('/api/v1/webhooks', body);
+  }
+
+  /** 查询死信投递记录(GET /api/v1/webhooks.deadletters) */
+  queryWebhookDeadLetter(query?: QueryWebhookDeadLetterQuery): Observable<ListResult<WebhookDelivery>> {
+    return this.http.get<ListResult<WebhookDelivery>>('/api/v1/webhooks.deadletters', { params: toParams(query) });
+  }
+
+  /** 删除数据(DELETE /api/v1/webhooks/:id) */
+  deleteWebhook(id: string): Observable<StatusResult> {
+    return this.http.delete<StatusResult>(`/api/v1/webhooks/${encodeURIComponent(id)}`);
+  }
+
+  /** 查询指定数据(GET /api/v1/webhooks/:id) */
+  getWebhook(id: string): Observable<Webhook> {
+    return this.http.get<Webhook>(`/api/v1/webhooks/${encodeURIComponent(id)}`);
+  }
+
+  /** 更新数据(PUT /api/v1/webhooks/:id) */
+  updateWebhook(id: string, body: Webhook): Observable<StatusResult> {
+    return this.http.put<StatusResult>(`/api/v1/webhooks/${encodeURIComponent(id)}`, body);
+  }
+
+  /** 查询投递记录(GET /api/v1/webhooks/:id/deliveries) */
+  queryWebhookDelivery(id: string, query?: QueryWebhookDeliveryQuery): Observable<ListResult<WebhookDelivery>> {
+    return this.http.get<ListResult<WebhookDelivery>>(`/api/v1/webhooks/${encodeURIComponent(id)}/deliveries`, { params: toParams(query) });
+  }
+
+  /** 重新投递(POST /api/v1/webhooks/:id/deliveries/:deliveryID/redeliver) */
+  redeliverWebhookDelivery(id: string, deliveryID: string): Observable<WebhookDelivery> {
+    return this.http.post<WebhookDelivery>(`/api/v1/webhooks/${encodeURIComponent(id)}/deliveries/${encodeURIComponent(deliveryID)}/redeliver`, null);
+  }
+
+  /** 禁用数据(PATCH /api/v1/webhooks/:id/disable) */
+  disableWebhook(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/webhooks/${encodeURIComponent(id)}/disable`, null);
+  }
+
+  /** 启用数据(PATCH /api/v1/webhooks/:id/enable) */
+  enableWebhook(id: string): Observable<StatusResult> {
+    return this.http.patch<StatusResult>(`/api/v1/webhooks/${encodeURIComponent(id)}/enable`, null);
+  }
+
+  /** 发送测试事件(POST /api/v1/webhooks/:id/ping) */
+  pingWebhook(id: string): Observable<WebhookDelivery> {
+    return this.http.post<WebhookDelivery>(`/api/v1/webhooks/${encodeURIComponent(id)}/ping`, null);
+  }
+
+  /** 重新生成签名密钥(POST /api/v1/webhooks/:id/secret) */
+  rotateWebhookSecret(id: string): Observable<WebhookSecretResult> {
+    return this.http.post<WebhookSecretResult>(`/api/v1/webhooks/${encodeURIComponent(id)}/secret`, null);
+  }
+
+  /** 存活检查(GET /healthz) */
+  live(): Observable<HealthResult> {
+    return this.http.get<HealthResult>('/healthz');
+  }
+
+  /** 就绪检查(依赖不可用时返回503)(GET /readyz) */
+  ready(): Observable<HealthResult> {
+    return this.http.get<HealthResult>('/readyz');
+  }
+}
@@ -0,0 +1,673 @@
+// Code generated by mag gen client. DO NOT EDIT.
+
+/** 对应服务端的schema.Audit */
+export interface Audit {
+  id: string;
+  entity_type: string;
+  entity_id: string;
+  action: string;
+  actor_id: string;
+  trace_id: string;
+  changes: AuditChange[];
+  created_at: string;
+}
+
+/** 对应服务端的schema.AuditChange */
+export interface AuditChange {
+  field: string;
+  before?: unknown;
+  after?: unknown;
+}
+
+/** 对应服务端的schema.BulkIDParam */
+export interface BulkIDParam {
+  ids: string[];
+}
+
+/** 对应服务端的schema.BulkItemResult */
+export interface BulkItemResult {
+  index: number;
+  id?: string;
+  status: string;
+  error?: ErrorItem;
+}
+
+/** 对应服务端的schema.BulkMenuCreateParam */
+export interface BulkMenuCreateParam {
+  items: Menu[];
+}
+
+/** 对应服务端的schema.BulkResult */
+export interface BulkResult {
+  total: number;
+  succeeded: number;
+  failed: number;
+  items: BulkItemResult[];
+}
+
+/** 对应服务端的schema.BulkRoleCreateParam */
+export interface BulkRoleCreateParam {
+  items: Role[];
+}
+
+/** 对应服务端的schema.BulkUserCreateParam */
+export interface BulkUserCreateParam {
+  items: User[];
+}
+
+/** 对应服务端的schema.BulkUserRoleParam */
+export interface BulkUserRoleParam {
+  ids: string[];
+  role_ids: string[];
+  replace: boolean;
+}
+
+/** 对应服务端的schema.Demo */
+export interface Demo {
+  id: string;
+  code: string;
+  name: string;
+  memo: string;
+  status: number;
+  creator: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/** 对应服务端的schema.ErrorItem */
+export interface ErrorItem {
+  code: number;
+  id?: string;
+  message: string;
+}
+
+/** 对应服务端的schema.ErrorResult */
+export interface ErrorResult {
+  error: ErrorItem;
+}
+
+/** 对应服务端的schema.FieldError */
+export interface FieldError {
+  field: string;
+  rule: string;
+  message: string;
+}
+
+/** 对应服务端的schema.HealthCheck */
+export interface HealthCheck {
+  name: string;
+  status: string;
+  error?: string;
+  duration_ms: number;
+}
+
+/** 对应服务端的schema.HealthResult */
+export interface HealthResult {
+  status: string;
+  checks?: HealthCheck[];
+}
+
+/** 对应服务端的schema.IDResult */
+export interface IDResult {
+  id: string;
+}
+
+/** 对应服务端的schema.ImportResult */
+export interface ImportResult {
+  dry_run: boolean;
+  applied: boolean;
+  total: number;
+  created: number;
+  updated: number;
+  failed: number;
+  rows: ImportRowResult[];
+}
+
+/** 对应服务端的schema.ImportRowResult */
+export interface ImportRowResult {
+  row: number;
+  id?: string;
+  key: string;
+  action?: string;
+  status: string;
+  errors?: FieldError[];
+}
+
+/** 对应服务端的schema.Log */
+export interface Log {
+  id: string;
+  level: string;
+  message: string;
+  trace_id: string;
+  user_id: string;
+  span_title: string;
+  span_function: string;
+  data: string;
+  version: string;
+  created_at: string;
+}
+
+/** 对应服务端的schema.LoginCaptcha */
+export interface LoginCaptcha {
+  captcha_id: string;
+}
+
+/** 对应服务端的schema.LoginParam */
+export interface LoginParam {
+  username: string;
+  password: string;
+  captcha_id: string;
+  captcha_code: string;
+}
+
+/** 对应服务端的schema.LoginTokenInfo */
+export interface LoginTokenInfo {
+  access_token: string;
+  token_type: string;
+  expires_at: number;
+}
+
+/** 对应服务端的schema.Menu */
+export interface Menu {
+  id: string;
+  name: string;
+  sequence: number;
+  icon: string;
+  router: string;
+  parent_id: string;
+  parent_path: string;
+  show_status: number;
+  status: number;
+  memo: string;
+  creator: string;
+  created_at: string;
+  updated_at: string;
+  actions: MenuAction[];
+}
+
+/** 对应服务端的schema.MenuAction */
+export interface MenuAction {
+  id: string;
+  menu_id: string;
+  code: string;
+  name: string;
+  resources: MenuActionResource[];
+}
+
+/** 对应服务端的schema.MenuActionResource */
+export interface MenuActionResource {
+  id: string;
+  action_id: string;
+  method: string;
+  path: string;
+}
+
+/** 对应服务端的schema.MenuTree */
+export interface MenuTree {
+  id: string;
+  name: string;
+  icon: string;
+  router: string;
+  parent_id: string;
+  parent_path: string;
+  sequence: number;
+  show_status: number;
+  status: number;
+  actions: MenuAction[];
+  children?: MenuTree[];
+}
+
+/** 对应服务端的schema.PaginationResult */
+export interface PaginationResult {
+  total: number;
+  current: number;
+  pageSize: number;
+  nextCursor?: string;
+  prevCursor?: string;
+}
+
+/** 对应服务端的schema.ProblemResult */
+export interface ProblemResult {
+  type: string;
+  title: string;
+  status: number;
+  detail: string;
+  code: number;
+  trace_id?: string;
+  errors?: FieldError[];
+}
+
+/** 对应服务端的schema.RBACAction */
+export interface RBACAction {
+  code: string;
+  name: string;
+  resources?: RBACResource[];
+}
+
+/** 对应服务端的schema.RBACChange */
+export interface RBACChange {
+  kind: string;
+  op: string;
+  key: string;
+  from?: string;
+  to?: string;
+}
+
+/** 对应服务端的schema.RBACDocument */
+export interface RBACDocument {
+  menus: RBACMenu[];
+  roles: RBACRole[];
+}
+
+/** 对应服务端的schema.RBACGrant */
+export interface RBACGrant {
+  menu: string;
+  actions: string[];
+}
+
+/** 对应服务端的schema.RBACImportResult */
+export interface RBACImportResult {
+  dry_run: boolean;
+  applied: boolean;
+  created: number;
+  updated: number;
+  deleted: number;
+  changes: RBACChange[];
+}
+
+/** 对应服务端的schema.RBACMenu */
+export interface RBACMenu {
+  name: string;
+  icon?: string;
+  router?: string;
+  sequence: number;
+  show_status: number;
+  status: number;
+  memo?: string;
+  actions?: RBACAction[];
+  children?: RBACMenu[];
+}
+
+/** 对应服务端的schema.RBACResource */
+export interface RBACResource {
+  method: string;
+  path: string;
+}
+
+/** 对应服务端的schema.RBACRole */
+export interface RBACRole {
+  name: string;
+  sequence: number;
+  status: number;
+  memo?: string;
+  grants?: RBACGrant[];
+}
+
+/** 对应服务端的config.ReloadResult */
+export interface ReloadResult {
+  applied: string[];
+  restart_required: string[];
+}
+
+/** 对应服务端的schema.Role */
+export interface Role {
+  id: string;
+  name: string;
+  sequence: number;
+  memo: string;
+  status: number;
+  creator: string;
+  created_at: string;
+  updated_at: string;
+  role_menus: RoleMenu[];
+}
+
+/** 对应服务端的schema.RoleMenu */
+export interface RoleMenu {
+  id: string;
+  role_id: string;
+  menu_id: string;
+  action_id: string;
+}
+
+/** 对应服务端的schema.StatusResult */
+export interface StatusResult {
+  status: string;
+}
+
+/** 对应服务端的schema.Tenant */
+export interface Tenant {
+  id: string;
+  code: string;
+  name: string;
+  domain: string;
+  status: number;
+  memo: string;
+  admin_id: string;
+  creator: string;
+  created_at: string;
+  updated_at: string;
+  admin?: TenantAdmin;
+}
+
+/** 对应服务端的schema.TenantAdmin */
+export interface TenantAdmin {
+  user_name: string;
+  real_name: string;
+  password: string;
+  phone: string;
+  email: string;
+}
+
+/** 对应服务端的schema.UpdatePasswordParam */
+export interface UpdatePasswordParam {
+  old_password: string;
+  new_password: string;
+}
+
+/** 对应服务端的schema.User */
+export interface User {
+  id: string;
+  user_name: string;
+  real_name: string;
+  password: string;
+  phone: string;
+  email: string;
+  status: number;
+  creator: string;
+  created_at: string;
+  user_roles: UserRole[];
+}
+
+/** 对应服务端的schema.UserLoginInfo */
+export interface UserLoginInfo {
+  user_id: string;
+  username: string;
+  real_name: string;
+  roles: Role[];
+}
+
+/** 对应服务端的schema.UserRole */
+export interface UserRole {
+  id: string;
+  user_id: string;
+  role_id: string;
+}
+
+/** 对应服务端的schema.UserShow */
+export interface UserShow {
+  id: string;
+  user_name: string;
+  real_name: string;
+  phone: string;
+  email: string;
+  status: number;
+  created_at: string;
+  roles: Role[];
+}
+
+/** 对应服务端的schema.Webhook */
+export interface Webhook {
+  id: string;
+  name: string;
+  url: string;
+  secret: string;
+  events: string[];
+  status: number;
+  memo: string;
+  creator: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/** 对应服务端的schema.WebhookDelivery */
+export interface WebhookDelivery {
+  id: string;
+  webhook_id: string;
+  event_id: string;
+  event_type: string;
+  payload: unknown;
+  status: number;
+  attempts: number;
+  response_status: number;
+  response_body: string;
+  last_error: string;
+  next_retry_at: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/** 对应服务端的schema.WebhookSecretResult */
+export interface WebhookSecretResult {
+  id: string;
+  secret: string;
+}
+
+/** 列表数据(分页查询时包含分页信息) */
+export interface ListResult<T> {
+  list: T[];
+  pagination?: PaginationResult;
+}
+
+/** 通用过滤条件(filter[key][op]=value，未指定op时为eq) */
+export interface QueryFilter {
+  key: string;
+  op?: string;
+  value: string;
+}
+
+/** QueryAudit的查询参数 */
+export interface QueryAuditQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  entityType?: string;
+  entityID?: string;
+  actorID?: string;
+  action?: string;
+  startTime?: string;
+  endTime?: string;
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+}
+
+/** QueryDemo的查询参数 */
+export interface QueryDemoQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  queryValue?: string;
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+}
+
+/** ExportDemo的查询参数 */
+export interface ExportDemoQuery {
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+  queryValue?: string;
+  status?: number;
+  format?: string;
+}
+
+/** QueryLog的查询参数 */
+export interface QueryLogQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  level?: string[];
+  traceID?: string;
+  userID?: string;
+  queryValue?: string;
+  startTime?: string;
+  endTime?: string;
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+}
+
+/** QueryMenu的查询参数 */
+export interface QueryMenuQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  queryValue?: string;
+  showStatus?: number;
+  status?: number;
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+}
+
+/** QueryMenuTree的查询参数 */
+export interface QueryMenuTreeQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  queryValue?: string;
+  showStatus?: number;
+  status?: number;
+}
+
+/** GetCaptchaPic的查询参数 */
+export interface GetCaptchaPicQuery {
+  id?: string;
+  reload?: string;
+}
+
+/** ExportRBAC的查询参数 */
+export interface ExportRBACQuery {
+  format?: string;
+}
+
+/** ImportRBAC的查询参数 */
+export interface ImportRBACQuery {
+  dryRun?: boolean;
+  prune?: boolean;
+}
+
+/** QueryRole的查询参数 */
+export interface QueryRoleQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  queryValue?: string;
+  status?: number;
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+}
+
+/** ExportRole的查询参数 */
+export interface ExportRoleQuery {
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+  queryValue?: string;
+  status?: number;
+  format?: string;
+}
+
+/** QueryRoleSelect的查询参数 */
+export interface QueryRoleSelectQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  queryValue?: string;
+  status?: number;
+}
+
+/** QueryTenant的查询参数 */
+export interface QueryTenantQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  code?: string;
+  queryValue?: string;
+  status?: number;
+}
+
+/** QueryUser的查询参数 */
+export interface QueryUserQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  userName?: string;
+  queryValue?: string;
+  status?: number;
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+  roleIDs?: string;
+}
+
+/** ExportUser的查询参数 */
+export interface ExportUserQuery {
+  filter?: QueryFilter[];
+  sort?: string;
+  fields?: string;
+  userName?: string;
+  queryValue?: string;
+  status?: number;
+  roleIDs?: string;
+  format?: string;
+}
+
+/** ImportUser的查询参数 */
+export interface ImportUserQuery {
+  dryRun?: boolean;
+}
+
+/** QueryWebhook的查询参数 */
+export interface QueryWebhookQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  queryValue?: string;
+  status?: number;
+}
+
+/** QueryWebhookDeadLetter的查询参数 */
+export interface QueryWebhookDeadLetterQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  eventType?: string;
+  status?: number;
+}
+
+/** QueryWebhookDelivery的查询参数 */
+export interface QueryWebhookDeliveryQuery {
+  current?: number;
+  pageSize?: number;
+  cursor?: string;
+  useCursor?: boolean;
+  noCount?: boolean;
+  eventType?: string;
+  status?: number;
+}
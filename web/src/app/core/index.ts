@@ -2,3 +2,5 @@ export * from './i18n/i18n.service';
 export * from './module-import-guard';
 export * from './net/default.interceptor';
 export * from './startup/startup.service';
+export * from './api/models';
+export * from './api/api.service';
@@ -112,7 +112,8 @@ export class DefaultInterceptor implements HttpInterceptor {
     // 统一加上服务端前缀
     let url = req.url;
     if (!url.startsWith('https://') && !url.startsWith('http://')) {
-      url = environment.SERVER_URL + url;
+      // 接口服务使用以/开头的路径，避免与前缀的/重复
+      url = url.startsWith('/') ? environment.SERVER_URL.replace(/\/$/, '') + url : environment.SERVER_URL + url;
     }
 
     const newReq = req.clone({ url });
@@ -1,15 +1,30 @@
 import { HttpClient } from '@angular/common/http';
 import { Inject, Injectable } from '@angular/core';
 import { ACLService } from '@delon/acl';
-import { ALAIN_I18N_TOKEN, MenuService, SettingsService, TitleService } from '@delon/theme';
+import { DA_SERVICE_TOKEN, ITokenService } from '@delon/auth';
+import { ALAIN_I18N_TOKEN, Menu, MenuService, SettingsService, TitleService } from '@delon/theme';
 import { TranslateService } from '@ngx-translate/core';
 import { NzIconService } from 'ng-zorro-antd/icon';
-import { zip } from 'rxjs';
-import { catchError } from 'rxjs/operators';
+import { Observable, of, zip } from 'rxjs';
+import { catchError, map } from 'rxjs/operators';
 import { ICONS } from '../../../style-icons';
 import { ICONS_AUTO } from '../../../style-icons-auto';
+import { ApiService } from '../api/api.service';
+import { MenuTree, UserLoginInfo } from '../api/models';
 import { I18NService } from '../i18n/i18n.service';
 
+/** 将服务端的菜单树转换为导航菜单(仅包含显示的菜单) */
+function toMenus(trees: MenuTree[]): Menu[] {
+  return (trees || [])
+    .filter((t) => t.show_status === 1)
+    .map((t) => ({
+      text: t.name,
+      link: t.router || undefined,
+      icon: t.icon ? `anticon anticon-${t.icon}` : undefined,
+      children: t.children && t.children.length > 0 ? toMenus(t.children) : undefined,
+    }));
+}
+
 /**
  * 用于应用启动时
  * 一般用来获取应用所需要的基础数据等
@@ -25,15 +40,30 @@ export class StartupService {
     private aclService: ACLService,
     private titleService: TitleService,
     private httpClient: HttpClient,
+    private api: ApiService,
+    @Inject(DA_SERVICE_TOKEN) private tokenService: ITokenService,
   ) {
     iconSrv.addIcon(...ICONS_AUTO, ...ICONS);
   }
 
+  /** 已登录时获取当前用户及其菜单树，未登录或令牌失效时返回空 */
+  private loadCurrent(): Observable<[UserLoginInfo, MenuTree[]] | null> {
+    const token = this.tokenService.get();
+    if (!token || !token.token) {
+      return of(null);
+    }
+    return zip(this.api.getCurrentUser(), this.api.queryCurrentMenuTree().pipe(map((res) => res.list))).pipe(catchError(() => of(null)));
+  }
+
   load(): Promise<any> {
     // only works with promises
     // https://github.com/angular/angular/issues/15088
     return new Promise((resolve) => {
-      zip(this.httpClient.get(`assets/tmp/i18n/${this.i18n.defaultLang}.json`), this.httpClient.get('assets/tmp/app-data.json'))
+      zip(
+        this.httpClient.get(`assets/tmp/i18n/${this.i18n.defaultLang}.json`),
+        this.httpClient.get('assets/tmp/app-data.json'),
+        this.loadCurrent(),
+      )
         .pipe(
           // 接收其他拦截器后产生的异常消息
           catchError((res) => {
@@ -43,7 +73,7 @@ export class StartupService {
           }),
         )
         .subscribe(
-          ([langData, appData]) => {
+          ([langData, appData, current]) => {
             // setting language data
             this.translate.setTranslation(this.i18n.defaultLang, langData);
             this.translate.setDefaultLang(this.i18n.defaultLang);
@@ -52,12 +82,17 @@ export class StartupService {
             const res: any = appData;
             // 应用信息：包括站点名、描述、年份
             this.settingService.setApp(res.app);
-            // 用户信息：包括姓名、头像、邮箱地址
-            this.settingService.setUser(res.user);
-            // ACL：设置权限为全量
+            // 用户信息及菜单：已登录时使用当前用户及其授权的菜单
+            if (current) {
+              const [user, menus] = current;
+              this.settingService.setUser({ ...res.user, name: user.real_name || user.username, email: '' });
+              this.menuService.add(toMenus(menus));
+            } else {
+              this.settingService.setUser(res.user);
+              this.menuService.add(res.menu);
+            }
+            // ACL：设置权限为全量(接口权限由服务端校验)
             this.aclService.setFull(true);
-            // 初始化菜单
-            this.menuService.add(res.menu);
             // 设置页面标题的后缀
             this.titleService.default = '';
             this.titleService.suffix = res.app.name;
@@ -1,33 +1,33 @@
-import { Component, OnInit, Inject, ChangeDetectionStrategy } from '@angular/core';
+import { Component, Inject, ChangeDetectionStrategy } from '@angular/core';
 import { Router } from '@angular/router';
 import { SettingsService } from '@delon/theme';
 import { DA_SERVICE_TOKEN, ITokenService } from '@delon/auth';
+import { ApiService } from '@core';
+import { finalize } from 'rxjs/operators';
 
 @Component({
   selector: 'layout-pro-user',
   templateUrl: 'user.component.html',
   changeDetection: ChangeDetectionStrategy.OnPush
 })
-export class LayoutProWidgetUserComponent implements OnInit {
+export class LayoutProWidgetUserComponent {
   constructor(
     public settings: SettingsService,
     private router: Router,
     @Inject(DA_SERVICE_TOKEN) private tokenService: ITokenService,
+    private api: ApiService,
   ) {}
 
-  ngOnInit(): void {
-    // mock
-    const token = this.tokenService.get() || {
-      token: 'nothing',
-      name: 'Admin',
-      avatar: './assets/logo-color.svg',
-      email: 'cipchk@qq.com',
-    };
-    this.tokenService.set(token);
-  }
-
+  /** 登出(服务端使令牌失效，请求失败时也清除本地令牌) */
   logout() {
-    this.tokenService.clear();
-    this.router.navigateByUrl(this.tokenService.login_url);
+    this.api
+      .logout()
+      .pipe(
+        finalize(() => {
+          this.tokenService.clear();
+          this.router.navigateByUrl(this.tokenService.login_url);
+        }),
+      )
+      .subscribe({ error: () => {} });
   }
 }
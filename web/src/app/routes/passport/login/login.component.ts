@@ -1,7 +1,7 @@
 import { Component, Inject, OnDestroy, Optional } from '@angular/core';
 import { FormBuilder, FormGroup, Validators } from '@angular/forms';
 import { Router } from '@angular/router';
-import { StartupService } from '@core';
+import { ApiService, ErrorResult, StartupService } from '@core';
 import { ReuseTabService } from '@delon/abc/reuse-tab';
 import { DA_SERVICE_TOKEN, ITokenService, SocialOpenType, SocialService } from '@delon/auth';
 import { SettingsService, _HttpClient } from '@delon/theme';
@@ -29,15 +29,18 @@ export class UserLoginComponent implements OnDestroy {
     private startupSrv: StartupService,
     public http: _HttpClient,
     public msg: NzMessageService,
+    private api: ApiService,
   ) {
     this.form = fb.group({
-      userName: [null, [Validators.required, Validators.pattern(/^(admin|user)$/)]],
-      password: [null, [Validators.required, Validators.pattern(/^(ng\-alain\.com)$/)]],
+      userName: [null, [Validators.required]],
+      password: [null, [Validators.required]],
+      captchaCode: [null, [Validators.required]],
       mobile: [null, [Validators.required, Validators.pattern(/^1\d{10}$/)]],
       captcha: [null, [Validators.required]],
       remember: [true],
     });
     modalSrv.closeAll();
+    this.refreshCaptcha();
   }
 
   // #region fields
@@ -54,10 +57,38 @@ export class UserLoginComponent implements OnDestroy {
   get captcha() {
     return this.form.controls.captcha;
   }
+  get captchaCode() {
+    return this.form.controls.captchaCode;
+  }
   form: FormGroup;
   error = '';
   type = 0;
 
+  // #region login captcha
+
+  captchaID = '';
+  captchaURL = '';
+
+  /** 获取新的登录验证码(图片以对象URL显示，更新时释放上一张) */
+  refreshCaptcha() {
+    this.api.getCaptchaID().subscribe((res) => {
+      this.captchaID = res.captcha_id;
+      this.api.getCaptchaPic({ id: res.captcha_id }).subscribe((pic) => {
+        this.revokeCaptcha();
+        this.captchaURL = URL.createObjectURL(pic);
+      });
+    });
+  }
+
+  private revokeCaptcha() {
+    if (this.captchaURL) {
+      URL.revokeObjectURL(this.captchaURL);
+      this.captchaURL = '';
+    }
+  }
+
+  // #endregion
+
   // #region get captcha
 
   count = 0;
@@ -93,7 +124,9 @@ export class UserLoginComponent implements OnDestroy {
       this.userName.updateValueAndValidity();
       this.password.markAsDirty();
       this.password.updateValueAndValidity();
-      if (this.userName.invalid || this.password.invalid) {
+      this.captchaCode.markAsDirty();
+      this.captchaCode.updateValueAndValidity();
+      if (this.userName.invalid || this.password.invalid || this.captchaCode.invalid) {
         return;
       }
     } else {
@@ -104,34 +137,39 @@ export class UserLoginComponent implements OnDestroy {
       if (this.mobile.invalid || this.captcha.invalid) {
         return;
       }
+      // 服务端暂未提供手机号登录
+      this.error = '暂不支持手机号登录';
+      return;
     }
 
-    // 默认配置中对所有HTTP请求都会强制 [校验](https://ng-alain.com/auth/getting-started) 用户 Token
-    // 然一般来说登录请求不需要校验，因此可以在请求URL加上：`/login?_allow_anonymous=true` 表示不触发用户 Token 校验
-    this.http
-      .post('/login/account?_allow_anonymous=true', {
-        type: this.type,
-        userName: this.userName.value,
+    // 登录成功后由接口服务保存访问令牌
+    this.api
+      .login({
+        username: this.userName.value,
         password: this.password.value,
+        captcha_id: this.captchaID,
+        captcha_code: this.captchaCode.value,
       })
-      .subscribe((res: any) => {
-        if (res.msg !== 'ok') {
-          this.error = res.msg;
-          return;
-        }
-        // 清空路由复用信息
-        this.reuseTabService.clear();
-        // 设置用户Token信息
-        this.tokenService.set(res.user);
-        // 重新获取 StartupService 内容，我们始终认为应用信息一般都会受当前用户授权范围而影响
-        this.startupSrv.load().then(() => {
-          let url = this.tokenService.referrer.url || '/';
-          if (url.includes('/passport')) {
-            url = '/';
-          }
-          this.router.navigateByUrl(url);
-        });
-      });
+      .subscribe(
+        () => {
+          // 清空路由复用信息
+          this.reuseTabService.clear();
+          // 重新获取 StartupService 内容，我们始终认为应用信息一般都会受当前用户授权范围而影响
+          this.startupSrv.load().then(() => {
+            let url = this.tokenService.referrer.url || '/';
+            if (url.includes('/passport')) {
+              url = '/';
+            }
+            this.router.navigateByUrl(url);
+          });
+        },
+        (err) => {
+          const body = err.error as ErrorResult;
+          this.error = (body && body.error && body.error.message) || err.message;
+          this.captchaCode.reset();
+          this.refreshCaptcha();
+        },
+      );
   }
 
   // #region social
@@ -182,5 +220,6 @@ export class UserLoginComponent implements OnDestroy {
     if (this.interval$) {
       clearInterval(this.interval$);
     }
+    this.revokeCaptcha();
   }
 }